@@ -0,0 +1,65 @@
+package lunchmoney
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// APIVersion is a version of the Lunch Money API, such as "v1". It is the
+// first segment of the API's paths.
+type APIVersion string
+
+// APIv1 is the version of the API the client uses unless told otherwise.
+const APIv1 APIVersion = "v1"
+
+// WithAPIVersion makes the client use version v of the API for the given
+// endpoints, named by the first segment of their path after the version,
+// such as "transactions" or "tags", or for every endpoint if none are given.
+// Endpoints can so be moved to a new version one at a time. The request and
+// response types of the endpoints must be those of version v.
+func WithAPIVersion(v APIVersion, endpoints ...string) Option {
+	return func(c *Client) error {
+		if v == "" || strings.Contains(string(v), "/") {
+			return fmt.Errorf("invalid API version %q", v)
+		}
+
+		if len(endpoints) == 0 {
+			c.apiVersion = v
+			return nil
+		}
+
+		// Clone so clients derived with With do not share overrides.
+		versions := maps.Clone(c.endpointVersions)
+		if versions == nil {
+			versions = map[string]APIVersion{}
+		}
+		for _, e := range endpoints {
+			e = strings.Trim(e, "/")
+			if e == "" || strings.Contains(e, "/") {
+				return fmt.Errorf("invalid endpoint %q", e)
+			}
+			versions[e] = v
+		}
+		c.endpointVersions = versions
+		return nil
+	}
+}
+
+// endpoint returns the path of the API endpoint described by format and
+// args, such as "transactions/%d", under the API version the client uses for
+// it.
+func (c *Client) endpoint(format string, args ...any) string {
+	p := format
+	if len(args) > 0 {
+		p = fmt.Sprintf(format, args...)
+	}
+
+	name, _, _ := strings.Cut(p, "/")
+	v, ok := c.endpointVersions[name]
+	if !ok {
+		v = c.apiVersion
+	}
+
+	return "/" + string(v) + "/" + p
+}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/Rhymond/go-money"
-	"github.com/go-playground/validator/v10"
 )
 
 // AssetsResponse is a response to an asset lookup.
@@ -15,84 +14,165 @@ type AssetsResponse struct {
 	Assets []*Asset `json:"assets"`
 }
 
+// AssetType is the type_name of an asset.
+type AssetType string
+
+// Asset types accepted and returned by the API.
+const (
+	AssetCash                 AssetType = "cash"
+	AssetCredit               AssetType = "credit"
+	AssetInvestment           AssetType = "investment"
+	AssetRealEstate           AssetType = "real estate"
+	AssetLoan                 AssetType = "loan"
+	AssetVehicle              AssetType = "vehicle"
+	AssetCryptocurrency       AssetType = "cryptocurrency"
+	AssetEmployeeCompensation AssetType = "employee compensation"
+	AssetOtherLiability       AssetType = "other liability"
+	AssetOtherAsset           AssetType = "other asset"
+)
+
+// IsLiability reports whether balances of this type are owed rather than
+// owned.
+func (t AssetType) IsLiability() bool {
+	switch t {
+	case AssetCredit, AssetLoan, AssetOtherLiability:
+		return true
+	default:
+		return false
+	}
+}
+
 // Asset is a single LM asset.
 type Asset struct {
-	ID              int64     `json:"id"`
-	TypeName        string    `json:"type_name"`
+	ID              AssetID   `json:"id"`
+	TypeName        AssetType `json:"type_name"`
 	SubtypeName     string    `json:"subtype_name"`
 	Name            string    `json:"name"`
 	DisplayName     string    `json:"display_name"`
-	Balance         string    `json:"balance"`
-	BalanceAsOf     time.Time `json:"balance_as_of"`
-	ToBase          float64   `json:"to_base"` // the balance converted to the user's primary currency
+	Balance         Decimal   `json:"balance"`
+	BalanceAsOf     Timestamp `json:"balance_as_of"`
+	ToBase          Decimal   `json:"to_base"` // the balance converted to the user's primary currency
 	Currency        string    `json:"currency"`
 	Status          string    `json:"status"`
 	InstitutionName string    `json:"institution_name"`
-	CreatedAt       time.Time `json:"created_at"`
+	CreatedAt       Timestamp `json:"created_at"`
+
+	// Extra holds the fields of the asset the client does not know about
+	// yet, so they survive re-encoding.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a, keeping unknown fields in Extra.
+func (a *Asset) UnmarshalJSON(data []byte) error {
+	type plain Asset
+	extra, err := decodeExtra(data, (*plain)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a together with the fields in Extra.
+func (a Asset) MarshalJSON() ([]byte, error) {
+	type plain Asset
+	return encodeExtra(plain(a), a.Extra)
+}
+
+// String describes the asset for logs and debugging, such as
+// "asset 7 Wallet 20.00 USD".
+func (a *Asset) String() string {
+	return fmt.Sprintf("asset %d %s %s %s", a.ID, a.Name, a.Balance, strings.ToUpper(a.Currency))
 }
 
 // ParsedAmount converts the asset's balance and currency into a money.Money object.
 // This provides a convenient way to work with the asset's value using the go-money library's
 // currency handling capabilities. Returns an error if the balance cannot be parsed.
 func (a *Asset) ParsedAmount() (*money.Money, error) {
-	return ParseCurrency(a.Balance, a.Currency)
+	return a.Balance.Money(a.Currency), nil
+}
+
+// ToBaseMoney is like Transaction.ToBaseMoney for the asset's balance.
+func (a *Asset) ToBaseMoney(primaryCurrency string) *money.Money {
+	return a.ToBase.Money(primaryCurrency)
 }
 
 // GetAssets retrieves all assets from the Lunch Money API.
 // It returns a slice of Asset objects containing information about each asset,
 // including balance, institution, and status details. Returns an error if the request fails.
 func (c *Client) GetAssets(ctx context.Context) ([]*Asset, error) {
-	validate := validator.New()
 	options := map[string]string{}
 
-	body, err := c.Get(ctx, "/v1/assets", options)
-	if err != nil {
-		return nil, fmt.Errorf("get assets: %w", err)
-	}
-
 	resp := &AssetsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	if err := validate.Struct(resp); err != nil {
-		return nil, err
+	if err := c.getJSON(ctx, c.endpoint("assets"), options, resp); err != nil {
+		return nil, fmt.Errorf("get assets: %w", err)
 	}
 
-	return resp.Assets, nil
+	return validateRecords(ctx, c, resp.Assets)
 }
 
 // UpdateAsset contains the fields that can be updated for an existing asset.
 // Only non-nil fields will be sent in the update request.
 type UpdateAsset struct {
-	TypeName             *string `json:"type_name,omitempty"`
-	SubtypeName          *string `json:"subtype_name,omitempty"`
-	Name                 *string `json:"name,omitempty"`
-	DisplayName          *string `json:"display_name,omitempty"`
-	Balance              *string `json:"balance,omitempty"`
-	BalanceAsOf          *string `json:"balance_as_of,omitempty"`
-	Currency             *string `json:"currency,omitempty"`
-	InstitutionName      *string `json:"institution_name,omitempty"`
-	ClosedOn             *string `json:"closed_on,omitempty"`
-	ExcludedTransactions *bool   `json:"excluded_transactions,omitempty"`
+	TypeName             *AssetType `json:"type_name,omitempty" validate:"omitnil,oneof=cash credit investment 'real estate' loan vehicle cryptocurrency 'employee compensation' 'other liability' 'other asset'"`
+	SubtypeName          *string    `json:"subtype_name,omitempty"`
+	Name                 *string    `json:"name,omitempty"`
+	DisplayName          *string    `json:"display_name,omitempty"`
+	Balance              *Decimal   `json:"balance,omitempty"`
+	BalanceAsOf          *string    `json:"balance_as_of,omitempty"`
+	Currency             *string    `json:"currency,omitempty"`
+	InstitutionName      *string    `json:"institution_name,omitempty"`
+	ClosedOn             *string    `json:"closed_on,omitempty"`
+	ExcludedTransactions *bool      `json:"excluded_transactions,omitempty"`
 }
 
 // UpdateAsset modifies an existing asset with the specified ID using the provided fields.
 // It returns the updated asset information or an error if the update fails.
 // Only fields that are non-nil in the asset parameter will be updated.
-func (c *Client) UpdateAsset(ctx context.Context, id int64, asset *UpdateAsset) (*Asset, error) {
-	validate := validator.New()
-	if err := validate.Struct(asset); err != nil {
+func (c *Client) UpdateAsset(ctx context.Context, id AssetID, asset *UpdateAsset) (*Asset, error) {
+	if err := c.validateRequest(ctx, asset); err != nil {
 		return nil, err
 	}
 
-	body, err := c.Put(ctx, fmt.Sprintf("/v1/assets/%d", id), asset)
+	body, err := c.Put(ctx, c.endpoint("assets/%d", id), asset)
 	if err != nil {
 		return nil, fmt.Errorf("put asset %d: %w", id, err)
 	}
 
 	resp := &Asset{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := c.decode(body, resp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CreateAsset holds the fields of a new manually-managed asset.
+type CreateAsset struct {
+	TypeName        AssetType `json:"type_name" validate:"required,oneof=cash credit investment 'real estate' loan vehicle cryptocurrency 'employee compensation' 'other liability' 'other asset'"`
+	SubtypeName     string    `json:"subtype_name,omitempty"`
+	Name            string    `json:"name" validate:"required,max=45"`
+	DisplayName     string    `json:"display_name,omitempty"`
+	Balance         Decimal   `json:"balance"`
+	BalanceAsOf     *string   `json:"balance_as_of,omitempty"`
+	Currency        string    `json:"currency,omitempty"`
+	InstitutionName string    `json:"institution_name,omitempty"`
+	ClosedOn        *string   `json:"closed_on,omitempty"`
+	// ExcludeTransactions hides the asset from the account picker when
+	// adding transactions.
+	ExcludeTransactions bool `json:"exclude_transactions,omitempty"`
+}
+
+// CreateAsset creates a manually-managed asset and returns it.
+func (c *Client) CreateAsset(ctx context.Context, asset *CreateAsset) (*Asset, error) {
+	if err := c.validateRequest(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	body, err := c.Post(ctx, c.endpoint("assets"), asset)
+	if err != nil {
+		return nil, fmt.Errorf("create asset: %w", err)
+	}
+
+	resp := &Asset{}
+	if err := c.decode(body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
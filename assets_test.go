@@ -0,0 +1,68 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAssets(t *testing.T) {
+	data, err := os.ReadFile("testdata/assets.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	assets, err := client.GetAssets(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, assets)
+
+	assert.Equal(t, AssetCash, assets[0].TypeName)
+	m, err := assets[0].ParsedAmount()
+	require.NoError(t, err)
+	assert.Equal(t, int64(120101), m.Amount())
+	assert.Equal(t, "CAD", m.Currency().Code)
+}
+
+func TestUpdateAssetTypeValidation(t *testing.T) {
+	client, err := NewClient("test-token", WithDryRun(true))
+	require.NoError(t, err)
+
+	_, err = client.UpdateAsset(context.Background(), 1, &UpdateAsset{TypeName: Ptr(AssetType("boat"))})
+	require.Error(t, err)
+
+	_, err = client.UpdateAsset(context.Background(), 1, &UpdateAsset{TypeName: Ptr(AssetRealEstate)})
+	require.NoError(t, err)
+
+	assert.True(t, AssetLoan.IsLiability())
+	assert.False(t, AssetVehicle.IsLiability())
+}
+
+func TestCreateAsset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/assets", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": 7, "type_name": "cash", "name": "Wallet", "balance": "20.0000", "currency": "usd"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	a, err := client.CreateAsset(context.Background(), &CreateAsset{TypeName: AssetCash, Name: "Wallet", Balance: MustParseDecimal("20")})
+	require.NoError(t, err)
+	assert.Equal(t, AssetID(7), a.ID)
+
+	_, err = client.CreateAsset(context.Background(), &CreateAsset{TypeName: "boat", Name: "Wallet"})
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -0,0 +1,127 @@
+// Package backup exports everything in a Lunch Money budget to a single
+// versioned JSON archive, for disaster recovery and local archival.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Version is the archive format written by Export. Read accepts archives up
+// to this version.
+const Version = 1
+
+// DefaultStart is the start of the transaction history Export pulls when no
+// start date is given.
+var DefaultStart = lunchmoney.NewDate(2000, time.January, 1)
+
+// Source is the subset of *lunchmoney.Client Export reads from.
+type Source interface {
+	GetUser(ctx context.Context) (*lunchmoney.User, error)
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+	GetCategories(ctx context.Context) ([]*lunchmoney.Category, error)
+	GetTags(ctx context.Context) ([]*lunchmoney.Tag, error)
+	GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error)
+	GetPlaidAccounts(ctx context.Context) ([]*lunchmoney.PlaidAccount, error)
+	GetCrypto(ctx context.Context) ([]*lunchmoney.Crypto, error)
+	GetBudgets(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+	GetRecurringExpenses(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error)
+}
+
+// Archive is the content of a backup.
+type Archive struct {
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `json:"created_at"`
+	User      *lunchmoney.User `json:"user"`
+	// Start and End are the range of transactions and budgets included.
+	Start         lunchmoney.Date                `json:"start"`
+	End           lunchmoney.Date                `json:"end"`
+	Transactions  []*lunchmoney.Transaction      `json:"transactions"`
+	Categories    []*lunchmoney.Category         `json:"categories"`
+	Tags          []*lunchmoney.Tag              `json:"tags"`
+	Assets        []*lunchmoney.Asset            `json:"assets"`
+	PlaidAccounts []*lunchmoney.PlaidAccount     `json:"plaid_accounts"`
+	Crypto        []*lunchmoney.Crypto           `json:"crypto"`
+	Budgets       []*lunchmoney.Budget           `json:"budgets"`
+	Recurring     []*lunchmoney.RecurringExpense `json:"recurring"`
+}
+
+// Options configure Export.
+type Options struct {
+	// Start and End bound the transactions and budgets exported, inclusive.
+	// They default to DefaultStart and today.
+	Start, End lunchmoney.Date
+	// Pagination is passed to GetAllTransactions.
+	Pagination *lunchmoney.PaginationOptions
+}
+
+// Export reads the whole budget from src and writes it to w as an indented
+// JSON archive, which it also returns. Nothing is written if any read fails.
+func Export(ctx context.Context, src Source, w io.Writer, opts *Options) (*Archive, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "backup")
+
+	a := &Archive{Version: Version, CreatedAt: time.Now().UTC(), Start: DefaultStart, End: lunchmoney.Today()}
+	var pages *lunchmoney.PaginationOptions
+	if opts != nil {
+		if !opts.Start.IsZero() {
+			a.Start = opts.Start
+		}
+		if !opts.End.IsZero() {
+			a.End = opts.End
+		}
+		pages = opts.Pagination
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs [9]error
+	)
+	txnFilters := &lunchmoney.TransactionFilters{StartDate: &a.Start, EndDate: &a.End}
+	budgetFilters := &lunchmoney.BudgetFilters{StartDate: a.Start, EndDate: a.End}
+
+	wg.Add(len(errs))
+	go func() { defer wg.Done(); a.User, errs[0] = src.GetUser(ctx) }()
+	go func() { defer wg.Done(); a.Transactions, errs[1] = src.GetAllTransactions(ctx, txnFilters, pages) }()
+	go func() { defer wg.Done(); a.Categories, errs[2] = src.GetCategories(ctx) }()
+	go func() { defer wg.Done(); a.Tags, errs[3] = src.GetTags(ctx) }()
+	go func() { defer wg.Done(); a.Assets, errs[4] = src.GetAssets(ctx) }()
+	go func() { defer wg.Done(); a.PlaidAccounts, errs[5] = src.GetPlaidAccounts(ctx) }()
+	go func() { defer wg.Done(); a.Crypto, errs[6] = src.GetCrypto(ctx) }()
+	go func() { defer wg.Done(); a.Budgets, errs[7] = src.GetBudgets(ctx, budgetFilters) }()
+	go func() { defer wg.Done(); a.Recurring, errs[8] = src.GetRecurringExpenses(ctx, nil) }()
+	wg.Wait()
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a); err != nil {
+		return nil, fmt.Errorf("write archive: %w", err)
+	}
+
+	return a, nil
+}
+
+// Read decodes an archive written by Export. It rejects archives written by
+// a newer version of the format.
+func Read(r io.Reader) (*Archive, error) {
+	a := &Archive{}
+	if err := json.NewDecoder(r).Decode(a); err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+
+	if a.Version < 1 || a.Version > Version {
+		return nil, fmt.Errorf("archive version %d is not supported (want 1 to %d)", a.Version, Version)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	filters *lunchmoney.TransactionFilters
+	failOn  string
+}
+
+func (f *fakeSource) err(name string) error {
+	if f.failOn == name {
+		return errors.New(name + " unavailable")
+	}
+	return nil
+}
+
+func (f *fakeSource) GetUser(context.Context) (*lunchmoney.User, error) {
+	return &lunchmoney.User{UserName: "Ann", PrimaryCurrency: "usd"}, f.err("user")
+}
+
+func (f *fakeSource) GetAllTransactions(_ context.Context, filters *lunchmoney.TransactionFilters, _ *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	f.filters = filters
+	return []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-01-02"), Payee: "Cafe", Amount: lunchmoney.MustParseDecimal("4.50"), CategoryID: lunchmoney.Ptr(lunchmoney.CategoryID(3))},
+	}, f.err("transactions")
+}
+
+func (f *fakeSource) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return []*lunchmoney.Category{{ID: 3, Name: "Coffee"}}, f.err("categories")
+}
+
+func (f *fakeSource) GetTags(context.Context) ([]*lunchmoney.Tag, error) {
+	return []*lunchmoney.Tag{{ID: 9, Name: "work"}}, f.err("tags")
+}
+
+func (f *fakeSource) GetAssets(context.Context) ([]*lunchmoney.Asset, error) {
+	return []*lunchmoney.Asset{{ID: 4, Name: "Cash", Balance: lunchmoney.MustParseDecimal("20")}}, f.err("assets")
+}
+
+func (f *fakeSource) GetPlaidAccounts(context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	return []*lunchmoney.PlaidAccount{{ID: 5, Name: "Checking"}}, f.err("plaid")
+}
+
+func (f *fakeSource) GetCrypto(context.Context) ([]*lunchmoney.Crypto, error) {
+	return nil, f.err("crypto")
+}
+
+func (f *fakeSource) GetBudgets(context.Context, *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	return []*lunchmoney.Budget{{CategoryID: 3, CategoryName: "Coffee"}}, f.err("budgets")
+}
+
+func (f *fakeSource) GetRecurringExpenses(context.Context, *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error) {
+	return []*lunchmoney.RecurringExpense{{ID: 6, Payee: "Gym", Cadence: "monthly"}}, f.err("recurring")
+}
+
+func TestExportRead(t *testing.T) {
+	src := &fakeSource{}
+	var buf bytes.Buffer
+	a, err := Export(context.Background(), src, &buf, &Options{Start: lunchmoney.MustParseDate("2023-01-01"), End: lunchmoney.MustParseDate("2024-12-31")})
+	require.NoError(t, err)
+	assert.Equal(t, "2023-01-01", src.filters.StartDate.String())
+	assert.Equal(t, "2024-12-31", src.filters.EndDate.String())
+	assert.Equal(t, Version, a.Version)
+
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, "Ann", got.User.UserName)
+	require.Len(t, got.Transactions, 1)
+	assert.Equal(t, "4.50", got.Transactions[0].Amount.String())
+	assert.Equal(t, lunchmoney.CategoryID(3), *got.Transactions[0].CategoryID)
+	assert.Len(t, got.Categories, 1)
+	assert.Len(t, got.Tags, 1)
+	assert.Len(t, got.Assets, 1)
+	assert.Len(t, got.PlaidAccounts, 1)
+	assert.Len(t, got.Budgets, 1)
+	assert.Len(t, got.Recurring, 1)
+	assert.Equal(t, "2024-12-31", got.End.String())
+}
+
+func TestExportError(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), &fakeSource{failOn: "tags"}, &buf, nil)
+	assert.ErrorContains(t, err, "tags unavailable")
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestReadVersion(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"version": 2}`))
+	assert.ErrorContains(t, err, "version 2 is not supported")
+
+	_, err = Read(strings.NewReader(`{}`))
+	assert.Error(t, err)
+}
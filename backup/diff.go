@@ -0,0 +1,204 @@
+package backup
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/events"
+)
+
+// TransactionChange is a transaction present in both archives with
+// different fields.
+type TransactionChange struct {
+	Before, After *lunchmoney.Transaction
+	// Fields are the JSON names of the fields that changed, as reported by
+	// events.Changed.
+	Fields []string
+}
+
+// CategoryChange is a category that was added, removed or changed. Before is
+// nil for added categories and After is nil for removed ones.
+type CategoryChange struct {
+	Before, After *lunchmoney.Category
+	Fields        []string
+}
+
+// Diff is what changed between two archives.
+type Diff struct {
+	Added   []*lunchmoney.Transaction
+	Removed []*lunchmoney.Transaction
+	Changed []TransactionChange
+	// Categories are in order of category ID.
+	Categories []CategoryChange
+	// Balances are the accounts present in both archives whose balance
+	// moved, using the account types of the events package.
+	Balances []*events.BalanceChanged
+}
+
+// Empty reports whether nothing changed.
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && len(d.Categories) == 0 && len(d.Balances) == 0
+}
+
+// Compare reports the differences from before to after. Transactions are
+// only compared for the dates covered by both archives, so archives of
+// different ranges do not report transactions outside the overlap as added
+// or removed. Transactions are listed in date order, then by ID.
+func Compare(before, after *Archive) *Diff {
+	d := &Diff{}
+	start, end := maxDate(before.Start, after.Start), minDate(before.End, after.End)
+	inRange := func(t *lunchmoney.Transaction) bool {
+		return !t.Date.Before(start) && (end.IsZero() || !t.Date.After(end))
+	}
+
+	old := map[lunchmoney.TransactionID]*lunchmoney.Transaction{}
+	for _, t := range before.Transactions {
+		if inRange(t) {
+			old[t.ID] = t
+		}
+	}
+	for _, t := range after.Transactions {
+		if !inRange(t) {
+			continue
+		}
+
+		prev, ok := old[t.ID]
+		if !ok {
+			d.Added = append(d.Added, t)
+			continue
+		}
+		delete(old, t.ID)
+
+		fields := events.Changed(events.FromTransaction(prev), events.FromTransaction(t))
+		if len(fields) > 0 {
+			d.Changed = append(d.Changed, TransactionChange{Before: prev, After: t, Fields: fields})
+		}
+	}
+	for _, t := range old {
+		d.Removed = append(d.Removed, t)
+	}
+
+	byDate := func(a, b *lunchmoney.Transaction) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	}
+	slices.SortFunc(d.Added, byDate)
+	slices.SortFunc(d.Removed, byDate)
+	slices.SortFunc(d.Changed, func(a, b TransactionChange) int { return byDate(a.After, b.After) })
+
+	d.Categories = compareCategories(before.Categories, after.Categories)
+	d.Balances = compareBalances(before, after)
+
+	return d
+}
+
+func compareCategories(before, after []*lunchmoney.Category) []CategoryChange {
+	old := map[lunchmoney.CategoryID]*lunchmoney.Category{}
+	for _, c := range before {
+		old[c.ID] = c
+	}
+
+	var ret []CategoryChange
+	for _, c := range after {
+		prev, ok := old[c.ID]
+		if !ok {
+			ret = append(ret, CategoryChange{After: c})
+			continue
+		}
+		delete(old, c.ID)
+
+		if fields := categoryFields(prev, c); len(fields) > 0 {
+			ret = append(ret, CategoryChange{Before: prev, After: c, Fields: fields})
+		}
+	}
+	for _, c := range old {
+		ret = append(ret, CategoryChange{Before: c})
+	}
+
+	slices.SortFunc(ret, func(a, b CategoryChange) int {
+		return cmp.Compare(categoryID(a), categoryID(b))
+	})
+
+	return ret
+}
+
+func categoryID(c CategoryChange) lunchmoney.CategoryID {
+	if c.After != nil {
+		return c.After.ID
+	}
+	return c.Before.ID
+}
+
+func categoryFields(before, after *lunchmoney.Category) []string {
+	var ret []string
+	add := func(name string, differ bool) {
+		if differ {
+			ret = append(ret, name)
+		}
+	}
+
+	add("name", before.Name != after.Name)
+	add("description", before.Description != after.Description)
+	add("is_income", before.IsIncome != after.IsIncome)
+	add("exclude_from_budget", before.ExcludeFromBudget != after.ExcludeFromBudget)
+	add("exclude_from_totals", before.ExcludeFromTotals != after.ExcludeFromTotals)
+	add("is_group", before.IsGroup != after.IsGroup)
+	add("group_id", (before.GroupID == nil) != (after.GroupID == nil) || (before.GroupID != nil && *before.GroupID != *after.GroupID))
+	add("archived", before.Archived != after.Archived)
+
+	return ret
+}
+
+func compareBalances(before, after *Archive) []*events.BalanceChanged {
+	type key struct {
+		kind string
+		id   int64
+	}
+	old := map[key]lunchmoney.Decimal{}
+	for _, a := range before.Assets {
+		old[key{events.AccountAsset, int64(a.ID)}] = a.Balance
+	}
+	for _, p := range before.PlaidAccounts {
+		old[key{events.AccountPlaidAccount, int64(p.ID)}] = p.Balance
+	}
+	for _, c := range before.Crypto {
+		old[key{events.AccountCrypto, int64(c.ID)}] = c.Balance
+	}
+
+	var ret []*events.BalanceChanged
+	check := func(kind string, id int64, name string, balance lunchmoney.Decimal, currency string) {
+		prev, ok := old[key{kind, id}]
+		if ok && !prev.Equal(balance) {
+			ret = append(ret, &events.BalanceChanged{AccountType: kind, AccountID: id, Name: name, Previous: prev, Current: balance, Currency: currency})
+		}
+	}
+	for _, a := range after.Assets {
+		check(events.AccountAsset, int64(a.ID), a.Name, a.Balance, a.Currency)
+	}
+	for _, p := range after.PlaidAccounts {
+		check(events.AccountPlaidAccount, int64(p.ID), p.Name, p.Balance, p.Currency)
+	}
+	for _, c := range after.Crypto {
+		check(events.AccountCrypto, int64(c.ID), c.Name, c.Balance, c.Currency)
+	}
+
+	return ret
+}
+
+func maxDate(a, b lunchmoney.Date) lunchmoney.Date {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minDate(a, b lunchmoney.Date) lunchmoney.Date {
+	switch {
+	case a.IsZero():
+		return b
+	case b.IsZero() || a.Before(b):
+		return a
+	default:
+		return b
+	}
+}
@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	txn := func(id int64, date, amount string) *lunchmoney.Transaction {
+		return &lunchmoney.Transaction{ID: lunchmoney.TransactionID(id), Date: lunchmoney.MustParseDate(date), Payee: "Shop", Amount: lunchmoney.MustParseDecimal(amount), Status: "uncleared"}
+	}
+
+	before := &Archive{
+		Start: lunchmoney.MustParseDate("2024-01-01"), End: lunchmoney.MustParseDate("2024-03-31"),
+		Transactions: []*lunchmoney.Transaction{
+			txn(1, "2024-01-05", "10"),
+			txn(2, "2024-02-01", "20"),
+			txn(3, "2024-02-02", "30"),
+			txn(4, "2024-03-01", "40.0"),
+		},
+		Categories: []*lunchmoney.Category{{ID: 1, Name: "Food"}, {ID: 2, Name: "Fun"}},
+		Assets:     []*lunchmoney.Asset{{ID: 7, Name: "Cash", Balance: lunchmoney.MustParseDecimal("100"), Currency: "usd"}},
+		PlaidAccounts: []*lunchmoney.PlaidAccount{
+			{ID: 8, Name: "Checking", Balance: lunchmoney.MustParseDecimal("5.0")},
+		},
+	}
+
+	changed := txn(2, "2024-02-01", "20")
+	changed.Status = "cleared"
+	changed.Tags = []*lunchmoney.Tag{{ID: 3}}
+	after := &Archive{
+		Start: lunchmoney.MustParseDate("2024-02-01"), End: lunchmoney.MustParseDate("2024-04-30"),
+		Transactions: []*lunchmoney.Transaction{
+			changed,
+			txn(4, "2024-03-01", "40.00"),
+			txn(5, "2024-03-15", "50"),
+			txn(6, "2024-04-10", "60"),
+		},
+		Categories: []*lunchmoney.Category{{ID: 1, Name: "Groceries"}, {ID: 3, Name: "Travel"}},
+		Assets:     []*lunchmoney.Asset{{ID: 7, Name: "Cash", Balance: lunchmoney.MustParseDecimal("80"), Currency: "usd"}},
+		PlaidAccounts: []*lunchmoney.PlaidAccount{
+			{ID: 8, Name: "Checking", Balance: lunchmoney.MustParseDecimal("5")},
+		},
+	}
+
+	d := Compare(before, after)
+	assert.False(t, d.Empty())
+
+	require.Len(t, d.Added, 1)
+	assert.Equal(t, lunchmoney.TransactionID(5), d.Added[0].ID)
+	require.Len(t, d.Removed, 1)
+	assert.Equal(t, lunchmoney.TransactionID(3), d.Removed[0].ID)
+	require.Len(t, d.Changed, 1)
+	assert.Equal(t, []string{"status", "tag_ids"}, d.Changed[0].Fields)
+
+	require.Len(t, d.Categories, 3)
+	assert.Equal(t, []string{"name"}, d.Categories[0].Fields)
+	assert.Nil(t, d.Categories[1].After)
+	assert.Nil(t, d.Categories[2].Before)
+
+	require.Len(t, d.Balances, 1)
+	assert.Equal(t, events.AccountAsset, d.Balances[0].AccountType)
+	assert.Equal(t, "100", d.Balances[0].Previous.String())
+	assert.Equal(t, "80", d.Balances[0].Current.String())
+
+	assert.True(t, Compare(after, after).Empty())
+}
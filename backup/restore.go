@@ -0,0 +1,303 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icco/lunchmoney"
+)
+
+// DefaultBatchSize is the number of transactions Restore inserts per request
+// when no batch size is given.
+const DefaultBatchSize = 100
+
+// Target is the subset of *lunchmoney.Client Restore writes to.
+type Target interface {
+	CreateCategory(ctx context.Context, cat *lunchmoney.CreateCategory) (lunchmoney.CategoryID, error)
+	CreateCategoryGroup(ctx context.Context, g *lunchmoney.CreateCategoryGroup) (lunchmoney.CategoryID, error)
+	CreateAsset(ctx context.Context, asset *lunchmoney.CreateAsset) (*lunchmoney.Asset, error)
+	InsertTransactions(ctx context.Context, req lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error)
+	GetCategories(ctx context.Context) ([]*lunchmoney.Category, error)
+	GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error)
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+}
+
+// RestoreOptions configure Restore.
+type RestoreOptions struct {
+	// BatchSize is the number of transactions inserted per request.
+	// Defaults to DefaultBatchSize.
+	BatchSize int
+}
+
+// Skipped is a record of the archive that Restore did not recreate.
+type Skipped struct {
+	// Kind is the archive section, such as "plaid_accounts".
+	Kind   string
+	ID     int64
+	Name   string
+	Reason string
+}
+
+// RestoreReport maps the IDs of archived records to the IDs of the records
+// Restore created, and lists what it could not restore.
+type RestoreReport struct {
+	Categories   map[lunchmoney.CategoryID]lunchmoney.CategoryID
+	Assets       map[lunchmoney.AssetID]lunchmoney.AssetID
+	Transactions map[lunchmoney.TransactionID]lunchmoney.TransactionID
+	Skipped      []Skipped
+}
+
+func (r *RestoreReport) skip(kind string, id int64, name, reason string) {
+	r.Skipped = append(r.Skipped, Skipped{Kind: kind, ID: id, Name: name, Reason: reason})
+}
+
+// Restore recreates the categories, manually-managed assets and manual
+// transactions of a into the budget behind t. Transactions keep their
+// category and asset through the new IDs, and their tags by name. Plaid
+// accounts and their transactions, crypto, budgets and recurring items
+// cannot be created through the API and are listed in the report as
+// skipped.
+//
+// Restore can be run again after a failure to resume it: categories and
+// assets that already exist with the same name, and transactions that
+// already exist with the same external ID, are mapped rather than created.
+// Transactions archived without an external ID are inserted with one
+// derived from their archived ID, so they are found on the next run too.
+//
+// Restore stops at the first failed write and returns the report of what was
+// created so far along with the error.
+func Restore(ctx context.Context, t Target, a *Archive, opts *RestoreOptions) (*RestoreReport, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "backup.restore")
+
+	batch := DefaultBatchSize
+	if opts != nil && opts.BatchSize > 0 {
+		batch = opts.BatchSize
+	}
+
+	r := &RestoreReport{
+		Categories:   map[lunchmoney.CategoryID]lunchmoney.CategoryID{},
+		Assets:       map[lunchmoney.AssetID]lunchmoney.AssetID{},
+		Transactions: map[lunchmoney.TransactionID]lunchmoney.TransactionID{},
+	}
+
+	if err := restoreCategories(ctx, t, a.Categories, r); err != nil {
+		return r, err
+	}
+
+	assets, err := t.GetAssets(ctx)
+	if err != nil {
+		return r, fmt.Errorf("restore assets: %w", err)
+	}
+	existing := map[string]lunchmoney.AssetID{}
+	for _, as := range assets {
+		existing[as.Name] = as.ID
+	}
+	for _, as := range a.Assets {
+		if id, ok := existing[as.Name]; ok {
+			r.Assets[as.ID] = id
+			continue
+		}
+
+		created, err := t.CreateAsset(ctx, &lunchmoney.CreateAsset{
+			TypeName:        as.TypeName,
+			SubtypeName:     as.SubtypeName,
+			Name:            as.Name,
+			DisplayName:     as.DisplayName,
+			Balance:         as.Balance,
+			Currency:        as.Currency,
+			InstitutionName: as.InstitutionName,
+		})
+		if err != nil {
+			return r, fmt.Errorf("restore asset %d: %w", as.ID, err)
+		}
+		r.Assets[as.ID] = created.ID
+	}
+
+	for _, p := range a.PlaidAccounts {
+		r.skip("plaid_accounts", int64(p.ID), p.Name, "synced account; reconnect it in Lunch Money")
+	}
+	for _, c := range a.Crypto {
+		r.skip("crypto", int64(c.ID), c.Name, "crypto balances cannot be created through the API")
+	}
+	for _, b := range a.Budgets {
+		r.skip("budgets", int64(b.CategoryID), b.CategoryName, "budgets cannot be set through the API")
+	}
+	for _, rc := range a.Recurring {
+		r.skip("recurring", int64(rc.ID), rc.Payee, "recurring items cannot be created through the API")
+	}
+
+	return r, restoreTransactions(ctx, t, a, batch, r)
+}
+
+// restoreCategories creates the plain categories first and then the groups,
+// which take the IDs of their new children. Categories are matched to the
+// existing ones by name and kind.
+func restoreCategories(ctx context.Context, t Target, categories []*lunchmoney.Category, r *RestoreReport) error {
+	current, err := t.GetCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("restore categories: %w", err)
+	}
+	type key struct {
+		name  string
+		group bool
+	}
+	existing := map[key]lunchmoney.CategoryID{}
+	for _, c := range current {
+		existing[key{c.Name, c.IsGroup}] = c.ID
+	}
+
+	children := map[lunchmoney.CategoryID][]lunchmoney.CategoryID{}
+	for _, c := range categories {
+		if c.IsGroup {
+			continue
+		}
+
+		id, ok := existing[key{c.Name, false}]
+		if ok {
+			r.Categories[c.ID] = id
+			continue
+		}
+		id, err := t.CreateCategory(ctx, &lunchmoney.CreateCategory{
+			Name:              c.Name,
+			Description:       c.Description,
+			IsIncome:          c.IsIncome,
+			ExcludeFromBudget: c.ExcludeFromBudget,
+			ExcludeFromTotals: c.ExcludeFromTotals,
+			Archived:          c.Archived,
+		})
+		if err != nil {
+			return fmt.Errorf("restore category %d: %w", c.ID, err)
+		}
+		r.Categories[c.ID] = id
+		if c.GroupID != nil {
+			children[*c.GroupID] = append(children[*c.GroupID], id)
+		}
+	}
+
+	for _, c := range categories {
+		if !c.IsGroup {
+			continue
+		}
+
+		id, ok := existing[key{c.Name, true}]
+		if ok {
+			r.Categories[c.ID] = id
+			continue
+		}
+		id, err := t.CreateCategoryGroup(ctx, &lunchmoney.CreateCategoryGroup{
+			Name:              c.Name,
+			Description:       c.Description,
+			IsIncome:          c.IsIncome,
+			ExcludeFromBudget: c.ExcludeFromBudget,
+			ExcludeFromTotals: c.ExcludeFromTotals,
+			CategoryIDs:       children[c.ID],
+		})
+		if err != nil {
+			return fmt.Errorf("restore category group %d: %w", c.ID, err)
+		}
+		r.Categories[c.ID] = id
+	}
+
+	return nil
+}
+
+// externalID is the external ID a transaction is inserted with: its own, or
+// one derived from its archived ID.
+func externalID(txn *lunchmoney.Transaction) string {
+	if txn.ExternalID != "" {
+		return txn.ExternalID
+	}
+	return fmt.Sprintf("backup-%d", txn.ID)
+}
+
+func restoreTransactions(ctx context.Context, t Target, a *Archive, batch int, r *RestoreReport) error {
+	filters := &lunchmoney.TransactionFilters{}
+	if !a.Start.IsZero() && !a.End.IsZero() {
+		filters.StartDate, filters.EndDate = &a.Start, &a.End
+	}
+	current, err := t.GetAllTransactions(ctx, filters, nil)
+	if err != nil {
+		return fmt.Errorf("restore transactions: %w", err)
+	}
+	existing := map[string]lunchmoney.TransactionID{}
+	for _, txn := range current {
+		if txn.ExternalID != "" {
+			existing[txn.ExternalID] = txn.ID
+		}
+	}
+
+	var (
+		pending []lunchmoney.InsertTransaction
+		ids     []lunchmoney.TransactionID
+	)
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		resp, err := t.InsertTransactions(ctx, lunchmoney.InsertTransactionsRequest{Transactions: pending})
+		if err != nil {
+			return fmt.Errorf("restore transactions %d to %d: %w", ids[0], ids[len(ids)-1], err)
+		}
+		for i, id := range resp.IDs {
+			if i < len(ids) {
+				r.Transactions[ids[i]] = id
+			}
+		}
+		pending, ids = nil, nil
+		return nil
+	}
+
+	for _, txn := range a.Transactions {
+		switch {
+		case txn.HasPlaidAccount():
+			r.skip("transactions", int64(txn.ID), txn.Payee, "synced from Plaid; it is imported again when the account is reconnected")
+			continue
+		case txn.IsGroup:
+			r.skip("transactions", int64(txn.ID), txn.Payee, "transaction groups cannot be created through the API")
+			continue
+		}
+
+		ext := externalID(txn)
+		if id, ok := existing[ext]; ok {
+			r.Transactions[txn.ID] = id
+			continue
+		}
+
+		it := lunchmoney.InsertTransaction{
+			Date:       txn.Date,
+			Amount:     txn.Amount,
+			Payee:      txn.Payee,
+			Currency:   txn.Currency,
+			Notes:      txn.Notes,
+			Status:     txn.Status,
+			ExternalID: ext,
+		}
+		for _, tag := range txn.Tags {
+			it.TagNames = append(it.TagNames, tag.Name)
+		}
+		if it.Status != "cleared" {
+			it.Status = "uncleared"
+		}
+		if txn.CategoryID != nil {
+			if id, ok := r.Categories[*txn.CategoryID]; ok {
+				it.CategoryID = &id
+			}
+		}
+		if txn.AssetID != nil {
+			if id, ok := r.Assets[*txn.AssetID]; ok {
+				it.AssetID = &id
+			}
+		}
+
+		pending = append(pending, it)
+		ids = append(ids, txn.ID)
+		if len(pending) == batch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
@@ -0,0 +1,158 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTarget struct {
+	next       int64
+	categories []*lunchmoney.CreateCategory
+	groups     []*lunchmoney.CreateCategoryGroup
+	assets     []*lunchmoney.CreateAsset
+	batches    [][]lunchmoney.InsertTransaction
+	failInsert bool
+
+	// existing are the records the budget holds, including those created.
+	existing struct {
+		categories []*lunchmoney.Category
+		assets     []*lunchmoney.Asset
+		txns       []*lunchmoney.Transaction
+	}
+}
+
+func (f *fakeTarget) id() int64 {
+	f.next++
+	return 100 + f.next
+}
+
+func (f *fakeTarget) CreateCategory(_ context.Context, c *lunchmoney.CreateCategory) (lunchmoney.CategoryID, error) {
+	f.categories = append(f.categories, c)
+	id := lunchmoney.CategoryID(f.id())
+	f.existing.categories = append(f.existing.categories, &lunchmoney.Category{ID: id, Name: c.Name})
+	return id, nil
+}
+
+func (f *fakeTarget) CreateCategoryGroup(_ context.Context, g *lunchmoney.CreateCategoryGroup) (lunchmoney.CategoryID, error) {
+	f.groups = append(f.groups, g)
+	id := lunchmoney.CategoryID(f.id())
+	f.existing.categories = append(f.existing.categories, &lunchmoney.Category{ID: id, Name: g.Name, IsGroup: true})
+	return id, nil
+}
+
+func (f *fakeTarget) CreateAsset(_ context.Context, a *lunchmoney.CreateAsset) (*lunchmoney.Asset, error) {
+	f.assets = append(f.assets, a)
+	created := &lunchmoney.Asset{ID: lunchmoney.AssetID(f.id()), Name: a.Name}
+	f.existing.assets = append(f.existing.assets, created)
+	return created, nil
+}
+
+func (f *fakeTarget) InsertTransactions(_ context.Context, req lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+	if f.failInsert {
+		return nil, errors.New("insert failed")
+	}
+	f.batches = append(f.batches, req.Transactions)
+	resp := &lunchmoney.InsertTransactionsResponse{}
+	for _, it := range req.Transactions {
+		id := lunchmoney.TransactionID(f.id())
+		resp.IDs = append(resp.IDs, id)
+		f.existing.txns = append(f.existing.txns, &lunchmoney.Transaction{ID: id, ExternalID: it.ExternalID})
+	}
+	return resp, nil
+}
+
+func (f *fakeTarget) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return f.existing.categories, nil
+}
+
+func (f *fakeTarget) GetAssets(context.Context) ([]*lunchmoney.Asset, error) {
+	return f.existing.assets, nil
+}
+
+func (f *fakeTarget) GetAllTransactions(context.Context, *lunchmoney.TransactionFilters, *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	return f.existing.txns, nil
+}
+
+func testArchive() *Archive {
+	food := lunchmoney.CategoryID(1)
+	cash := lunchmoney.AssetID(4)
+	return &Archive{
+		Version: Version,
+		Categories: []*lunchmoney.Category{
+			{ID: 1, Name: "Food", IsGroup: true},
+			{ID: 2, Name: "Groceries", GroupID: &food},
+			{ID: 3, Name: "Salary", IsIncome: true},
+		},
+		Assets:        []*lunchmoney.Asset{{ID: cash, Name: "Cash", TypeName: lunchmoney.AssetCash, Balance: lunchmoney.MustParseDecimal("20")}},
+		PlaidAccounts: []*lunchmoney.PlaidAccount{{ID: 5, Name: "Checking"}},
+		Budgets:       []*lunchmoney.Budget{{CategoryID: 2, CategoryName: "Groceries"}},
+		Transactions: []*lunchmoney.Transaction{
+			{ID: 10, Payee: "Market", Amount: lunchmoney.MustParseDecimal("12"), CategoryID: lunchmoney.Ptr(lunchmoney.CategoryID(2)), AssetID: &cash, Status: "cleared"},
+			{ID: 11, Payee: "Bank", Amount: lunchmoney.MustParseDecimal("3"), PlaidAccountID: lunchmoney.Ptr(lunchmoney.PlaidAccountID(5))},
+			{ID: 12, Payee: "Employer", Amount: lunchmoney.MustParseDecimal("-100"), CategoryID: lunchmoney.Ptr(lunchmoney.CategoryID(3)), Status: "pending"},
+			{ID: 13, Payee: "Lunch", Amount: lunchmoney.MustParseDecimal("8"), ExternalID: "cafe-1", Tags: []*lunchmoney.Tag{{ID: 6, Name: "work"}}},
+		},
+	}
+}
+
+func TestRestore(t *testing.T) {
+	target := &fakeTarget{}
+	r, err := Restore(context.Background(), target, testArchive(), &RestoreOptions{BatchSize: 2})
+	require.NoError(t, err)
+
+	require.Len(t, target.categories, 2)
+	require.Len(t, target.groups, 1)
+	assert.Equal(t, []lunchmoney.CategoryID{r.Categories[2]}, target.groups[0].CategoryIDs)
+	assert.Len(t, r.Categories, 3)
+	assert.Len(t, target.assets, 1)
+
+	require.Len(t, target.batches, 2)
+	first := target.batches[0][0]
+	assert.Equal(t, r.Categories[2], *first.CategoryID)
+	assert.Equal(t, r.Assets[4], *first.AssetID)
+	assert.Equal(t, "cleared", first.Status)
+	assert.Equal(t, "uncleared", target.batches[0][1].Status)
+	assert.Len(t, r.Transactions, 3)
+	assert.Contains(t, r.Transactions, lunchmoney.TransactionID(13))
+	assert.Equal(t, "backup-10", first.ExternalID)
+	last := target.batches[1][0]
+	assert.Equal(t, "cafe-1", last.ExternalID)
+	assert.Equal(t, []string{"work"}, last.TagNames)
+
+	kinds := map[string]int{}
+	for _, s := range r.Skipped {
+		kinds[s.Kind]++
+	}
+	assert.Equal(t, map[string]int{"plaid_accounts": 1, "budgets": 1, "transactions": 1}, kinds)
+}
+
+func TestRestoreError(t *testing.T) {
+	target := &fakeTarget{failInsert: true}
+	r, err := Restore(context.Background(), target, testArchive(), nil)
+	assert.ErrorContains(t, err, "insert failed")
+	require.NotNil(t, r)
+	assert.Len(t, r.Categories, 3)
+	assert.Empty(t, r.Transactions)
+
+	// A second run picks up where the first stopped.
+	target.failInsert = false
+	again, err := Restore(context.Background(), target, testArchive(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, r.Categories, again.Categories)
+	assert.Equal(t, r.Assets, again.Assets)
+	assert.Len(t, target.categories, 2)
+	assert.Len(t, target.groups, 1)
+	assert.Len(t, target.assets, 1)
+	require.Len(t, target.batches, 1)
+	assert.Len(t, again.Transactions, 3)
+
+	// Nothing is left to insert on a third.
+	_, err = Restore(context.Background(), target, testArchive(), nil)
+	require.NoError(t, err)
+	assert.Len(t, target.batches, 1)
+}
@@ -0,0 +1,170 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bill is an expected charge from a recurring item.
+type Bill struct {
+	Date           Date
+	Payee          string
+	Amount         Decimal
+	Currency       string
+	AssetID        *AssetID
+	PlaidAccountID *PlaidAccountID
+	Recurring      *RecurringExpense
+}
+
+// UpcomingBills returns the charges expected from recurring items between
+// from and to, inclusive, ordered by date. Items whose cadence is not
+// understood are logged and left out.
+func (c *Client) UpcomingBills(ctx context.Context, from, to Date) ([]*Bill, error) {
+	ctx = WithDefaultOperation(ctx, "bills")
+
+	items, err := c.GetRecurringExpenses(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*Bill
+	for _, r := range items {
+		dates, err := r.Occurrences(from, to)
+		if err != nil {
+			if c.logger != nil {
+				c.logger.WarnContext(ctx, "lunchmoney skipped recurring item", slog.Int64("id", int64(r.ID)), slog.Any("error", err))
+			}
+			continue
+		}
+
+		for _, d := range dates {
+			ret = append(ret, &Bill{
+				Date:           d,
+				Payee:          r.Payee,
+				Amount:         r.Amount,
+				Currency:       r.Currency,
+				AssetID:        r.AssetID,
+				PlaidAccountID: r.PlaidAccountID,
+				Recurring:      r,
+			})
+		}
+	}
+	slices.SortStableFunc(ret, func(a, b *Bill) int { return a.Date.Compare(b.Date) })
+
+	return ret, nil
+}
+
+// Occurrences returns the dates between from and to, inclusive, on which the
+// item is expected to be charged. Dates are projected from BillingDate by the
+// cadence and bounded by StartDate and EndDate when they are set.
+func (r *RecurringExpense) Occurrences(from, to Date) ([]Date, error) {
+	anchor, err := ParseDate(r.BillingDate)
+	if err != nil {
+		return nil, fmt.Errorf("recurring item %d: billing date: %w", r.ID, err)
+	}
+
+	step, err := parseCadence(r.Cadence)
+	if err != nil {
+		return nil, fmt.Errorf("recurring item %d: %w", r.ID, err)
+	}
+
+	if !r.StartDate.IsZero() && r.StartDate.After(from) {
+		from = r.StartDate
+	}
+	if !r.EndDate.IsZero() && r.EndDate.Before(to) {
+		to = r.EndDate
+	}
+
+	var ret []Date
+	for i := 0; ; i++ {
+		d := step.nth(anchor, i)
+		if d.After(to) {
+			break
+		}
+		if !d.Before(from) {
+			ret = append(ret, d)
+		}
+	}
+
+	return ret, nil
+}
+
+// cadence is the interval between charges of a recurring item. Exactly one
+// of days and months is set.
+type cadence struct {
+	days   int
+	months int
+	// twice charges twice each month, 15 days apart.
+	twice bool
+}
+
+func parseCadence(s string) (cadence, error) {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case "once a week", "weekly":
+		return cadence{days: 7}, nil
+	case "twice a month":
+		return cadence{months: 1, twice: true}, nil
+	case "monthly", "once a month":
+		return cadence{months: 1}, nil
+	case "twice a year":
+		return cadence{months: 6}, nil
+	case "yearly", "once a year", "annually":
+		return cadence{months: 12}, nil
+	}
+
+	// "every 2 weeks", "once every 3 months", ...
+	f := strings.Fields(strings.TrimPrefix(s, "once "))
+	if len(f) == 3 && f[0] == "every" {
+		n, err := strconv.Atoi(f[1])
+		if err == nil && n > 0 {
+			switch strings.TrimSuffix(f[2], "s") {
+			case "day":
+				return cadence{days: n}, nil
+			case "week":
+				return cadence{days: 7 * n}, nil
+			case "month":
+				return cadence{months: n}, nil
+			case "year":
+				return cadence{months: 12 * n}, nil
+			}
+		}
+	}
+
+	return cadence{}, fmt.Errorf("unknown cadence %q", s)
+}
+
+// nth returns the i-th charge date counting from anchor. Months are always
+// stepped from the anchor, so a charge on the 31st falls on the last day of
+// shorter months and returns to the 31st afterwards.
+func (c cadence) nth(anchor Date, i int) Date {
+	if c.days > 0 {
+		return anchor.AddDays(i * c.days)
+	}
+	if !c.twice {
+		return addMonthsClamped(anchor, i*c.months)
+	}
+
+	d := addMonthsClamped(anchor, i/2)
+	if i%2 == 0 {
+		return d
+	}
+
+	// The second charge is 15 days after the first, kept within the same
+	// month so each month has exactly two.
+	if anchor.Day <= 15 {
+		last := NewDate(d.Year, d.Month+1, 0)
+		return NewDate(d.Year, d.Month, min(anchor.Day+15, last.Day))
+	}
+
+	return NewDate(d.Year, d.Month+1, anchor.Day-15)
+}
+
+func addMonthsClamped(d Date, n int) Date {
+	last := NewDate(d.Year, d.Month+time.Month(n)+1, 0)
+	return NewDate(last.Year, last.Month, min(d.Day, last.Day))
+}
@@ -0,0 +1,68 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrences(t *testing.T) {
+	dates := func(ds []Date) []string {
+		var ret []string
+		for _, d := range ds {
+			ret = append(ret, d.String())
+		}
+		return ret
+	}
+
+	r := &RecurringExpense{Cadence: "monthly", BillingDate: "2024-01-31"}
+	got, err := r.Occurrences(MustParseDate("2024-02-01"), MustParseDate("2024-04-30"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-02-29", "2024-03-31", "2024-04-30"}, dates(got))
+
+	r = &RecurringExpense{Cadence: "every 2 weeks", BillingDate: "2024-01-05", EndDate: MustParseDate("2024-02-10")}
+	got, err = r.Occurrences(MustParseDate("2024-01-10"), MustParseDate("2024-03-01"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-19", "2024-02-02"}, dates(got))
+
+	r = &RecurringExpense{Cadence: "twice a month", BillingDate: "2024-01-01"}
+	got, err = r.Occurrences(MustParseDate("2024-01-01"), MustParseDate("2024-01-31"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-01", "2024-01-16"}, dates(got))
+
+	r = &RecurringExpense{Cadence: "twice a month", BillingDate: "2024-01-20"}
+	got, err = r.Occurrences(MustParseDate("2024-02-01"), MustParseDate("2024-02-29"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-02-05", "2024-02-20"}, dates(got))
+
+	r = &RecurringExpense{Cadence: "whenever", BillingDate: "2024-01-01"}
+	_, err = r.Occurrences(MustParseDate("2024-01-01"), MustParseDate("2024-01-31"))
+	assert.ErrorContains(t, err, `unknown cadence "whenever"`)
+}
+
+func TestUpcomingBills(t *testing.T) {
+	data, err := os.ReadFile("testdata/recurring.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	bills, err := client.UpcomingBills(context.Background(), MustParseDate("2024-03-01"), MustParseDate("2024-03-31"))
+	require.NoError(t, err)
+	require.Len(t, bills, 5)
+	for i := 1; i < len(bills); i++ {
+		assert.False(t, bills[i].Date.Before(bills[i-1].Date))
+	}
+	assert.Equal(t, "2024-03-01", bills[0].Date.String())
+	assert.NotNil(t, bills[0].Recurring)
+}
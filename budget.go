@@ -1,26 +1,23 @@
 package lunchmoney
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
+	"slices"
 
 	"github.com/Rhymond/go-money"
-	"github.com/go-playground/validator/v10"
 )
 
 // Budget defines a categories budget over time.
 type Budget struct {
 	CategoryGroupName string                 `json:"category_group_name,omitempty"`
-	CategoryID        int                    `json:"category_id"`
+	CategoryID        CategoryID             `json:"category_id"`
 	CategoryName      string                 `json:"category_name"`
 	Data              map[string]*BudgetData `json:"data,omitempty" validate:"dive"`
 	ExcludeFromBudget bool                   `json:"exclude_from_budget"`
 	ExcludeFromTotals bool                   `json:"exclude_from_totals"`
-	GroupID           int                    `json:"group_id"`
+	GroupID           *CategoryID            `json:"group_id"`
 	HasChildren       bool                   `json:"has_children,omitempty"`
 	IsGroup           bool                   `json:"is_group,omitempty"`
 	IsIncome          bool                   `json:"is_income"`
@@ -29,27 +26,74 @@ type Budget struct {
 		Sum  float64 `json:"sum"`
 		List []struct {
 			Payee    string  `json:"payee"`
-			Amount   string  `json:"amount"`
+			Amount   Decimal `json:"amount"`
 			Currency string  `json:"currency"`
-			ToBase   float64 `json:"to_base"`
+			ToBase   Decimal `json:"to_base"`
 		} `json:"list"`
 	} `json:"recurring,omitempty"`
+	Config *BudgetConfig `json:"config,omitempty"`
 }
 
-// BudgetData is a single month's budget for a category.
+// Month returns the budget data for the month containing d.
+func (b *Budget) Month(d Date) (*BudgetData, bool) {
+	bd, ok := b.Data[NewDate(d.Year, d.Month, 1).String()]
+	return bd, ok
+}
+
+// Months returns the months the budget has data for, in order.
+func (b *Budget) Months() []Date {
+	ret := make([]Date, 0, len(b.Data))
+	for _, bd := range b.Data {
+		ret = append(ret, bd.BudgetMonth)
+	}
+	slices.SortFunc(ret, Date.Compare)
+
+	return ret
+}
+
+// BudgetConfig is the recurring budget setting of a category.
+type BudgetConfig struct {
+	ConfigID    int64   `json:"config_id"`
+	Cadence     string  `json:"cadence"`
+	Amount      Decimal `json:"amount"`
+	Currency    string  `json:"currency"`
+	ToBase      Decimal `json:"to_base"`
+	AutoSuggest string  `json:"auto_suggest"`
+}
+
+// BudgetData is a single month's budget for a category. The *ToBase fields
+// are in the user's primary currency.
 type BudgetData struct {
-	BudgetMonth     string      `json:"budget_month,omitempty" validate:"datetime=2006-01-02"`
-	BudgetToBase    float64     `json:"budget_to_base,omitempty"`
-	BudgetAmount    json.Number `json:"budget_amount,omitempty"`
-	BudgetCurrency  string      `json:"budget_currency,omitempty"`
-	SpendingToBase  float64     `json:"spending_to_base,omitempty"`
-	NumTransactions int         `json:"num_transactions,omitempty"`
+	BudgetMonth     Date    `json:"budget_month,omitempty" validate:"datetime=2006-01-02"`
+	BudgetToBase    Decimal `json:"budget_to_base,omitempty"`
+	BudgetAmount    Decimal `json:"budget_amount,omitempty"`
+	BudgetCurrency  string  `json:"budget_currency,omitempty"`
+	SpendingToBase  Decimal `json:"spending_to_base,omitempty"`
+	NumTransactions int     `json:"num_transactions,omitempty"`
+	IsAutomated     bool    `json:"is_automated,omitempty"`
+}
+
+// Remaining returns how much of the month's budget is left, in the primary
+// currency. It is negative when the category is over budget.
+func (b *BudgetData) Remaining() Decimal {
+	return b.BudgetToBase.Sub(b.SpendingToBase)
+}
+
+// OverBudget reports whether spending exceeded a set budget for the month.
+// Months without a budget are never over budget.
+func (b *BudgetData) OverBudget() bool {
+	return !b.BudgetToBase.IsZero() && b.Remaining().Sign() < 0
 }
 
 // BudgetFilters are options to pass into the request for budget history.
 type BudgetFilters struct {
-	StartDate string `json:"start_date" validate:"datetime=2006-01-02,required"`
-	EndDate   string `json:"end_date" validate:"datetime=2006-01-02,required"`
+	StartDate Date `json:"start_date" validate:"datetime=2006-01-02,required"`
+	EndDate   Date `json:"end_date" validate:"datetime=2006-01-02,required"`
+}
+
+// validate checks that the end date is not before the start date.
+func (r *BudgetFilters) validate() error {
+	return validateDateRange(&r.StartDate, &r.EndDate, true)
 }
 
 // ToMap converts the budget filters to a string map to be sent with the request as
@@ -73,15 +117,14 @@ func (r *BudgetFilters) ToMap() (map[string]string, error) {
 // This provides a convenient way to work with budget figures using the go-money library's
 // currency handling capabilities. Returns an error if the amount cannot be parsed.
 func (b *BudgetData) ParsedAmount() (*money.Money, error) {
-	return ParseCurrency(b.BudgetAmount.String(), b.BudgetCurrency)
+	return b.BudgetAmount.Money(b.BudgetCurrency), nil
 }
 
 // GetBudgets returns budgets within a time period.
 func (c *Client) GetBudgets(ctx context.Context, filters *BudgetFilters) ([]*Budget, error) {
-	validate := validator.New()
 	options := map[string]string{}
 	if filters != nil {
-		if err := validate.StructCtx(ctx, filters); err != nil {
+		if err := c.validateRequest(ctx, filters); err != nil {
 			return nil, err
 		}
 
@@ -92,40 +135,96 @@ func (c *Client) GetBudgets(ctx context.Context, filters *BudgetFilters) ([]*Bud
 		options = maps
 	}
 
-	body, err := c.Get(ctx, "/v1/budgets", options)
-	if err != nil {
-		return nil, fmt.Errorf("get budgets: %w", err)
-	}
-
 	var resp []*Budget
-	var bodyCopy bytes.Buffer
-	tee := io.TeeReader(body, &bodyCopy)
-	if err := json.NewDecoder(tee).Decode(&resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	if err := c.getJSON(ctx, c.endpoint("budgets"), options, &resp); err != nil {
+		return nil, fmt.Errorf("get budgets: %w", err)
 	}
 
 	for _, b := range resp {
 		// Clean up sometimes bad data returned.
 		for k, bd := range b.Data {
-			if bd.BudgetMonth == "" {
-				bd.BudgetMonth = k
+			if bd.BudgetMonth.IsZero() {
+				if err := bd.BudgetMonth.UnmarshalText([]byte(k)); err != nil {
+					return nil, fmt.Errorf("budget month %q: %w", k, err)
+				}
 			}
 		}
+	}
 
-		if err := validate.StructCtx(ctx, b); err != nil {
-			var validationErrors validator.ValidationErrors
-			var invalidValidationError *validator.InvalidValidationError
-
-			switch {
-			case errors.As(err, &validationErrors):
-				return nil, fmt.Errorf("validating response: %s", validationErrors.Error())
-			case errors.As(err, &invalidValidationError):
-				return nil, fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
-			default:
-				return nil, fmt.Errorf("validating response (%T): %w", err, err)
-			}
+	budgets, err := validateRecords(ctx, c, resp)
+	if err != nil {
+		return nil, responseValidationError(err)
+	}
+
+	return budgets, nil
+}
+
+// BudgetPeriod is a category's budget summed over the months of a period,
+// in the user's primary currency.
+type BudgetPeriod struct {
+	Start, End      Date
+	Budgeted        Decimal
+	Spent           Decimal
+	NumTransactions int
+	// Months is the number of months in the period the category has data
+	// for.
+	Months int
+}
+
+// Remaining returns how much of the period's budget is left. It is negative
+// when the category is over budget.
+func (p *BudgetPeriod) Remaining() Decimal {
+	return p.Budgeted.Sub(p.Spent)
+}
+
+// OverBudget reports whether spending exceeded a set budget over the period.
+func (p *BudgetPeriod) OverBudget() bool {
+	return !p.Budgeted.IsZero() && p.Remaining().Sign() < 0
+}
+
+// Period sums the months of b between start and end, inclusive, using the
+// amounts converted to the primary currency, so categories budgeted in
+// several currencies add up. A month is included if its first day is in the
+// range or start falls within it.
+func (b *Budget) Period(start, end Date) *BudgetPeriod {
+	p := &BudgetPeriod{Start: start, End: end}
+	first := NewDate(start.Year, start.Month, 1)
+	for _, bd := range b.Data {
+		if bd.BudgetMonth.Before(first) || bd.BudgetMonth.After(end) {
+			continue
 		}
+		p.Budgeted = p.Budgeted.Add(bd.BudgetToBase)
+		p.Spent = p.Spent.Add(bd.SpendingToBase)
+		p.NumTransactions += bd.NumTransactions
+		p.Months++
+	}
+
+	return p
+}
+
+// BudgetTotal is a category's budget collapsed into a single period.
+type BudgetTotal struct {
+	Budget *Budget
+	Period *BudgetPeriod
+}
+
+// GetBudgetTotals returns one total per category for the period of filters,
+// for callers that want a single number per category rather than a map of
+// months.
+func (c *Client) GetBudgetTotals(ctx context.Context, filters *BudgetFilters) ([]*BudgetTotal, error) {
+	if filters == nil {
+		filters = &BudgetFilters{}
 	}
 
-	return resp, nil
+	budgets, err := c.GetBudgets(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*BudgetTotal, len(budgets))
+	for i, b := range budgets {
+		ret[i] = &BudgetTotal{Budget: b, Period: b.Period(filters.StartDate, filters.EndDate)}
+	}
+
+	return ret, nil
 }
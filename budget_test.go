@@ -0,0 +1,78 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBudgets(t *testing.T) {
+	data, err := os.ReadFile("testdata/budgets.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2021-01-01", r.URL.Query().Get("start_date"))
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	budgets, err := client.GetBudgets(context.Background(), &BudgetFilters{
+		StartDate: NewDate(2021, 1, 1),
+		EndDate:   NewDate(2021, 12, 31),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, budgets)
+
+	extras := budgets[0]
+	months := extras.Months()
+	require.NotEmpty(t, months)
+	assert.Equal(t, NewDate(2021, 1, 1), months[0])
+
+	jan, ok := extras.Month(NewDate(2021, 1, 15))
+	require.True(t, ok)
+	assert.Equal(t, "-4240.07", jan.Remaining().String())
+	assert.True(t, jan.OverBudget())
+}
+
+func TestGetBudgetTotals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"category_id": 1, "category_name": "Travel", "data": {
+			"2024-01-01": {"budget_to_base": 100, "budget_amount": 90, "budget_currency": "eur", "spending_to_base": 50, "num_transactions": 2},
+			"2024-02-01": {"budget_to_base": 100, "budget_amount": 90, "budget_currency": "eur", "spending_to_base": 175.5, "num_transactions": 3},
+			"2024-03-01": {"budget_to_base": 100, "spending_to_base": 10, "num_transactions": 1}
+		}}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	totals, err := client.GetBudgetTotals(context.Background(), &BudgetFilters{StartDate: NewDate(2024, 1, 15), EndDate: NewDate(2024, 2, 29)})
+	require.NoError(t, err)
+	require.Len(t, totals, 1)
+	assert.Equal(t, "Travel", totals[0].Budget.CategoryName)
+
+	p := totals[0].Period
+	assert.Equal(t, 2, p.Months)
+	assert.Equal(t, "200", p.Budgeted.String())
+	assert.Equal(t, "225.5", p.Spent.String())
+	assert.Equal(t, 5, p.NumTransactions)
+	assert.True(t, p.OverBudget())
+
+	_, err = client.GetBudgetTotals(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestBudgetDataOverBudget(t *testing.T) {
+	assert.False(t, (&BudgetData{SpendingToBase: MustParseDecimal("10")}).OverBudget())
+	assert.False(t, (&BudgetData{BudgetToBase: MustParseDecimal("10"), SpendingToBase: MustParseDecimal("10")}).OverBudget())
+	assert.True(t, (&BudgetData{BudgetToBase: MustParseDecimal("10"), SpendingToBase: MustParseDecimal("10.01")}).OverBudget())
+}
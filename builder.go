@@ -0,0 +1,249 @@
+package lunchmoney
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// TransactionBuilder builds an InsertTransaction without the pointer
+// boilerplate:
+//
+//	t, err := lunchmoney.NewTransaction(date, amount, "Airline").
+//		Category(travel).
+//		Tag("travel").
+//		Cleared().
+//		Build()
+//
+// Each setter checks its value as it is set; Build reports every problem
+// found together in a ValidationError.
+type TransactionBuilder struct {
+	t      InsertTransaction
+	fields []FieldError
+}
+
+// NewTransaction starts building a transaction of amount paid to payee on
+// date.
+func NewTransaction(date Date, amount Decimal, payee string) *TransactionBuilder {
+	return &TransactionBuilder{t: InsertTransaction{Date: date, Amount: amount, Payee: payee}}
+}
+
+// Category sets the transaction's category.
+func (b *TransactionBuilder) Category(id CategoryID) *TransactionBuilder {
+	b.t.CategoryID = &id
+	return b
+}
+
+// Asset puts the transaction in a manually-managed asset.
+func (b *TransactionBuilder) Asset(id AssetID) *TransactionBuilder {
+	b.t.AssetID = &id
+	return b
+}
+
+// PlaidAccount puts the transaction in a Plaid account.
+func (b *TransactionBuilder) PlaidAccount(id PlaidAccountID) *TransactionBuilder {
+	b.t.PlaidAccountID = &id
+	return b
+}
+
+// Recurring matches the transaction to a recurring item.
+func (b *TransactionBuilder) Recurring(id RecurringID) *TransactionBuilder {
+	b.t.RecurringID = &id
+	return b
+}
+
+// Currency sets the transaction's currency, such as "usd". It defaults to
+// the user's primary currency.
+func (b *TransactionBuilder) Currency(code string) *TransactionBuilder {
+	if len(code) != 3 {
+		b.invalid("currency", "len", "3", code)
+		return b
+	}
+	b.t.Currency = strings.ToLower(code)
+	return b
+}
+
+// Notes sets the transaction's notes.
+func (b *TransactionBuilder) Notes(notes string) *TransactionBuilder {
+	b.t.Notes = notes
+	return b
+}
+
+// ExternalID sets the transaction's external ID, used by importers to
+// recognise transactions they created.
+func (b *TransactionBuilder) ExternalID(id string) *TransactionBuilder {
+	if len(id) > 75 {
+		b.invalid("external_id", "max", "75", id)
+		return b
+	}
+	b.t.ExternalID = id
+	return b
+}
+
+// Tag attaches the tag with the given name, which is created if it does not
+// exist yet.
+func (b *TransactionBuilder) Tag(name string) *TransactionBuilder {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		b.invalid("tags", "required", "", name)
+		return b
+	}
+	b.t.TagNames = append(b.t.TagNames, name)
+	return b
+}
+
+// TagByID attaches the tag with the given ID.
+func (b *TransactionBuilder) TagByID(id TagID) *TransactionBuilder {
+	b.t.TagsIDs = append(b.t.TagsIDs, id)
+	return b
+}
+
+// Cleared marks the transaction as cleared.
+func (b *TransactionBuilder) Cleared() *TransactionBuilder {
+	b.t.Status = "cleared"
+	return b
+}
+
+// Uncleared marks the transaction as uncleared.
+func (b *TransactionBuilder) Uncleared() *TransactionBuilder {
+	b.t.Status = "uncleared"
+	return b
+}
+
+// Build returns the transaction, or a ValidationError listing every invalid
+// value.
+func (b *TransactionBuilder) Build() (*InsertTransaction, error) {
+	fields := slices.Clone(b.fields)
+	if err := newValidationError(newValidator().Struct(&b.t)); err != nil {
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			return nil, err
+		}
+		fields = append(fields, verr.Fields...)
+	}
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+
+	t := b.t
+	return &t, nil
+}
+
+func (b *TransactionBuilder) invalid(field, constraint, param string, value any) {
+	b.fields = append(b.fields, FieldError{Field: field, Constraint: constraint, Param: param, Value: value})
+}
+
+// UpdateBuilder builds an UpdateTransaction with only the fields that were
+// set, so a zero value can be set deliberately and an untouched field is
+// never sent:
+//
+//	ut, err := lunchmoney.NewUpdate().SetCategory(groceries).SetNotes("").MarkCleared().Build()
+//
+// Like TransactionBuilder, setters check their values and Build reports
+// every problem together.
+type UpdateBuilder struct {
+	ut     UpdateTransaction
+	fields []FieldError
+}
+
+// NewUpdate starts building an update that changes nothing.
+func NewUpdate() *UpdateBuilder {
+	return &UpdateBuilder{}
+}
+
+// SetDate changes the transaction's date.
+func (b *UpdateBuilder) SetDate(d Date) *UpdateBuilder {
+	b.ut.Date = &d
+	return b
+}
+
+// SetCategory changes the transaction's category.
+func (b *UpdateBuilder) SetCategory(id CategoryID) *UpdateBuilder {
+	b.ut.CategoryID = &id
+	return b
+}
+
+// SetPayee changes the transaction's payee.
+func (b *UpdateBuilder) SetPayee(payee string) *UpdateBuilder {
+	b.ut.Payee = &payee
+	return b
+}
+
+// SetCurrency changes the transaction's currency, such as "usd".
+func (b *UpdateBuilder) SetCurrency(code string) *UpdateBuilder {
+	if len(code) != 3 {
+		b.invalid("currency", "len", "3", code)
+		return b
+	}
+	b.ut.Currency = Ptr(strings.ToLower(code))
+	return b
+}
+
+// SetAsset moves the transaction to a manually-managed asset.
+func (b *UpdateBuilder) SetAsset(id AssetID) *UpdateBuilder {
+	b.ut.AssetID = &id
+	return b
+}
+
+// SetRecurring matches the transaction to a recurring item.
+func (b *UpdateBuilder) SetRecurring(id RecurringID) *UpdateBuilder {
+	b.ut.RecurringID = &id
+	return b
+}
+
+// SetNotes changes the transaction's notes. An empty string clears them.
+func (b *UpdateBuilder) SetNotes(notes string) *UpdateBuilder {
+	b.ut.Notes = &notes
+	return b
+}
+
+// SetExternalID changes the transaction's external ID.
+func (b *UpdateBuilder) SetExternalID(id string) *UpdateBuilder {
+	if len(id) > 75 {
+		b.invalid("external_id", "max", "75", id)
+		return b
+	}
+	b.ut.ExternalID = &id
+	return b
+}
+
+// SetTags replaces the transaction's tags.
+func (b *UpdateBuilder) SetTags(ids ...TagID) *UpdateBuilder {
+	b.ut.TagsIDs = append([]TagID{}, ids...)
+	return b
+}
+
+// MarkCleared marks the transaction as cleared.
+func (b *UpdateBuilder) MarkCleared() *UpdateBuilder {
+	b.ut.Status = Ptr("cleared")
+	return b
+}
+
+// MarkUncleared marks the transaction as uncleared.
+func (b *UpdateBuilder) MarkUncleared() *UpdateBuilder {
+	b.ut.Status = Ptr("uncleared")
+	return b
+}
+
+// Build returns the update, or a ValidationError listing every invalid
+// value.
+func (b *UpdateBuilder) Build() (*UpdateTransaction, error) {
+	fields := slices.Clone(b.fields)
+	if err := newValidationError(newValidator().Struct(&b.ut)); err != nil {
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			return nil, err
+		}
+		fields = append(fields, verr.Fields...)
+	}
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+
+	ut := b.ut
+	return &ut, nil
+}
+
+func (b *UpdateBuilder) invalid(field, constraint, param string, value any) {
+	b.fields = append(b.fields, FieldError{Field: field, Constraint: constraint, Param: param, Value: value})
+}
@@ -0,0 +1,90 @@
+package lunchmoney
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionBuilder(t *testing.T) {
+	txn, err := NewTransaction(MustParseDate("2024-06-01"), MustParseDecimal("420.00"), "Airline").
+		Category(4).
+		Currency("EUR").
+		Tag("travel").
+		TagByID(9).
+		ExternalID("bank-123").
+		Cleared().
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, CategoryID(4), *txn.CategoryID)
+	assert.Equal(t, "eur", txn.Currency)
+	assert.Equal(t, "cleared", txn.Status)
+
+	data, err := json.Marshal(txn)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"date": "2024-06-01",
+		"amount": "420.00",
+		"payee": "Airline",
+		"category_id": 4,
+		"currency": "eur",
+		"status": "cleared",
+		"external_id": "bank-123",
+		"tags": [9, "travel"]
+	}`, string(data))
+
+	var decoded InsertTransaction
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []TagID{9}, decoded.TagsIDs)
+	assert.Equal(t, []string{"travel"}, decoded.TagNames)
+}
+
+func TestTransactionBuilderInvalid(t *testing.T) {
+	_, err := NewTransaction(Date{}, MustParseDecimal("1"), "Shop").
+		Currency("euro").
+		Tag(" ").
+		ExternalID(strings.Repeat("x", 76)).
+		Build()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	var fields []string
+	for _, f := range verr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"currency", "tags", "external_id", "date"}, fields)
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	ut, err := NewUpdate().SetCategory(0).SetNotes("").MarkCleared().Build()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(ut)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"category_id": 0, "notes": "", "status": "cleared"}`, string(data))
+
+	_, err = NewUpdate().SetCurrency("dollars").SetExternalID(strings.Repeat("x", 76)).Build()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Fields, 2)
+}
+
+func TestUpdateBuilderClearTags(t *testing.T) {
+	ut, err := NewUpdate().SetTags().Build()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(&UpdateRequest{Transaction: ut})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"transaction": {"tags": []}}`, string(data))
+
+	ut, err = NewUpdate().SetTags(2, 5).Build()
+	require.NoError(t, err)
+	data, err = json.Marshal(ut)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tags": [2, 5]}`, string(data))
+}
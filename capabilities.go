@@ -0,0 +1,166 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Capability names an optional API feature that not every API version
+// supports.
+type Capability string
+
+const (
+	// CapabilityTagWrite is support for creating, updating and archiving
+	// tags.
+	CapabilityTagWrite Capability = "tags.write"
+	// CapabilityRecurringItems is the /v1/recurring_items endpoint that
+	// replaces recurring expenses.
+	CapabilityRecurringItems Capability = "recurring_items"
+	// CapabilityCrypto is the /v1/crypto endpoint.
+	CapabilityCrypto Capability = "crypto"
+)
+
+// probe describes how to detect a capability. The endpoint at path is
+// requested with method; it is supported unless the API answers that the
+// route does not exist. If allow is set, the Allow header of the response
+// must also list that method.
+type probe struct {
+	method   string
+	endpoint string
+	allow    string
+}
+
+var probes = map[Capability]probe{
+	CapabilityTagWrite:       {method: http.MethodOptions, endpoint: "tags", allow: http.MethodPost},
+	CapabilityRecurringItems: {method: http.MethodGet, endpoint: "recurring_items"},
+	CapabilityCrypto:         {method: http.MethodGet, endpoint: "crypto"},
+}
+
+// capabilityCache holds the result of probing the API. It is shared by
+// pointer so the Client struct stays copyable.
+type capabilityCache struct {
+	mu     sync.Mutex
+	probed map[Capability]bool
+}
+
+// WithCapabilities declares that the API supports the given optional
+// features, enabling the client methods that depend on them without probing.
+func WithCapabilities(caps ...Capability) Option {
+	return func(c *Client) error {
+		m := maps.Clone(c.capabilities)
+		if m == nil {
+			m = map[Capability]bool{}
+		}
+
+		for _, cap := range caps {
+			m[cap] = true
+		}
+		c.capabilities = m
+		return nil
+	}
+}
+
+// Supports reports whether the client knows the API supports cap, either
+// because it was declared with WithCapabilities or found by Capabilities.
+func (c *Client) Supports(cap Capability) bool {
+	if c.capabilities[cap] {
+		return true
+	}
+
+	if c.probed == nil {
+		return false
+	}
+
+	c.probed.mu.Lock()
+	defer c.probed.mu.Unlock()
+	return c.probed.probed[cap]
+}
+
+// Capabilities probes the API for the optional features it supports and
+// returns them along with any declared with WithCapabilities. Probes are
+// cheap GET or OPTIONS requests made once per client; later calls return the
+// cached result. If a probe fails nothing is cached, so the call can be
+// retried.
+func (c *Client) Capabilities(ctx context.Context) (map[Capability]bool, error) {
+	cache := c.probed
+	if cache == nil {
+		cache = &capabilityCache{}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	ctx = WithDefaultOperation(ctx, "capabilities")
+	if cache.probed == nil {
+		found := map[Capability]bool{}
+		for cap, p := range probes {
+			ok, err := c.probe(ctx, p)
+			if err != nil {
+				return nil, fmt.Errorf("probe %s: %w", cap, err)
+			}
+			found[cap] = ok
+		}
+		cache.probed = found
+	}
+
+	ret := maps.Clone(cache.probed)
+	for cap, ok := range c.capabilities {
+		ret[cap] = ret[cap] || ok
+	}
+
+	return ret, nil
+}
+
+func (c *Client) probe(ctx context.Context, p probe) (bool, error) {
+	u := *c.base
+	u.Path = c.endpoint(p.endpoint)
+	req, err := http.NewRequestWithContext(ctx, p.method, u.String(), nil)
+	if err != nil {
+		return false, fmt.Errorf("could not create request: %w", err)
+	}
+
+	_, resp, err := c.send(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return false, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, fmt.Errorf("%s", resp.Status)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return false, fmt.Errorf("%s", resp.Status)
+	}
+
+	if p.allow == "" {
+		return true, nil
+	}
+
+	var methods []string
+	for _, v := range resp.Header.Values("Allow") {
+		for _, m := range strings.Split(v, ",") {
+			methods = append(methods, strings.ToUpper(strings.TrimSpace(m)))
+		}
+	}
+
+	return slices.Contains(methods, p.allow), nil
+}
+
+func (c *Client) require(cap Capability) error {
+	if !c.Supports(cap) {
+		return fmt.Errorf("%s: %w", cap, ErrUnsupported)
+	}
+
+	return nil
+}
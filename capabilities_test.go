@@ -0,0 +1,64 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.Method == http.MethodOptions && r.URL.Path == "/v1/tags":
+			w.Header().Set("Allow", "GET, POST")
+		case r.URL.Path == "/v1/crypto":
+			_, _ = w.Write([]byte(`{"crypto": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	assert.False(t, client.Supports(CapabilityTagWrite))
+
+	caps, err := client.Capabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[Capability]bool{
+		CapabilityTagWrite:       true,
+		CapabilityRecurringItems: false,
+		CapabilityCrypto:         true,
+	}, caps)
+	assert.True(t, client.Supports(CapabilityTagWrite))
+
+	_, err = client.Capabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, len(probes), calls)
+
+	declared, err := client.With(WithCapabilities(CapabilityRecurringItems))
+	require.NoError(t, err)
+	assert.True(t, declared.Supports(CapabilityRecurringItems))
+	assert.False(t, declared.Supports(CapabilityTagWrite))
+}
+
+func TestCapabilitiesUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Capabilities(context.Background())
+	require.Error(t, err)
+	assert.False(t, client.Supports(CapabilityCrypto))
+}
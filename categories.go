@@ -1,15 +1,9 @@
 package lunchmoney
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
-	"time"
-
-	"github.com/go-playground/validator/v10"
 )
 
 // CategoriesResponse is the response we get from requesting categories.
@@ -23,16 +17,54 @@ type CategoriesResponse struct {
 // Categories are used to organize transactions and budgets.
 // They can be grouped hierarchically and marked as income or excluded from various calculations.
 type Category struct {
-	ID                int64     `json:"id"`                  // Unique identifier for the category
-	Name              string    `json:"name"`                // Display name of the category
-	Description       string    `json:"description"`         // Optional description of the category
-	IsIncome          bool      `json:"is_income"`           // Whether this category represents income
-	ExcludeFromBudget bool      `json:"exclude_from_budget"` // Whether to exclude from budget calculations
-	ExcludeFromTotals bool      `json:"exclude_from_totals"` // Whether to exclude from total calculations
-	UpdatedAt         time.Time `json:"updated_at"`          // Last modification timestamp
-	CreatedAt         time.Time `json:"created_at"`          // Creation timestamp
-	IsGroup           bool      `json:"is_group"`            // Whether this category is a group
-	GroupID           int64     `json:"group_id"`            // ID of the parent group, if any
+	ID                CategoryID  `json:"id"`                  // Unique identifier for the category
+	Name              string      `json:"name"`                // Display name of the category
+	Description       string      `json:"description"`         // Optional description of the category
+	IsIncome          bool        `json:"is_income"`           // Whether this category represents income
+	ExcludeFromBudget bool        `json:"exclude_from_budget"` // Whether to exclude from budget calculations
+	ExcludeFromTotals bool        `json:"exclude_from_totals"` // Whether to exclude from total calculations
+	UpdatedAt         Timestamp   `json:"updated_at"`          // Last modification timestamp
+	CreatedAt         Timestamp   `json:"created_at"`          // Creation timestamp
+	IsGroup           bool        `json:"is_group"`            // Whether this category is a group
+	GroupID           *CategoryID `json:"group_id"`            // ID of the parent group, nil if ungrouped
+	GroupCategoryName string      `json:"group_category_name"` // Name of the parent group, empty if ungrouped
+	Archived          bool        `json:"archived"`            // Whether the category is archived
+	ArchivedOn        Timestamp   `json:"archived_on"`         // When the category was archived, zero if not archived
+	Order             int         `json:"order"`               // Position of the category in the user's chosen ordering
+	Children          []*Category `json:"children,omitempty"`  // Categories in the group, only set for groups
+
+	// Extra holds the fields of the category the client does not know about
+	// yet, so they survive re-encoding.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes c, keeping unknown fields in Extra.
+func (c *Category) UnmarshalJSON(data []byte) error {
+	type plain Category
+	extra, err := decodeExtra(data, (*plain)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields in Extra.
+func (c Category) MarshalJSON() ([]byte, error) {
+	type plain Category
+	return encodeExtra(plain(c), c.Extra)
+}
+
+// String describes the category for logs and debugging, such as
+// "category 3 Groceries" or "category group 4 Food".
+func (c *Category) String() string {
+	if c.IsGroup {
+		return fmt.Sprintf("category group %d %s", c.ID, c.Name)
+	}
+
+	return fmt.Sprintf("category %d %s", c.ID, c.Name)
+}
+
+// InGroup reports whether the category belongs to a category group.
+func (c *Category) InGroup() bool {
+	return c.GroupID != nil
 }
 
 // GetCategories returns a flattened list of all categories in alphabetical
@@ -43,36 +75,18 @@ type Category struct {
 // The context can be used to control the request lifecycle.
 // Returns an error if the API request fails or if the response cannot be validated.
 func (c *Client) GetCategories(ctx context.Context) ([]*Category, error) {
-	validate := validator.New()
 	options := map[string]string{}
-	body, err := c.Get(ctx, "/v1/categories", options)
-	if err != nil {
+	var resp *CategoriesResponse
+	if err := c.getJSON(ctx, c.endpoint("categories"), options, &resp); err != nil {
 		return nil, fmt.Errorf("get categories: %w", err)
 	}
 
-	var resp *CategoriesResponse
-	var bodyCopy bytes.Buffer
-	tee := io.TeeReader(body, &bodyCopy)
-	if err := json.NewDecoder(tee).Decode(&resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	categories, err := validateRecords(ctx, c, resp.Categories)
+	if err != nil {
+		return nil, responseValidationError(err)
 	}
 
-	for _, b := range resp.Categories {
-		if err := validate.StructCtx(ctx, b); err != nil {
-			var validationErrors validator.ValidationErrors
-			var invalidValidationError *validator.InvalidValidationError
-
-			switch {
-			case errors.As(err, &validationErrors):
-				return nil, fmt.Errorf("validating response: %s", validationErrors.Error())
-			case errors.As(err, &invalidValidationError):
-				return nil, fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
-			default:
-				return nil, fmt.Errorf("validating response (%T): %w", err, err)
-			}
-		}
-	}
-	return resp.Categories, nil
+	return categories, nil
 }
 
 // GetCategory retrieves a single category by its ID.
@@ -85,34 +99,70 @@ func (c *Client) GetCategories(ctx context.Context) ([]*Category, error) {
 //
 // Returns the category details or an error if the request fails or
 // the response cannot be validated.
-func (c *Client) GetCategory(ctx context.Context, id int64) (*Category, error) {
+func (c *Client) GetCategory(ctx context.Context, id CategoryID) (*Category, error) {
 	options := map[string]string{}
-	body, err := c.Get(ctx, fmt.Sprintf("/v1/categories/%d", id), options)
-	if err != nil {
-		return nil, fmt.Errorf("error getting category: %w", err)
-	}
-
 	var resp *Category
-	var bodyCopy bytes.Buffer
-	tee := io.TeeReader(body, &bodyCopy)
-	if err := json.NewDecoder(tee).Decode(&resp); err != nil {
+	if err := c.getJSON(ctx, c.endpoint("categories/%d", id), options, &resp); err != nil {
 		return nil, fmt.Errorf("error getting category: %w", err)
 	}
 
-	validate := validator.New()
-	if err := validate.StructCtx(ctx, resp); err != nil {
-		var validationErrors validator.ValidationErrors
-		var invalidValidationError *validator.InvalidValidationError
-
-		switch {
-		case errors.As(err, &validationErrors):
-			return nil, fmt.Errorf("validating response: %s", validationErrors.Error())
-		case errors.As(err, &invalidValidationError):
-			return nil, fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
-		default:
-			return nil, fmt.Errorf("validating response (%T): %w", err, err)
-		}
+	if err := c.validateRecord(ctx, resp); err != nil {
+		return nil, responseValidationError(err)
 	}
 
 	return resp, nil
 }
+
+// CreateCategory holds the fields of a new category.
+type CreateCategory struct {
+	Name              string      `json:"name" validate:"required,max=40"`
+	Description       string      `json:"description,omitempty" validate:"max=140"`
+	IsIncome          bool        `json:"is_income"`
+	ExcludeFromBudget bool        `json:"exclude_from_budget"`
+	ExcludeFromTotals bool        `json:"exclude_from_totals"`
+	Archived          bool        `json:"archived,omitempty"`
+	GroupID           *CategoryID `json:"group_id,omitempty"`
+}
+
+// CreateCategoryGroup holds the fields of a new category group.
+// CategoryIDs are existing categories to move into the group.
+type CreateCategoryGroup struct {
+	Name              string       `json:"name" validate:"required,max=40"`
+	Description       string       `json:"description,omitempty" validate:"max=140"`
+	IsIncome          bool         `json:"is_income"`
+	ExcludeFromBudget bool         `json:"exclude_from_budget"`
+	ExcludeFromTotals bool         `json:"exclude_from_totals"`
+	CategoryIDs       []CategoryID `json:"category_ids,omitempty"`
+}
+
+type createCategoryResponse struct {
+	CategoryID CategoryID `json:"category_id"`
+}
+
+// CreateCategory creates a category and returns its ID.
+func (c *Client) CreateCategory(ctx context.Context, cat *CreateCategory) (CategoryID, error) {
+	return c.createCategory(ctx, c.endpoint("categories"), cat)
+}
+
+// CreateCategoryGroup creates a category group and returns its ID.
+func (c *Client) CreateCategoryGroup(ctx context.Context, g *CreateCategoryGroup) (CategoryID, error) {
+	return c.createCategory(ctx, c.endpoint("categories/group"), g)
+}
+
+func (c *Client) createCategory(ctx context.Context, path string, req any) (CategoryID, error) {
+	if err := c.validateRequest(ctx, req); err != nil {
+		return 0, err
+	}
+
+	body, err := c.Post(ctx, path, req)
+	if err != nil {
+		return 0, fmt.Errorf("create category: %w", err)
+	}
+
+	resp := &createCategoryResponse{}
+	if err := c.decode(body, resp); err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp.CategoryID, nil
+}
@@ -2,9 +2,9 @@ package lunchmoney
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
 	"time"
 
@@ -48,10 +48,10 @@ func TestGetCategories(t *testing.T) {
 					IsIncome:          false,
 					ExcludeFromBudget: false,
 					ExcludeFromTotals: false,
-					UpdatedAt:         time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-					CreatedAt:         time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:         Timestamp{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+					CreatedAt:         Timestamp{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
 					IsGroup:           false,
-					GroupID:           0,
+					GroupID:           Ptr(CategoryID(0)),
 				},
 			},
 		},
@@ -75,9 +75,7 @@ func TestGetCategories(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client, err := NewClient("test-token")
-			require.NoError(t, err)
-			client.Base, err = url.Parse(server.URL)
+			client, err := NewClient("test-token", WithBaseURL(server.URL))
 			require.NoError(t, err)
 
 			got, err := client.GetCategories(context.Background())
@@ -96,7 +94,7 @@ func TestGetCategories(t *testing.T) {
 func TestGetCategory(t *testing.T) {
 	tests := []struct {
 		name        string
-		id          int64
+		id          CategoryID
 		response    string
 		statusCode  int
 		wantErr     bool
@@ -126,10 +124,10 @@ func TestGetCategory(t *testing.T) {
 				IsIncome:          false,
 				ExcludeFromBudget: false,
 				ExcludeFromTotals: false,
-				UpdatedAt:         time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
-				CreatedAt:         time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:         Timestamp{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+				CreatedAt:         Timestamp{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
 				IsGroup:           false,
-				GroupID:           0,
+				GroupID:           Ptr(CategoryID(0)),
 			},
 		},
 		{
@@ -153,9 +151,7 @@ func TestGetCategory(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client, err := NewClient("test-token")
-			require.NoError(t, err)
-			client.Base, err = url.Parse(server.URL)
+			client, err := NewClient("test-token", WithBaseURL(server.URL))
 			require.NoError(t, err)
 
 			got, err := client.GetCategory(context.Background(), tt.id)
@@ -170,3 +166,51 @@ func TestGetCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoryFullModel(t *testing.T) {
+	var c Category
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": 10,
+		"name": "Food",
+		"is_group": true,
+		"group_id": null,
+		"archived": true,
+		"archived_on": "2024-02-01T10:00:00.000Z",
+		"order": 3,
+		"children": [
+			{"id": 11, "name": "Groceries", "group_id": 10, "group_category_name": "Food"}
+		]
+	}`), &c))
+
+	assert.True(t, c.Archived)
+	assert.Equal(t, time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC), c.ArchivedOn.Time)
+	assert.Equal(t, 3, c.Order)
+	require.Len(t, c.Children, 1)
+	assert.Equal(t, "Food", c.Children[0].GroupCategoryName)
+	assert.Equal(t, CategoryID(10), *c.Children[0].GroupID)
+}
+
+func TestCreateCategory(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"category_id": 83}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	id, err := client.CreateCategory(context.Background(), &CreateCategory{Name: "Coffee"})
+	require.NoError(t, err)
+	assert.Equal(t, CategoryID(83), id)
+
+	_, err = client.CreateCategoryGroup(context.Background(), &CreateCategoryGroup{Name: "Food", CategoryIDs: []CategoryID{83}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/v1/categories", "/v1/categories/group"}, paths)
+
+	_, err = client.CreateCategory(context.Background(), &CreateCategory{})
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Len(t, paths, 2)
+}
@@ -0,0 +1,120 @@
+package lunchmoney
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// CategoryNode is a category in a CategoryTree.
+type CategoryNode struct {
+	*Category
+	// Parent is the group containing the category, nil at the top level.
+	Parent *CategoryNode
+	// Children are the categories of a group, in display order.
+	Children []*CategoryNode
+}
+
+// CategoryTree arranges the flat category list returned by GetCategories
+// into groups and their children, with lookups by ID and name.
+type CategoryTree struct {
+	// Roots are the category groups and ungrouped categories, in display
+	// order.
+	Roots []*CategoryNode
+
+	byID   map[CategoryID]*CategoryNode
+	byName map[string]*CategoryNode
+}
+
+// NewCategoryTree builds a tree from cats. Categories nested in the Children
+// of a group are included as well, so either shape of the API response
+// works. Siblings are ordered by Order, then name, then ID. A category whose
+// group is missing from cats is placed at the top level.
+func NewCategoryTree(cats []*Category) *CategoryTree {
+	t := &CategoryTree{
+		byID:   map[CategoryID]*CategoryNode{},
+		byName: map[string]*CategoryNode{},
+	}
+
+	var add func(cs []*Category)
+	add = func(cs []*Category) {
+		for _, c := range cs {
+			if _, ok := t.byID[c.ID]; !ok {
+				t.byID[c.ID] = &CategoryNode{Category: c}
+			}
+			add(c.Children)
+		}
+	}
+	add(cats)
+
+	for _, n := range t.byID {
+		if n.GroupID != nil && *n.GroupID != n.ID {
+			if p, ok := t.byID[*n.GroupID]; ok {
+				n.Parent = p
+				p.Children = append(p.Children, n)
+				continue
+			}
+		}
+		t.Roots = append(t.Roots, n)
+	}
+
+	sortNodes(t.Roots)
+	t.Walk(func(n *CategoryNode, _ int) {
+		sortNodes(n.Children)
+		key := strings.ToLower(n.Name)
+		if _, ok := t.byName[key]; !ok {
+			t.byName[key] = n
+		}
+	})
+
+	return t
+}
+
+func sortNodes(ns []*CategoryNode) {
+	slices.SortFunc(ns, func(a, b *CategoryNode) int {
+		return cmp.Or(
+			cmp.Compare(a.Order, b.Order),
+			cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+}
+
+// ByID returns the category with the given ID.
+func (t *CategoryTree) ByID(id CategoryID) (*CategoryNode, bool) {
+	n, ok := t.byID[id]
+	return n, ok
+}
+
+// ByName returns the category with the given name, compared
+// case-insensitively. If several categories share a name, the first in tree
+// order is returned.
+func (t *CategoryTree) ByName(name string) (*CategoryNode, bool) {
+	n, ok := t.byName[strings.ToLower(name)]
+	return n, ok
+}
+
+// Walk calls fn for every category in tree order, parents before their
+// children. depth is 0 for top-level categories.
+func (t *CategoryTree) Walk(fn func(n *CategoryNode, depth int)) {
+	var walk func(ns []*CategoryNode, depth int)
+	walk = func(ns []*CategoryNode, depth int) {
+		for _, n := range ns {
+			fn(n, depth)
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(t.Roots, 0)
+}
+
+// Path returns the names from the top level down to n, such as
+// ["Food", "Groceries"].
+func (n *CategoryNode) Path() []string {
+	var ret []string
+	for ; n != nil; n = n.Parent {
+		ret = append(ret, n.Name)
+	}
+	slices.Reverse(ret)
+
+	return ret
+}
@@ -0,0 +1,40 @@
+package lunchmoney
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryTree(t *testing.T) {
+	food := CategoryID(1)
+	tree := NewCategoryTree([]*Category{
+		{ID: 3, Name: "Restaurants", GroupID: &food, Order: 2},
+		{ID: 5, Name: "Rent"},
+		{ID: 1, Name: "Food", IsGroup: true, Children: []*Category{
+			{ID: 2, Name: "Groceries", GroupID: &food, Order: 1},
+		}},
+		{ID: 4, Name: "Coffee", GroupID: &food, Order: 2},
+		{ID: 6, Name: "Orphan", GroupID: Ptr(CategoryID(99))},
+	})
+
+	var got []string
+	tree.Walk(func(n *CategoryNode, depth int) {
+		got = append(got, strings.Repeat("-", depth)+n.Name)
+	})
+	assert.Equal(t, []string{"Food", "-Groceries", "-Coffee", "-Restaurants", "Orphan", "Rent"}, got)
+
+	n, ok := tree.ByName("groceries")
+	require.True(t, ok)
+	assert.Equal(t, CategoryID(2), n.ID)
+	assert.Equal(t, []string{"Food", "Groceries"}, n.Path())
+
+	n, ok = tree.ByID(4)
+	require.True(t, ok)
+	assert.Equal(t, "Food", n.Parent.Name)
+
+	_, ok = tree.ByID(99)
+	assert.False(t, ok)
+}
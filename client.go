@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Rhymond/go-money"
+	"github.com/go-playground/validator/v10"
 )
 
 const (
@@ -19,8 +23,9 @@ const (
 )
 
 type addAuthHeaderTransport struct {
-	T   http.RoundTripper
-	Key string
+	T         http.RoundTripper
+	Key       string
+	UserAgent string
 }
 
 func (adt *addAuthHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -29,30 +34,169 @@ func (adt *addAuthHeaderTransport) RoundTrip(req *http.Request) (*http.Response,
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", adt.Key))
-	req.Header.Add("User-Agent", "github.com/icco/lunchmoney/0.0.0")
+	req.Header.Set("User-Agent", adt.UserAgent)
 
 	return adt.T.RoundTrip(req)
 }
 
-// Client holds our base configuration for our LunchMoney client.
+// Client holds our base configuration for our LunchMoney client. It is
+// configured with options when it is created and never changes afterwards,
+// so it is safe for concurrent use; With derives a differently configured
+// copy.
 type Client struct {
-	HTTP *http.Client
-	Base *url.URL
+	httpClient *http.Client
+	base       *url.URL
+
+	// limiter, if set, throttles every request made by the client. Requests
+	// are scheduled by the Priority attached to their context.
+	limiter *RateLimiter
+
+	logger       *slog.Logger
+	dryRun       bool
+	capabilities map[Capability]bool
+	probed       *capabilityCache
+	stats        *stats
+
+	skipValidation     bool
+	strictDecoding     bool
+	schemaValidation   bool
+	maxResponseSize    int64
+	apiVersion         APIVersion
+	endpointVersions   map[string]APIVersion
+	maxRetries         int
+	retryBackoff       time.Duration
+	maxRetryAfter      time.Duration
+	backoff            Backoff
+	validate           *validator.Validate
+	responseValidation ResponseValidation
+	onInvalidRecord    InvalidRecordFunc
 }
 
-// NewClient creates a new client with the specified API key.
-func NewClient(apikey string) (*Client, error) {
+// NewClient creates a new client with the specified API key, configured by
+// any options given.
+func NewClient(apikey string, opts ...Option) (*Client, error) {
 	base, err := url.Parse(BaseAPIURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid base URI: %w", err)
 	}
 
-	return &Client{
-		HTTP: &http.Client{
-			Transport: &addAuthHeaderTransport{T: http.DefaultTransport, Key: apikey},
+	c := &Client{
+		httpClient: &http.Client{
+			Transport: &addAuthHeaderTransport{T: http.DefaultTransport, Key: apikey, UserAgent: defaultUserAgent},
 		},
-		Base: base,
-	}, nil
+		base:       base,
+		apiVersion: APIv1,
+		probed:     &capabilityCache{},
+		stats:      &stats{ops: map[string]OperationStats{}},
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, fmt.Errorf("apply option: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// BaseURL returns the URL of the API the client sends requests to.
+func (c *Client) BaseURL() *url.URL {
+	u := *c.base
+	return &u
+}
+
+// With returns a copy of the client with opts applied on top of its current
+// configuration. The copy shares the underlying transport and its connection
+// pool with c, so deriving clients is cheap, and c itself is never modified.
+func (c *Client) With(opts ...Option) (*Client, error) {
+	d := *c
+	d.probed = &capabilityCache{}
+	hc := *c.httpClient
+	d.httpClient = &hc
+	if a, ok := hc.Transport.(*addAuthHeaderTransport); ok {
+		ac := *a
+		hc.Transport = &ac
+	}
+
+	for _, opt := range opts {
+		if err := opt(&d); err != nil {
+			return nil, fmt.Errorf("apply option: %w", err)
+		}
+	}
+
+	return &d, nil
+}
+
+// logRequest records a finished request in the client's stats and debug log.
+// A status of 0 means no response was received.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, resp *http.Response, start time.Time) {
+	d := time.Since(start)
+	op := OperationFromContext(ctx)
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	if c.stats != nil {
+		c.stats.record(op, status, d)
+	}
+
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.String("operation", op),
+		slog.String("request_id", req.Header.Get(RequestIDHeader)),
+		slog.Int("status", status),
+		slog.Duration("duration", d),
+	}
+	if resp != nil {
+		for k, v := range correlation(resp.Header) {
+			attrs = append(attrs, slog.String(k, v))
+		}
+	}
+	c.logger.DebugContext(ctx, "lunchmoney request", attrs...)
+}
+
+// send sends req once the client's limiter allows it, with a request ID, and
+// records it in the client's stats and debug log. It returns the request ID
+// with the response.
+func (c *Client) send(ctx context.Context, req *http.Request) (string, *http.Response, error) {
+	if err := c.wait(ctx); err != nil {
+		return "", nil, err
+	}
+
+	id := setRequestID(ctx, req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(ctx, req, resp, start)
+	if err != nil {
+		return id, nil, fmt.Errorf("request %s %s (request ID %s) failed: %w", req.Method, req.URL.Path, id, err)
+	}
+
+	return id, resp, nil
+}
+
+// decode decodes a response body into v, rejecting unknown fields under
+// WithStrictDecoding.
+func (c *Client) decode(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if !c.strictDecoding {
+		return dec.Decode(v)
+	}
+
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		// encoding/json has no error type for unknown fields.
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return fmt.Errorf("%w: %w", ErrUnknownField, err)
+		}
+		return err
+	}
+
+	return checkExtra(v)
 }
 
 // ErrorResponse is json if we get an error from the LM API.
@@ -78,7 +222,108 @@ func (e *ErrorResponse) Error() string {
 // key/value pairs specified in options. It returns the body of the response or
 // an error.
 func (c *Client) Get(ctx context.Context, path string, options map[string]string) (io.Reader, error) {
-	u, err := url.Parse(c.Base.String())
+	return c.GetValues(ctx, path, queryValues(options))
+}
+
+// GetValues is like Get but takes the query as url.Values, so a parameter can
+// be given several times.
+func (c *Client) GetValues(ctx context.Context, path string, query url.Values) (_ io.Reader, err error) {
+	resp, err := c.get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp, &err)
+
+	// The length is only a hint: a server could announce gigabytes and send
+	// little, so the buffer grows as the body arrives beyond maxPrealloc.
+	var buf bytes.Buffer
+	if resp.ContentLength > 0 {
+		buf.Grow(int(min(resp.ContentLength, maxPrealloc)))
+	}
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+
+	if c.schemaValidation {
+		if err := checkSchema(http.MethodGet, path, buf.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &buf, nil
+}
+
+// getJSON makes a GET request like Get and decodes the response into v. The
+// body is decoded as it is read rather than buffered first, unless schema
+// validation needs all of it.
+func (c *Client) getJSON(ctx context.Context, path string, options map[string]string, v any) (err error) {
+	resp, err := c.get(ctx, path, queryValues(options))
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp, &err)
+
+	if c.schemaValidation {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("could not read response: %w", err)
+		}
+		if err := checkSchema(http.MethodGet, path, b); err != nil {
+			return err
+		}
+		if err := c.decode(bytes.NewReader(b), v); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.decode(resp.Body, v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	// Drain what follows the value so the connection can be reused.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+
+	return nil
+}
+
+// get sends a GET request, retrying it as configured with WithRetries, and
+// returns the response if its status is 200 OK. The caller must close the
+// body, for example with closeBody.
+func (c *Client) get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	// Retries carry the ID of the first attempt.
+	if RequestIDFromContext(ctx) == "" {
+		ctx = WithRequestID(ctx, newRequestID())
+	}
+
+	var wait time.Duration
+	for attempt := 1; ; attempt++ {
+		resp, err := c.getOnce(ctx, path, query)
+		if err == nil || attempt > c.maxRetries || !retryable(err) {
+			return resp, err
+		}
+
+		wait = c.backoffPolicy().Delay(attempt, wait, err)
+		if err := c.retryWait(ctx, err, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// queryValues converts the options taken by Get to url.Values.
+func queryValues(options map[string]string) url.Values {
+	query := make(url.Values, len(options))
+	for k, v := range options {
+		query.Set(k, v)
+	}
+
+	return query
+}
+
+func (c *Client) getOnce(ctx context.Context, path string, options url.Values) (_ *http.Response, err error) {
+	u, err := url.Parse(c.base.String())
 	if err != nil {
 		return nil, fmt.Errorf("bad path: %w", err)
 	}
@@ -86,47 +331,95 @@ func (c *Client) Get(ctx context.Context, path string, options map[string]string
 	u.Path = path
 	query := u.Query()
 	for k, v := range options {
-		query.Set(k, v)
+		query[k] = v
 	}
 	u.RawQuery = query.Encode()
 
-	req := &http.Request{Method: http.MethodGet, URL: u}
-	resp, err := c.HTTP.Do(req)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("request (%+v) failed: %w", req, err)
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+
+	id, resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.limitBody(resp, path); err != nil {
+		return nil, err
 	}
-	defer func() {
-		if cerr := resp.Body.Close(); cerr != nil {
-			if err != nil {
-				err = fmt.Errorf("error closing response body: %w: %w", cerr, err)
-			} else {
-				err = fmt.Errorf("error closing response body: %w", cerr)
-			}
-		}
-	}()
 
 	if resp.StatusCode != http.StatusOK {
+		defer closeBody(resp, &err)
+
 		var buf bytes.Buffer
-		tee := io.TeeReader(resp.Body, &buf)
-		errResp := ErrorResponse{}
-		if err := json.NewDecoder(tee).Decode(&errResp); err != nil {
-			return nil, fmt.Errorf("could not decode error response %s: %w", buf.String(), err)
+		if err := c.tryToFindError(resp, id, &buf, true); err != nil {
+			return nil, err
 		}
 
-		// log.Printf("%s -> %+v", buf.String(), errResp)
-		if errResp.Error() != "" {
-			return nil, fmt.Errorf("%s: %s", resp.Status, errResp.Error())
-		}
+		return nil, newAPIError(resp, id, "")
+	}
+
+	return resp, nil
+}
 
-		return nil, fmt.Errorf("%s", resp.Status)
+// limitBody makes reading the body of resp fail with a ResponseTooLargeError
+// once more than the client's maximum response size has been read. A body
+// declared too large is closed and rejected without reading it.
+func (c *Client) limitBody(resp *http.Response, path string) error {
+	if c.maxResponseSize <= 0 {
+		return nil
 	}
 
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, resp.Body); err != nil {
-		return nil, fmt.Errorf("could not read response: %w", err)
+	if resp.ContentLength > c.maxResponseSize {
+		_ = resp.Body.Close()
+		return &ResponseTooLargeError{Path: path, Limit: c.maxResponseSize}
 	}
 
-	return &buf, nil
+	// Reading one byte past the limit tells a body of exactly the limit
+	// from a larger one.
+	resp.Body = &limitedBody{
+		r:      io.LimitReader(resp.Body, c.maxResponseSize+1),
+		Closer: resp.Body,
+		err:    &ResponseTooLargeError{Path: path, Limit: c.maxResponseSize},
+	}
+	return nil
+}
+
+// maxPrealloc bounds the buffer allocated up front for a response body
+// based on its Content-Length.
+const maxPrealloc = 1 << 20
+
+type limitedBody struct {
+	r io.Reader
+	io.Closer
+	read int64
+	err  *ResponseTooLargeError
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.read > b.err.Limit {
+		return 0, b.err
+	}
+
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if over := b.read - b.err.Limit; over > 0 {
+		return max(n-int(over), 0), b.err
+	}
+
+	return n, err
+}
+
+// closeBody closes the body of resp, adding any error to *err.
+func closeBody(resp *http.Response, err *error) {
+	cerr := resp.Body.Close()
+	switch {
+	case cerr == nil:
+	case *err != nil:
+		*err = fmt.Errorf("error closing response body: %w: %w", cerr, *err)
+	default:
+		*err = fmt.Errorf("error closing response body: %w", cerr)
+	}
 }
 
 // Put performs an HTTP PUT request to the specified API endpoint with the provided body.
@@ -141,8 +434,8 @@ func (c *Client) Post(ctx context.Context, path string, body any) (io.Reader, er
 	return c.do(ctx, http.MethodPost, path, body)
 }
 
-func (c *Client) do(ctx context.Context, method string, path string, body any) (io.Reader, error) {
-	u, err := url.Parse(c.Base.String())
+func (c *Client) do(ctx context.Context, method string, path string, body any) (_ io.Reader, err error) {
+	u, err := url.Parse(c.base.String())
 	if err != nil {
 		return nil, fmt.Errorf("bad path: %w", err)
 	}
@@ -160,66 +453,85 @@ func (c *Client) do(ctx context.Context, method string, path string, body any) (
 	}
 
 	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.HTTP.Do(req)
+	if c.dryRun {
+		if c.logger != nil {
+			c.logger.InfoContext(ctx, "lunchmoney dry run", slog.String("method", method), slog.String("path", u.Path), slog.String("body", string(b)))
+		}
+
+		return bytes.NewBufferString("{}"), nil
+	}
+
+	id, resp, err := c.send(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("request (%+v) failed: %w", req, err)
+		return nil, err
 	}
-	defer func() {
-		if cerr := resp.Body.Close(); cerr != nil {
-			if err != nil {
-				err = fmt.Errorf("error closing response body: %w: %w", cerr, err)
-			} else {
-				err = fmt.Errorf("error closing response body: %w", cerr)
-			}
-		}
-	}()
+	if err := c.limitBody(resp, path); err != nil {
+		return nil, err
+	}
+	defer closeBody(resp, &err)
 
 	if resp.StatusCode != http.StatusOK {
 		var buf bytes.Buffer
-		err := c.tryToFindError(resp, &buf, true)
+		err := c.tryToFindError(resp, id, &buf, true)
 		if err != nil {
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("%s", resp.Status)
+		return nil, newAPIError(resp, id, "")
 	}
 
 	// Sometimes 200 still means that there is an error
 	var finalReader bytes.Buffer
-	err = c.tryToFindError(resp, &finalReader, false)
+	err = c.tryToFindError(resp, id, &finalReader, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.schemaValidation {
+		if err := checkSchema(method, path, finalReader.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
 	return &finalReader, nil
 }
 
-func (*Client) tryToFindError(resp *http.Response, outBuf *bytes.Buffer, failOnDecodeErr bool) error {
+func (*Client) tryToFindError(resp *http.Response, requestID string, outBuf *bytes.Buffer, failOnDecodeErr bool) error {
 	tee := io.TeeReader(resp.Body, outBuf)
 	errResp := ErrorResponse{}
 	if err := json.NewDecoder(tee).Decode(&errResp); err != nil {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return err
+		}
 		if failOnDecodeErr {
-			return fmt.Errorf("could not decode error response %s: %w", outBuf.String(), err)
+			// Not JSON, e.g. an HTML page from a proxy; report the body as is.
+			return newAPIError(resp, requestID, strings.TrimSpace(outBuf.String()))
 		}
 		// some other message is involved here (eg array)
 		return nil
 	}
 
 	if errResp.Error() != "" {
-		return fmt.Errorf("%s: %s", resp.Status, errResp.Error())
+		return newAPIError(resp, requestID, errResp.Error())
 	}
 	return nil
 }
 
+// Ptr returns a pointer to v. It is a convenience for filling in the optional
+// pointer fields used by filters, requests and models.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
 // ParseCurrency converts a string amount and currency code into a money.Money struct.
-// It parses the amount as a float, multiplies by 100 to convert to cents, and returns
-// a Money object in the specified currency. Returns an error if the amount can't be parsed.
+// It parses the amount as a Decimal and rounds it to the currency's minor unit, so no
+// precision is lost to floating point. Returns an error if the amount can't be parsed.
 func ParseCurrency(amount, currency string) (*money.Money, error) {
-	f, err := strconv.ParseFloat(amount, 64)
+	d, err := ParseDecimal(amount)
 	if err != nil {
-		return nil, fmt.Errorf("%q is not valid float: %w", amount, err)
+		return nil, err
 	}
 
-	v := int64(100 * f)
-	return money.New(v, currency), nil
+	return d.Money(currency), nil
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/internal/output"
+)
+
+func budgetsShow(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("budgets")
+	month := fs.String("month", "", "month to show, as YYYY-MM (default: the current month)")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	from, to, err := dateRange(*month, "", "")
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+	budgets, err := c.GetBudgets(ctx, &lunchmoney.BudgetFilters{StartDate: from, EndDate: to})
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	r := output.Result{
+		Columns: []string{"category_id", "category", "budgeted", "spent", "remaining", "transactions"},
+		Data:    budgets,
+	}
+	for _, b := range budgets {
+		var budgeted, spent, remaining string
+		n := 0
+		if bd, ok := b.Month(from); ok {
+			budgeted, spent, remaining = bd.BudgetToBase.String(), bd.SpendingToBase.String(), bd.Remaining().String()
+			n = bd.NumTransactions
+		}
+		r.Rows = append(r.Rows, []string{
+			strconv.FormatInt(int64(b.CategoryID), 10),
+			b.CategoryName,
+			budgeted,
+			spent,
+			remaining,
+			strconv.Itoa(n),
+		})
+	}
+
+	return r, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/internal/output"
+)
+
+func categoriesList(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("list")
+	archived := fs.Bool("archived", false, "include archived categories")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+	cats, err := c.GetCategories(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	r := output.Result{Columns: []string{"id", "name", "group", "income", "archived"}}
+	var data []*lunchmoney.Category
+	lunchmoney.NewCategoryTree(cats).Walk(func(n *lunchmoney.CategoryNode, depth int) {
+		if n.Archived && !*archived {
+			return
+		}
+		data = append(data, n.Category)
+
+		group := ""
+		if n.Parent != nil {
+			group = n.Parent.Name
+		}
+		r.Rows = append(r.Rows, []string{
+			strconv.FormatInt(int64(n.ID), 10),
+			strings.Repeat("  ", depth) + n.Name,
+			group,
+			strconv.FormatBool(n.IsIncome),
+			strconv.FormatBool(n.Archived),
+		})
+	})
+	r.Data = data
+
+	return r, nil
+}
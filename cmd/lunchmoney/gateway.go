@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/gateway"
+	"github.com/icco/lunchmoney/internal/output"
+)
+
+// gatewayServe runs the caching gateway until ctx is done. It writes no
+// command output of its own.
+func gatewayServe(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("gateway")
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	ttl := fs.Duration("ttl", time.Minute, "how long responses are cached")
+	stale := fs.Duration("stale-if-error", time.Hour, "how long expired responses are served when the API fails")
+	rps := fs.Float64("rps", 1, "upstream requests per second, 0 for no limit")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	opts := gateway.Options{TTL: *ttl, StaleIfError: *stale}
+	if *rps > 0 {
+		l, err := lunchmoney.NewRateLimiter(*rps, max(1, int(*rps)))
+		if err != nil {
+			return output.Result{}, &output.UsageError{Err: err}
+		}
+		opts.Limiter = l
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	srv := &http.Server{Addr: *addr, Handler: gateway.New(c, opts), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return output.Result{}, err
+	}
+
+	return output.Result{}, nil
+}
@@ -0,0 +1,201 @@
+// Command lunchmoney is a command line client for the Lunch Money API.
+//
+// Usage:
+//
+//	lunchmoney [-o table|json|csv] <command> [flags]
+//
+// The access token is read from LUNCHMONEY_TOKEN. Run "lunchmoney help" for
+// the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/internal/output"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+// command is a node in the command tree. Leaves have run set.
+type command struct {
+	name  string
+	short string
+	run   func(ctx context.Context, a *app, args []string) (output.Result, error)
+	subs  []*command
+}
+
+var commands = []*command{
+	{name: "txns", short: "list and update transactions", subs: []*command{
+		{name: "list", short: "list transactions for a month or date range", run: txnsList},
+		{name: "update", short: "update one or more transactions", run: txnsUpdate},
+	}},
+	{name: "categories", short: "list categories", subs: []*command{
+		{name: "list", short: "list categories", run: categoriesList},
+	}},
+	{name: "budgets", short: "show budgets for a month", run: budgetsShow},
+	{name: "mirror", short: "keep a local copy of the transaction history", subs: []*command{
+		{name: "backfill", short: "pull the history into a directory, resuming an interrupted run", run: mirrorBackfill},
+	}},
+	{name: "review", short: "interactively review uncleared transactions", run: reviewRun},
+	{name: "gateway", short: "serve a caching read-only proxy of the API over HTTP", run: gatewayServe},
+	{name: "rpc", short: "serve the typed LunchMoneyService API over gRPC", run: rpcServe},
+	{name: "mcp", short: "serve the budget to LLM assistants over MCP on stdio", run: mcpServe},
+}
+
+// app carries the state shared by commands.
+type app struct {
+	getenv func(string) string
+	stdin  io.Reader
+	stdout io.Writer
+	// stderr takes prompts and progress, so stdout only carries the result.
+	stderr io.Writer
+	format string
+	client *lunchmoney.Client
+}
+
+// Client returns the API client, creating it on first use. The token is
+// checked when the client is created, so a bad token fails with one clear
+// error before the command does any work.
+func (a *app) Client(ctx context.Context) (*lunchmoney.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	token := a.getenv("LUNCHMONEY_TOKEN")
+	if token == "" {
+		return nil, &output.UsageError{Err: errors.New("LUNCHMONEY_TOKEN is not set")}
+	}
+
+	c, err := lunchmoney.NewClient(token)
+	if err != nil {
+		return nil, err
+	}
+	if base := a.getenv("LUNCHMONEY_API_URL"); base != "" {
+		if c, err = c.With(lunchmoney.WithBaseURL(base)); err != nil {
+			return nil, &output.UsageError{Err: fmt.Errorf("LUNCHMONEY_API_URL: %w", err)}
+		}
+	}
+
+	if _, err := c.Ping(ctx); err != nil {
+		if errors.Is(err, lunchmoney.ErrInvalidToken) {
+			return nil, fmt.Errorf("LUNCHMONEY_TOKEN was rejected, create a new one at https://my.lunchmoney.app/developers: %w", err)
+		}
+		return nil, err
+	}
+
+	a.client = c
+	return c, nil
+}
+
+// flags returns a flag set for a command that also accepts -o, so the output
+// format can be given before or after the command.
+func (a *app) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&a.format, "o", a.format, "output format: table, json or csv")
+	fs.StringVar(&a.format, "output", a.format, "output format: table, json or csv")
+	return fs
+}
+
+// parse parses args into fs, turning flag errors into usage errors, and
+// checks the output format before the command does any work.
+func (a *app) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return &output.UsageError{Err: fmt.Errorf("%s: %w", fs.Name(), err)}
+	}
+
+	_, err := output.ParseFormat(a.format)
+	return err
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) int {
+	a := &app{getenv: getenv, stdin: stdin, stdout: stdout, stderr: stderr, format: string(output.Table)}
+
+	res, err := dispatch(ctx, a, args)
+	format, ferr := output.ParseFormat(a.format)
+	if ferr != nil {
+		format = output.Table
+	}
+
+	// After a partial failure the result holds what succeeded.
+	if res != nil {
+		if err := output.Write(stdout, format, *res); err != nil {
+			return output.WriteError(stderr, format, err)
+		}
+	}
+	if err != nil {
+		return output.WriteError(stderr, format, err)
+	}
+
+	return output.ExitOK
+}
+
+func dispatch(ctx context.Context, a *app, args []string) (*output.Result, error) {
+	fs := a.flags("lunchmoney")
+	if err := a.parse(fs, args); err != nil {
+		return nil, err
+	}
+	args = fs.Args()
+
+	path := "lunchmoney"
+	cmds := commands
+	for {
+		if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			usage(a.stdout, path, cmds)
+			return nil, nil
+		}
+
+		cmd := find(cmds, args[0])
+		if cmd == nil {
+			return nil, &output.UsageError{Err: fmt.Errorf("unknown command %q, see %q", strings.TrimSpace(path+" "+args[0]), path+" help")}
+		}
+		path += " " + cmd.name
+		args = args[1:]
+
+		if cmd.run != nil {
+			res, err := cmd.run(ctx, a, args)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", strings.TrimPrefix(path, "lunchmoney "), err)
+				var partial *output.PartialError
+				if !errors.As(err, &partial) {
+					return nil, err
+				}
+			}
+			if res.Columns == nil && res.Data == nil {
+				return nil, err // the command wrote its own output
+			}
+			return &res, err
+		}
+		cmds = cmd.subs
+	}
+}
+
+func find(cmds []*command, name string) *command {
+	for _, c := range cmds {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func usage(w io.Writer, path string, cmds []*command) {
+	_, _ = fmt.Fprintf(w, "usage: %s [-o table|json|csv] <command> [flags]\n\ncommands:\n", path)
+	for _, c := range cmds {
+		_, _ = fmt.Fprintf(w, "  %-12s %s\n", c.name, c.short)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney/internal/output"
+	"github.com/icco/lunchmoney/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apiCall struct {
+	method, path, query, body string
+}
+
+func fakeAPI(t *testing.T) (func(string) string, *[]apiCall) {
+	t.Helper()
+
+	var calls []apiCall
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every command checks the token first; tests only look at the
+		// calls that follow.
+		if r.URL.Path == "/v1/me" {
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message": "Access token does not exist."}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"user_id": 1, "budget_name": "Home"}`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, apiCall{r.Method, r.URL.Path, r.URL.RawQuery, string(body)})
+
+		switch {
+		case r.URL.Path == "/v1/tags":
+			_, _ = w.Write([]byte(`[{"id": 5, "name": "work"}]`))
+		case r.URL.Path == "/v1/transactions" && strings.Contains(r.URL.RawQuery, "2024-05-01"):
+			_, _ = w.Write([]byte(`{"transactions": [
+				{"id": 7, "date": "2024-05-03", "payee": "Coffee", "amount": "4.50", "currency": "usd", "status": "uncleared"},
+				{"id": 8, "date": "2024-05-04", "payee": "Rent", "amount": "900", "currency": "usd", "status": "uncleared"},
+				{"id": 9, "date": "2024-05-05", "payee": "Done", "amount": "1", "currency": "usd", "status": "cleared"}
+			], "has_more": false}`))
+		case r.URL.Path == "/v1/transactions":
+			_, _ = w.Write([]byte(`{"transactions": [{"id": 7, "date": "2024-06-03", "payee": "Coffee", "amount": "4.50", "currency": "usd", "category_id": 3, "status": "cleared"}], "has_more": false}`))
+		case r.URL.Path == "/v1/transactions/9" && r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+		case strings.HasPrefix(r.URL.Path, "/v1/transactions/") && r.Method == http.MethodPut:
+			_, _ = w.Write([]byte(`{"updated": true}`))
+		case r.URL.Path == "/v1/transactions/7":
+			_, _ = w.Write([]byte(`{"id": 7, "date": "2024-06-03", "payee": "Tea", "amount": "4.50", "currency": "usd", "status": "cleared"}`))
+		case r.URL.Path == "/v1/categories":
+			_, _ = w.Write([]byte(`{"categories": [{"id": 1, "name": "Food", "is_group": true}, {"id": 3, "name": "Coffee", "group_id": 1}, {"id": 4, "name": "Old", "archived": true}]}`))
+		case r.URL.Path == "/v1/budgets":
+			_, _ = w.Write([]byte(`[{"category_id": 3, "category_name": "Coffee", "data": {"2024-06-01": {"budget_month": "2024-06-01", "budget_to_base": "50", "spending_to_base": "60", "num_transactions": 4}}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	env := map[string]string{"LUNCHMONEY_TOKEN": "test-token", "LUNCHMONEY_API_URL": server.URL}
+	return func(k string) string { return env[k] }, &calls
+}
+
+func runCLI(getenv func(string) string, args ...string) (int, string, string) {
+	return runCLIInput(getenv, "", args...)
+}
+
+func runCLIInput(getenv func(string) string, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, getenv)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestTxnsList(t *testing.T) {
+	getenv, calls := fakeAPI(t)
+
+	code, stdout, stderr := runCLI(getenv, "txns", "list", "--month", "2024-06", "-o", "csv")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Equal(t, "id,date,payee,amount,currency,category_id,status\n7,2024-06-03,Coffee,4.50,usd,3,cleared\n", stdout)
+	require.NotEmpty(t, *calls)
+	assert.Contains(t, (*calls)[0].query, "start_date=2024-06-01")
+	assert.Contains(t, (*calls)[0].query, "end_date=2024-06-30")
+
+	code, stdout, _ = runCLI(getenv, "-o", "json", "txns", "list", "--start", "2024-06-01", "--end", "2024-06-02")
+	require.Equal(t, output.ExitOK, code)
+	var env struct {
+		SchemaVersion int              `json:"schema_version"`
+		Data          []map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &env))
+	assert.Equal(t, 1, env.SchemaVersion)
+	assert.Equal(t, float64(7), env.Data[0]["id"])
+}
+
+func TestTxnsUpdate(t *testing.T) {
+	getenv, calls := fakeAPI(t)
+
+	code, stdout, stderr := runCLI(getenv, "txns", "update", "--payee", "Tea", "7")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Contains(t, stdout, "Tea")
+	require.Len(t, *calls, 2)
+	assert.Equal(t, http.MethodPut, (*calls)[0].method)
+	assert.JSONEq(t, `{"transaction": {"payee": "Tea"}}`, (*calls)[0].body)
+
+	code, _, _ = runCLI(getenv, "txns", "update", "7")
+	assert.Equal(t, output.ExitUsage, code)
+
+	// Transaction 9 cannot be updated: 7 is still printed.
+	code, stdout, stderr = runCLI(getenv, "txns", "update", "--payee", "Tea", "7", "9")
+	assert.Equal(t, output.ExitPartial, code)
+	assert.Contains(t, stdout, "Tea")
+	assert.Contains(t, stderr, "1 succeeded, 1 failed\n  update transaction 9: 404 Not Found")
+
+	code, stdout, _ = runCLI(getenv, "txns", "update", "--payee", "Tea", "9")
+	assert.Equal(t, output.ExitAPI, code)
+	assert.Empty(t, stdout)
+
+	// Transaction 8 is updated but cannot be read back: the update still
+	// succeeds, with a warning.
+	code, stdout, stderr = runCLI(getenv, "txns", "update", "--payee", "Tea", "7", "8")
+	assert.Equal(t, output.ExitOK, code, stderr)
+	assert.Contains(t, stdout, "Tea")
+	assert.Contains(t, stderr, "warning: transaction 8 was updated but could not be read back: get transaction 8: 404 Not Found")
+
+	code, _, stderr = runCLI(getenv, "txns", "update", "--status", "bogus", "7")
+	assert.Equal(t, output.ExitValidation, code)
+	assert.Contains(t, stderr, "status")
+}
+
+func TestCategoriesAndBudgets(t *testing.T) {
+	getenv, _ := fakeAPI(t)
+
+	code, stdout, stderr := runCLI(getenv, "categories", "list")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Contains(t, stdout, "  Coffee")
+	assert.NotContains(t, stdout, "Old")
+
+	code, stdout, stderr = runCLI(getenv, "budgets", "--month", "2024-06", "-o", "csv")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Equal(t, "category_id,category,budgeted,spent,remaining,transactions\n3,Coffee,50,60,-10,4\n", stdout)
+}
+
+func TestMirrorBackfill(t *testing.T) {
+	getenv, calls := fakeAPI(t)
+	dir := t.TempDir()
+
+	code, stdout, stderr := runCLI(getenv, "mirror", "backfill", "--dir", dir, "--start", "2024-05-01", "--end", "2024-06-30", "-o", "csv")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Equal(t, "shards,transactions,synced_through\n2,4,2024-06-30\n", stdout)
+	assert.Contains(t, stderr, "[2/2] 2024-06-01 to 2024-06-30: 1 transactions")
+
+	s, err := store.OpenDir(dir)
+	require.NoError(t, err)
+	txns, err := store.LoadTransactions(context.Background(), s)
+	require.NoError(t, err)
+	assert.Len(t, txns, 3)
+
+	// Everything was pulled, so a second run has nothing to do.
+	*calls = nil
+	code, stdout, stderr = runCLI(getenv, "mirror", "backfill", "--dir", dir, "--start", "2024-05-01", "--end", "2024-06-30", "-o", "csv")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Equal(t, "shards,transactions,synced_through\n0,0,2024-06-30\n", stdout)
+	assert.Empty(t, *calls)
+
+	code, _, _ = runCLI(getenv, "mirror", "backfill", "--start", "2024-05-01")
+	assert.Equal(t, output.ExitUsage, code)
+	code, _, _ = runCLI(getenv, "mirror", "backfill", "--dir", dir, "--start", "2024-05-01", "--shard", "day")
+	assert.Equal(t, output.ExitUsage, code)
+}
+
+func TestMCP(t *testing.T) {
+	getenv, calls := fakeAPI(t)
+
+	code, stdout, stderr := runCLIInput(getenv, `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "list_categories"}}`, "mcp")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Contains(t, stdout, `"id":1,"result":{"content":[{"type":"text","text":"[{\"id\":1,\"name\":\"Food\"`)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "/v1/categories", (*calls)[0].path)
+
+	code, _, stderr = runCLI(getenv, "mcp", "-scopes", "read,admin")
+	assert.Equal(t, output.ExitUsage, code)
+	assert.Contains(t, stderr, `unknown scope "admin"`)
+}
+
+func TestUsageErrors(t *testing.T) {
+	getenv, _ := fakeAPI(t)
+
+	code, stdout, _ := runCLI(getenv)
+	assert.Equal(t, output.ExitOK, code)
+	assert.Contains(t, stdout, "txns")
+
+	code, _, stderr := runCLI(getenv, "bogus")
+	assert.Equal(t, output.ExitUsage, code)
+	assert.Contains(t, stderr, `unknown command "lunchmoney bogus"`)
+
+	code, _, _ = runCLI(getenv, "-o", "xml", "categories", "list")
+	assert.Equal(t, output.ExitUsage, code)
+
+	code, _, stderr = runCLI(func(string) string { return "" }, "categories", "list")
+	assert.Equal(t, output.ExitUsage, code)
+	assert.Contains(t, stderr, "LUNCHMONEY_TOKEN")
+
+	badToken := func(k string) string {
+		if k == "LUNCHMONEY_TOKEN" {
+			return "expired"
+		}
+		return getenv(k)
+	}
+	code, _, stderr = runCLI(badToken, "categories", "list")
+	assert.Equal(t, output.ExitAPI, code)
+	assert.Contains(t, stderr, "LUNCHMONEY_TOKEN was rejected")
+}
+
+func TestReview(t *testing.T) {
+	getenv, calls := fakeAPI(t)
+
+	code, stdout, stderr := runCLIInput(getenv, "k coffee\nt work\nk nope\nc\ns\n", "-o", "json", "review", "--month", "2024-05")
+	require.Equal(t, output.ExitOK, code, stderr)
+	assert.Contains(t, stderr, "2 uncleared transactions")
+	assert.Contains(t, stderr, `unknown category "nope"`)
+	assert.Contains(t, stderr, "Food / Coffee  #work")
+	assert.Contains(t, stderr, "all done")
+
+	// The prompts stay out of the result.
+	var env struct {
+		Data map[string]int `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &env))
+	assert.Equal(t, map[string]int{"reviewed": 2, "cleared": 1, "updated": 3}, env.Data)
+
+	var puts []apiCall
+	for _, c := range *calls {
+		if c.method == http.MethodPut {
+			puts = append(puts, c)
+		}
+	}
+	require.Len(t, puts, 3)
+	assert.JSONEq(t, `{"transaction": {"category_id": 3}}`, puts[0].body)
+	assert.JSONEq(t, `{"transaction": {"tags": [5]}}`, puts[1].body)
+	assert.JSONEq(t, `{"transaction": {"status": "cleared"}}`, puts[2].body)
+	assert.Equal(t, "/v1/transactions/7", puts[2].path)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/icco/lunchmoney/internal/output"
+	"github.com/icco/lunchmoney/mcp"
+)
+
+// mcpServe serves the Lunch Money tools over MCP on stdin and stdout until
+// stdin is closed. It writes no command output of its own.
+func mcpServe(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("mcp")
+	scopes := fs.String("scopes", "read", "comma-separated permissions granted to the assistant: read, write")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	var granted []mcp.Scope
+	for _, s := range strings.Split(*scopes, ",") {
+		sc, err := mcp.ParseScope(strings.TrimSpace(s))
+		if err != nil {
+			return output.Result{}, &output.UsageError{Err: err}
+		}
+		granted = append(granted, sc)
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	return output.Result{}, mcp.NewServer(c, mcp.Options{Scopes: granted}).Serve(ctx, a.stdin, a.stdout)
+}
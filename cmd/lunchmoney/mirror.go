@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/internal/output"
+	"github.com/icco/lunchmoney/mirror"
+	"github.com/icco/lunchmoney/store"
+)
+
+// mirrorBackfill pulls the transaction history into a store directory. The
+// last date pulled is recorded after each shard, so running it again after
+// an interruption resumes from there.
+func mirrorBackfill(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("mirror backfill")
+	dir := fs.String("dir", "", "directory to store the transactions in (required)")
+	start := fs.String("start", "", "first date to pull, as YYYY-MM-DD (required)")
+	end := fs.String("end", "", "last date to pull, as YYYY-MM-DD (default: today)")
+	shard := fs.String("shard", "month", "size of each batch: month or week")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	if *dir == "" || *start == "" {
+		return output.Result{}, &output.UsageError{Err: errors.New("--dir and --start are required")}
+	}
+	from, err := lunchmoney.ParseDate(*start)
+	if err != nil {
+		return output.Result{}, &output.UsageError{Err: err}
+	}
+	to := lunchmoney.Today()
+	if *end != "" {
+		if to, err = lunchmoney.ParseDate(*end); err != nil {
+			return output.Result{}, &output.UsageError{Err: err}
+		}
+	}
+	var by lunchmoney.Period
+	switch *shard {
+	case "month":
+		by = lunchmoney.PeriodMonth
+	case "week":
+		by = lunchmoney.PeriodWeek
+	default:
+		return output.Result{}, &output.UsageError{Err: fmt.Errorf("invalid --shard %q, want month or week", *shard)}
+	}
+
+	s, err := store.OpenDir(*dir)
+	if err != nil {
+		return output.Result{}, err
+	}
+	opts := mirror.BackfillOptions{Start: from.Time(), End: to.Time(), ShardBy: by}
+	done, ok, err := store.SyncedThrough(ctx, s)
+	if err != nil {
+		return output.Result{}, err
+	}
+	if ok && !done.Before(from) {
+		next := done.AddDays(1).Time()
+		opts.Resume = &next
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	var shards, txns int
+	opts.Progress = func(p mirror.BackfillProgress) {
+		shards++
+		txns += p.Transactions
+		// Progress cannot fail, so a failure to record it only costs the
+		// next run a shard it could have skipped.
+		if err := store.SetSyncedThrough(ctx, s, lunchmoney.DateOf(p.Shard.End)); err != nil {
+			_, _ = fmt.Fprintf(a.stderr, "record progress: %v\n", err)
+		}
+		_, _ = fmt.Fprintf(a.stderr, "[%d/%d] %s to %s: %d transactions\n", p.Done, p.Total, lunchmoney.DateOf(p.Shard.Start), lunchmoney.DateOf(p.Shard.End), p.Transactions)
+	}
+	m, err := mirror.Open(ctx, c, s)
+	if err != nil {
+		return output.Result{}, err
+	}
+	if err := m.Backfill(ctx, opts); err != nil {
+		return output.Result{}, err
+	}
+
+	through, _, err := store.SyncedThrough(ctx, s)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	return output.Result{
+		Columns: []string{"shards", "transactions", "synced_through"},
+		Rows:    [][]string{{strconv.Itoa(shards), strconv.Itoa(txns), through.String()}},
+		Data:    map[string]any{"shards": shards, "transactions": txns, "synced_through": through},
+	}, nil
+}
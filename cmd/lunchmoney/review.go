@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/internal/output"
+)
+
+const reviewHelp = `keys:
+  c  mark cleared and go to the next transaction
+  s  skip to the next transaction
+  k  set the category, by name or ID
+  t  add tags, by name or ID, separated by commas
+  n  set the notes
+  q  quit
+
+On a terminal keys act as soon as they are pressed, and k, t and n then ask
+for their value. Otherwise each command is a line, such as "k groceries".
+`
+
+// reviewArgs names the value asked for after the keys that take one.
+var reviewArgs = map[string]string{"k": "category", "t": "tags", "n": "notes"}
+
+// reviewInput reads review commands. It returns io.EOF when the input ends.
+type reviewInput interface {
+	next() (key, arg string, err error)
+}
+
+// lineInput reads a command per line, for input that is not a terminal.
+type lineInput struct {
+	sc *bufio.Scanner
+}
+
+func (in lineInput) next() (string, string, error) {
+	if !in.sc.Scan() {
+		return "", "", cmp.Or(in.sc.Err(), io.EOF)
+	}
+
+	key, arg, _ := strings.Cut(strings.TrimSpace(in.sc.Text()), " ")
+	return key, strings.TrimSpace(arg), nil
+}
+
+// keyInput reads single keystrokes from a terminal, and a line for the value
+// of keys that take one.
+type keyInput struct {
+	f   *os.File
+	r   *bufio.Reader
+	out io.Writer
+}
+
+func (in *keyInput) next() (string, string, error) {
+	// The terminal is only in character mode while waiting for a key, so
+	// it is back to normal however the session ends.
+	restore, err := cbreak(in.f)
+	if err != nil {
+		return "", "", err
+	}
+	b, err := in.r.ReadByte()
+	restore()
+	if err != nil {
+		return "", "", err
+	}
+
+	switch b {
+	case 3, 4: // Ctrl-C, Ctrl-D
+		return "q", "", nil
+	case '\r', '\n':
+		return "", "", nil
+	}
+
+	key := string(b)
+	_, _ = fmt.Fprintln(in.out, key)
+	name, ok := reviewArgs[key]
+	if !ok {
+		return key, "", nil
+	}
+
+	_, _ = fmt.Fprintf(in.out, "%s: ", name)
+	line, err := in.r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", "", err
+	}
+
+	return key, strings.TrimSpace(line), nil
+}
+
+// reviewSession walks through uncleared transactions, applying each change
+// as soon as it is entered. Prompts go to out, which is stderr, so that
+// stdout only carries the summary.
+type reviewSession struct {
+	client *lunchmoney.Client
+	out    io.Writer
+	cats   *lunchmoney.CategoryTree
+	tags   []*lunchmoney.Tag
+
+	reviewed, cleared, updated int
+}
+
+func reviewRun(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("review")
+	month := fs.String("month", "", "month to review, as YYYY-MM (default: the current month)")
+	start := fs.String("start", "", "first date to review, as YYYY-MM-DD")
+	end := fs.String("end", "", "last date to review, as YYYY-MM-DD")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	from, to, err := dateRange(*month, *start, *end)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+	ctx = lunchmoney.WithOperation(ctx, "review")
+
+	txns, err := c.GetAllTransactions(ctx, &lunchmoney.TransactionFilters{StartDate: &from, EndDate: &to}, nil)
+	if err != nil {
+		return output.Result{}, err
+	}
+	cats, err := c.GetCategories(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+	tags, err := c.GetTags(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	var in reviewInput = lineInput{bufio.NewScanner(a.stdin)}
+	if f, ok := a.stdin.(*os.File); ok {
+		if restore, err := cbreak(f); err == nil {
+			restore()
+			in = &keyInput{f: f, r: bufio.NewReader(f), out: a.stderr}
+		}
+	}
+
+	s := &reviewSession{client: c, out: a.stderr, cats: lunchmoney.NewCategoryTree(cats), tags: tags}
+	if err := s.run(ctx, in, txns); err != nil {
+		return output.Result{}, err
+	}
+
+	return output.Result{
+		Columns: []string{"reviewed", "cleared", "updated"},
+		Rows:    [][]string{{strconv.Itoa(s.reviewed), strconv.Itoa(s.cleared), strconv.Itoa(s.updated)}},
+		Data:    map[string]int{"reviewed": s.reviewed, "cleared": s.cleared, "updated": s.updated},
+	}, nil
+}
+
+func (s *reviewSession) run(ctx context.Context, in reviewInput, txns []*lunchmoney.Transaction) error {
+	var pending []*lunchmoney.Transaction
+	for _, t := range txns {
+		if t.Status != "cleared" && !t.IsGroup {
+			pending = append(pending, t)
+		}
+	}
+	s.printf("%d uncleared transactions. Type ? for help.\n", len(pending))
+
+	for i := 0; i < len(pending); {
+		t := pending[i]
+		s.printf("\n[%d/%d] %s  %s  %s %s  %s\n> ", i+1, len(pending), t.Date, t.Payee, t.Amount, strings.ToUpper(t.Currency), s.describe(t))
+		key, arg, err := in.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var (
+			ut      lunchmoney.UpdateTransaction
+			advance bool
+		)
+		switch key {
+		case "q":
+			return nil
+		case "s", "":
+			i++
+			s.reviewed++
+			continue
+		case "?", "h":
+			s.printf("%s", reviewHelp)
+			continue
+		case "c":
+			ut.Status = lunchmoney.Ptr("cleared")
+			advance = true
+		case "k":
+			id, ok := s.category(arg)
+			if !ok {
+				s.printf("unknown category %q\n", arg)
+				continue
+			}
+			ut.CategoryID = &id
+		case "t":
+			ids, err := s.addTags(t, arg)
+			if err != nil {
+				s.printf("%v\n", err)
+				continue
+			}
+			ut.TagsIDs = ids
+		case "n":
+			ut.Notes = &arg
+		default:
+			s.printf("unknown key %q, type ? for help\n", key)
+			continue
+		}
+
+		if _, err := s.client.UpdateTransaction(ctx, t.ID, &ut); err != nil {
+			if lunchmoney.IsValidation(err) {
+				s.printf("rejected: %v\n", err)
+				continue
+			}
+			return err
+		}
+		s.apply(t, &ut)
+		s.updated++
+		if advance {
+			s.cleared++
+			s.reviewed++
+			i++
+		}
+	}
+
+	s.printf("\nall done\n")
+	return nil
+}
+
+// apply mirrors a successful update onto the local copy of t.
+func (s *reviewSession) apply(t *lunchmoney.Transaction, ut *lunchmoney.UpdateTransaction) {
+	if ut.Status != nil {
+		t.Status = *ut.Status
+	}
+	if ut.CategoryID != nil {
+		t.CategoryID = ut.CategoryID
+	}
+	if ut.Notes != nil {
+		t.Notes = *ut.Notes
+	}
+	if ut.TagsIDs != nil {
+		t.Tags = nil
+		for _, id := range ut.TagsIDs {
+			tag := s.tagByID(id)
+			if tag == nil {
+				tag = &lunchmoney.Tag{ID: id}
+			}
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+}
+
+func (s *reviewSession) describe(t *lunchmoney.Transaction) string {
+	var parts []string
+	if t.CategoryID != nil {
+		if n, ok := s.cats.ByID(*t.CategoryID); ok {
+			parts = append(parts, strings.Join(n.Path(), " / "))
+		}
+	} else {
+		parts = append(parts, "uncategorized")
+	}
+	for _, tag := range t.Tags {
+		parts = append(parts, "#"+tag.Name)
+	}
+	if t.Notes != "" {
+		parts = append(parts, strconv.Quote(t.Notes))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+func (s *reviewSession) category(arg string) (lunchmoney.CategoryID, bool) {
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		n, ok := s.cats.ByID(lunchmoney.CategoryID(id))
+		return lunchmoney.CategoryID(id), ok && !n.IsGroup
+	}
+
+	n, ok := s.cats.ByName(arg)
+	if !ok || n.IsGroup {
+		return 0, false
+	}
+
+	return n.ID, true
+}
+
+// tag finds a tag by ID or case-insensitive name, or returns nil.
+func (s *reviewSession) tag(arg string) *lunchmoney.Tag {
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return s.tagByID(lunchmoney.TagID(id))
+	}
+
+	for _, t := range s.tags {
+		if strings.EqualFold(t.Name, arg) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (s *reviewSession) tagByID(id lunchmoney.TagID) *lunchmoney.Tag {
+	for _, t := range s.tags {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// addTags returns the IDs of t's tags plus the comma separated tags in arg.
+func (s *reviewSession) addTags(t *lunchmoney.Transaction, arg string) ([]lunchmoney.TagID, error) {
+	var ids []lunchmoney.TagID
+	for _, tag := range t.Tags {
+		ids = append(ids, tag.ID)
+	}
+
+	for _, name := range strings.Split(arg, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		tag := s.tag(name)
+		if tag == nil {
+			return nil, fmt.Errorf("unknown tag %q", name)
+		}
+		if !t.HasTag(tag.ID) {
+			ids = append(ids, tag.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no tags given")
+	}
+
+	return ids, nil
+}
+
+func (s *reviewSession) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(s.out, format, args...)
+}
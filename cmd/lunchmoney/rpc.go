@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"net"
+
+	"github.com/icco/lunchmoney/internal/output"
+	lunchmoneyv1 "github.com/icco/lunchmoney/proto/lunchmoney/v1"
+	"github.com/icco/lunchmoney/rpc"
+	"google.golang.org/grpc"
+)
+
+// rpcServe serves LunchMoneyService over gRPC until ctx is done. It writes
+// no command output of its own.
+func rpcServe(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("rpc")
+	addr := fs.String("addr", "localhost:8081", "address to listen on")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	srv := grpc.NewServer()
+	lunchmoneyv1.RegisterLunchMoneyServiceServer(srv, rpc.NewServer(c))
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	if err := srv.Serve(lis); err != nil {
+		return output.Result{}, err
+	}
+
+	return output.Result{}, nil
+}
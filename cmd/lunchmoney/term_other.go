@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// cbreak is not supported here, so review reads whole lines.
+func cbreak(*os.File) (func(), error) {
+	return nil, errors.New("character mode is not supported on this platform")
+}
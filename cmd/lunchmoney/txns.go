@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/internal/output"
+)
+
+func txnsList(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("list")
+	month := fs.String("month", "", "month to list, as YYYY-MM (default: the current month)")
+	start := fs.String("start", "", "first date to list, as YYYY-MM-DD")
+	end := fs.String("end", "", "last date to list, as YYYY-MM-DD")
+	category := fs.Int64("category", 0, "only list transactions in this category ID")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+
+	from, to, err := dateRange(*month, *start, *end)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	q := lunchmoney.NewTransactionQuery().Between(from, to)
+	if *category != 0 {
+		q = q.Category(lunchmoney.CategoryID(*category))
+	}
+	filters, err := q.Filters()
+	if err != nil {
+		return output.Result{}, &output.UsageError{Err: err}
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+	txns, err := c.GetAllTransactions(ctx, filters, nil)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	return transactionsResult(txns), nil
+}
+
+func txnsUpdate(ctx context.Context, a *app, args []string) (output.Result, error) {
+	fs := a.flags("update")
+	var ut lunchmoney.UpdateTransaction
+	payee := fs.String("payee", "", "new payee")
+	notes := fs.String("notes", "", "new notes")
+	status := fs.String("status", "", "new status: cleared or uncleared")
+	date := fs.String("date", "", "new date, as YYYY-MM-DD")
+	category := fs.Int64("category", 0, "new category ID")
+	if err := a.parse(fs, args); err != nil {
+		return output.Result{}, err
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	delete(set, "o")
+	delete(set, "output")
+
+	if fs.NArg() == 0 {
+		return output.Result{}, &output.UsageError{Err: errors.New("usage: lunchmoney txns update [flags] <id>...")}
+	}
+	ids := make([]lunchmoney.TransactionID, fs.NArg())
+	for i, arg := range fs.Args() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return output.Result{}, &output.UsageError{Err: fmt.Errorf("invalid transaction ID %q", arg)}
+		}
+		ids[i] = lunchmoney.TransactionID(id)
+	}
+
+	if set["payee"] {
+		ut.Payee = payee
+	}
+	if set["notes"] {
+		ut.Notes = notes
+	}
+	if set["status"] {
+		ut.Status = status
+	}
+	if set["category"] {
+		ut.CategoryID = lunchmoney.Ptr(lunchmoney.CategoryID(*category))
+	}
+	if set["date"] {
+		d, err := lunchmoney.ParseDate(*date)
+		if err != nil {
+			return output.Result{}, &output.UsageError{Err: err}
+		}
+		ut.Date = &d
+	}
+	if len(set) == 0 {
+		return output.Result{}, &output.UsageError{Err: errors.New("nothing to update")}
+	}
+
+	c, err := a.Client(ctx)
+	if err != nil {
+		return output.Result{}, err
+	}
+
+	// Each transaction is updated on its own, so one failure does not stop
+	// the others. The updated transactions are printed either way. A
+	// transaction that was updated but cannot be read back still counts as
+	// updated; it is left out of the output with a warning.
+	var (
+		updated []*lunchmoney.Transaction
+		n       int
+		errs    []error
+	)
+	for _, id := range ids {
+		if _, err := c.UpdateTransaction(ctx, id, &ut); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		n++
+
+		t, err := c.GetTransaction(ctx, id, nil)
+		if err != nil {
+			fmt.Fprintf(a.stderr, "warning: transaction %d was updated but could not be read back: %v\n", id, err)
+			continue
+		}
+		updated = append(updated, t)
+	}
+
+	switch {
+	case len(errs) == 0:
+		return transactionsResult(updated), nil
+	case n == 0:
+		return output.Result{}, errors.Join(errs...)
+	default:
+		return transactionsResult(updated), &output.PartialError{Succeeded: n, Errs: errs}
+	}
+}
+
+func transactionsResult(txns []*lunchmoney.Transaction) output.Result {
+	r := output.Result{
+		Columns: []string{"id", "date", "payee", "amount", "currency", "category_id", "status"},
+		Data:    txns,
+	}
+	for _, t := range txns {
+		r.Rows = append(r.Rows, []string{
+			strconv.FormatInt(int64(t.ID), 10),
+			t.Date.String(),
+			t.Payee,
+			t.Amount.String(),
+			t.Currency,
+			optionalID(t.CategoryID),
+			t.Status,
+		})
+	}
+
+	return r
+}
+
+// dateRange resolves the --month, --start and --end flags. With none set it
+// returns the current month.
+func dateRange(month, start, end string) (lunchmoney.Date, lunchmoney.Date, error) {
+	if month != "" && (start != "" || end != "") {
+		return lunchmoney.Date{}, lunchmoney.Date{}, &output.UsageError{Err: errors.New("--month cannot be combined with --start or --end")}
+	}
+
+	if start != "" || end != "" {
+		if start == "" || end == "" {
+			return lunchmoney.Date{}, lunchmoney.Date{}, &output.UsageError{Err: errors.New("--start and --end must be given together")}
+		}
+		from, err := lunchmoney.ParseDate(start)
+		if err != nil {
+			return lunchmoney.Date{}, lunchmoney.Date{}, &output.UsageError{Err: err}
+		}
+		to, err := lunchmoney.ParseDate(end)
+		if err != nil {
+			return lunchmoney.Date{}, lunchmoney.Date{}, &output.UsageError{Err: err}
+		}
+		return from, to, nil
+	}
+
+	first := lunchmoney.Today()
+	first.Day = 1
+	if month != "" {
+		t, err := time.Parse("2006-01", month)
+		if err != nil {
+			return lunchmoney.Date{}, lunchmoney.Date{}, &output.UsageError{Err: fmt.Errorf("invalid month %q, want YYYY-MM", month)}
+		}
+		first = lunchmoney.DateOf(t)
+	}
+
+	return first, first.AddMonths(1).AddDays(-1), nil
+}
+
+func optionalID[ID ~int64](id *ID) string {
+	if id == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(int64(*id), 10)
+}
@@ -0,0 +1,117 @@
+// Package coingecko is a lunchmoney.PriceProvider backed by the CoinGecko
+// simple price API. It is an example of a price source for
+// lunchmoney.EnrichCrypto; any other source can be used by implementing the
+// interface.
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+)
+
+// BaseURL is the CoinGecko API used by default.
+const BaseURL = "https://api.coingecko.com/api/v3"
+
+// DefaultIDs maps common ticker symbols to CoinGecko coin IDs.
+var DefaultIDs = map[string]string{
+	"btc":  "bitcoin",
+	"eth":  "ethereum",
+	"sol":  "solana",
+	"ada":  "cardano",
+	"dot":  "polkadot",
+	"doge": "dogecoin",
+	"ltc":  "litecoin",
+	"xrp":  "ripple",
+	"usdc": "usd-coin",
+	"usdt": "tether",
+}
+
+// Options configure a Provider.
+type Options struct {
+	// BaseURL overrides the API URL, for example for the pro API.
+	BaseURL string
+	// APIKey is sent as the demo API key header, if set.
+	APIKey string
+	// IDs add to or override DefaultIDs. CoinGecko identifies coins by ID
+	// rather than by symbol, since symbols are not unique.
+	IDs map[string]string
+	// HTTP is the client used for requests. Defaults to
+	// http.DefaultClient.
+	HTTP *http.Client
+}
+
+// Provider quotes prices from CoinGecko.
+type Provider struct {
+	opts Options
+	ids  map[string]string
+}
+
+// New creates a provider.
+func New(opts Options) *Provider {
+	if opts.BaseURL == "" {
+		opts.BaseURL = BaseURL
+	}
+	if opts.HTTP == nil {
+		opts.HTTP = http.DefaultClient
+	}
+
+	ids := map[string]string{}
+	for k, v := range DefaultIDs {
+		ids[k] = v
+	}
+	for k, v := range opts.IDs {
+		ids[strings.ToLower(k)] = v
+	}
+
+	return &Provider{opts: opts, ids: ids}
+}
+
+// Price implements lunchmoney.PriceProvider.
+func (p *Provider) Price(ctx context.Context, symbol, fiat string) (lunchmoney.Decimal, error) {
+	symbol, fiat = strings.ToLower(symbol), strings.ToLower(fiat)
+	id, ok := p.ids[symbol]
+	if !ok {
+		return lunchmoney.Decimal{}, fmt.Errorf("no CoinGecko ID for symbol %q", symbol)
+	}
+
+	u := strings.TrimSuffix(p.opts.BaseURL, "/") + "/simple/price?" + url.Values{
+		"ids":           {id},
+		"vs_currencies": {fiat},
+		"precision":     {"full"},
+	}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return lunchmoney.Decimal{}, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.opts.APIKey != "" {
+		req.Header.Set("x-cg-demo-api-key", p.opts.APIKey)
+	}
+
+	resp, err := p.opts.HTTP.Do(req)
+	if err != nil {
+		return lunchmoney.Decimal{}, fmt.Errorf("get price: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return lunchmoney.Decimal{}, fmt.Errorf("get price: %s", resp.Status)
+	}
+
+	var prices map[string]map[string]lunchmoney.Decimal
+	if err := json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+		return lunchmoney.Decimal{}, fmt.Errorf("decode response: %w", err)
+	}
+	price, ok := prices[id][fiat]
+	if !ok {
+		return lunchmoney.Decimal{}, fmt.Errorf("no %s price for %s", fiat, id)
+	}
+
+	return price, nil
+}
@@ -0,0 +1,46 @@
+package coingecko
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/simple/price", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("x-cg-demo-api-key"))
+		assert.Equal(t, "usd", r.URL.Query().Get("vs_currencies"))
+		switch r.URL.Query().Get("ids") {
+		case "bitcoin":
+			_, _ = w.Write([]byte(`{"bitcoin": {"usd": 64123.12}}`))
+		case "shiba-inu":
+			_, _ = w.Write([]byte(`{"shiba-inu": {"usd": 1.7e-05}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	p := New(Options{BaseURL: server.URL, APIKey: "key", IDs: map[string]string{"SHIB": "shiba-inu", "new": "new-coin"}})
+
+	price, err := p.Price(context.Background(), "BTC", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "64123.12", price.String())
+
+	price, err = p.Price(context.Background(), "shib", "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "0.000017", price.String())
+
+	_, err = p.Price(context.Background(), "new", "usd")
+	assert.ErrorContains(t, err, "no usd price for new-coin")
+	_, err = p.Price(context.Background(), "zzz", "usd")
+	assert.ErrorContains(t, err, `no CoinGecko ID for symbol "zzz"`)
+
+	var _ lunchmoney.PriceProvider = p
+}
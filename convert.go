@@ -0,0 +1,126 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrNoRate is returned when a Converter has no exchange rate for a currency.
+var ErrNoRate = errors.New("no exchange rate")
+
+// RateProvider supplies exchange rates a Converter has not learned from the
+// API, for example from an external exchange rate service.
+type RateProvider interface {
+	// Rate returns how many units of to one unit of from is worth.
+	Rate(ctx context.Context, from, to string) (Decimal, error)
+}
+
+// rateScale is the number of decimal places kept for learned rates.
+const rateScale = 10
+
+// Converter converts amounts into the user's primary currency. It learns
+// rates from the to_base values the API reports alongside amounts, and falls
+// back to a RateProvider for currencies it has not seen. It is safe for
+// concurrent use.
+type Converter struct {
+	primary  string
+	provider RateProvider
+
+	mu       sync.RWMutex
+	observed map[string]observed
+}
+
+// observed sums the absolute amounts and to_base values seen in a currency.
+type observed struct {
+	amount, toBase Decimal
+}
+
+// NewConverter returns a converter into primary. provider may be nil.
+func NewConverter(primary string, provider RateProvider) *Converter {
+	return &Converter{
+		primary:  strings.ToUpper(primary),
+		provider: provider,
+		observed: map[string]observed{},
+	}
+}
+
+// Converter returns a converter into the user's primary currency, as reported
+// by /v1/me. provider may be nil.
+func (c *Client) Converter(ctx context.Context, provider RateProvider) (*Converter, error) {
+	u, err := c.GetUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get primary currency: %w", err)
+	}
+
+	return NewConverter(u.PrimaryCurrency, provider), nil
+}
+
+// Primary returns the upper-cased currency amounts are converted into.
+func (cv *Converter) Primary() string {
+	return cv.primary
+}
+
+// Observe learns the rate for currency from an amount and its to_base value.
+// Zero amounts are ignored. The rate is the sum of the to_base values over the
+// sum of the amounts observed, so a small amount with a heavily rounded
+// to_base barely moves it.
+func (cv *Converter) Observe(amount Decimal, currency string, toBase Decimal) {
+	currency = strings.ToUpper(currency)
+	if amount.IsZero() || currency == "" || currency == cv.primary {
+		return
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	o := cv.observed[currency]
+	cv.observed[currency] = observed{amount: o.amount.Add(amount.Abs()), toBase: o.toBase.Add(toBase.Abs())}
+}
+
+// ObserveTransactions learns rates from the to_base values of txns.
+func (cv *Converter) ObserveTransactions(txns []*Transaction) {
+	for _, t := range txns {
+		cv.Observe(t.Amount, t.Currency, t.ToBase)
+	}
+}
+
+// Rate returns how much one unit of currency is worth in the primary
+// currency. The error wraps ErrNoRate if the rate is unknown and there is no
+// provider.
+func (cv *Converter) Rate(ctx context.Context, currency string) (Decimal, error) {
+	currency = strings.ToUpper(currency)
+	if currency == cv.primary {
+		return NewDecimal(1, 0), nil
+	}
+
+	cv.mu.RLock()
+	o, ok := cv.observed[currency]
+	cv.mu.RUnlock()
+	if ok {
+		return o.toBase.Div(o.amount, rateScale), nil
+	}
+
+	if cv.provider == nil {
+		return Decimal{}, fmt.Errorf("%s to %s: %w", currency, cv.primary, ErrNoRate)
+	}
+
+	r, err := cv.provider.Rate(ctx, currency, cv.primary)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%s to %s: %w", currency, cv.primary, err)
+	}
+
+	return r, nil
+}
+
+// Convert converts amount from currency into the primary currency, rounded
+// to the primary currency's minor units.
+func (cv *Converter) Convert(ctx context.Context, amount Decimal, currency string) (Decimal, error) {
+	r, err := cv.Rate(ctx, currency)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return amount.Mul(r).Round(currencyFraction(cv.primary)), nil
+}
@@ -0,0 +1,63 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedRates map[string]Decimal
+
+func (f fixedRates) Rate(_ context.Context, from, _ string) (Decimal, error) {
+	r, ok := f[from]
+	if !ok {
+		return Decimal{}, errors.New("unsupported")
+	}
+
+	return r, nil
+}
+
+func TestConverter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"user_name": "Test", "primary_currency": "usd"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	cv, err := client.Converter(context.Background(), fixedRates{"GBP": MustParseDecimal("1.25")})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", cv.Primary())
+
+	cv.ObserveTransactions([]*Transaction{
+		{Amount: MustParseDecimal("100.00"), Currency: "eur", ToBase: MustParseDecimal("108.50")},
+		{Amount: MustParseDecimal("0"), Currency: "cad", ToBase: MustParseDecimal("0")},
+		// A later small amount with a rounded to_base does not replace the
+		// rate learned from the large one.
+		{Amount: MustParseDecimal("-0.01"), Currency: "eur", ToBase: MustParseDecimal("-0.02")},
+	})
+
+	got, err := cv.Convert(context.Background(), MustParseDecimal("10"), "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, "10.85", got.String())
+
+	got, err = cv.Convert(context.Background(), MustParseDecimal("3.333"), "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "3.33", got.String())
+
+	got, err = cv.Convert(context.Background(), MustParseDecimal("2"), "gbp")
+	require.NoError(t, err)
+	assert.Equal(t, "2.50", got.String())
+
+	_, err = cv.Convert(context.Background(), MustParseDecimal("2"), "cad")
+	assert.ErrorContains(t, err, "unsupported")
+
+	_, err = NewConverter("usd", nil).Convert(context.Background(), MustParseDecimal("2"), "cad")
+	assert.ErrorIs(t, err, ErrNoRate)
+}
@@ -0,0 +1,222 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Rhymond/go-money"
+)
+
+// DefaultCryptoFraction is the number of decimal places used for crypto
+// currencies go-money does not know. Eight places keep realistic balances
+// within the int64 range money.Money uses.
+const DefaultCryptoFraction = 8
+
+// cryptoCurrencies are registered with go-money when the package loads.
+// Fractions are capped at DefaultCryptoFraction for the same reason.
+var cryptoCurrencies = map[string]int{
+	"BTC":   8,
+	"ETH":   8,
+	"SOL":   8,
+	"DOGE":  8,
+	"LTC":   8,
+	"BCH":   8,
+	"DOT":   8,
+	"AVAX":  8,
+	"MATIC": 8,
+	"ADA":   6,
+	"XRP":   6,
+	"USDT":  6,
+	"USDC":  6,
+	"DAI":   8,
+}
+
+// currencyMu guards go-money's currency registry: RegisterCurrency writes it
+// and the package's own lookups read it under the lock.
+var currencyMu sync.RWMutex
+
+// registered holds the codes RegisterCurrency has seen, upper case, so a
+// known code is never registered again.
+var registered sync.Map
+
+func init() {
+	for code, fraction := range cryptoCurrencies {
+		registerCurrency(code, fraction)
+	}
+}
+
+// RegisterCurrency makes a currency known to go-money with the given number
+// of decimal places, so amounts in it convert and format correctly. Existing
+// currencies are left untouched, and each code is registered once. go-money's
+// registry is global: the package's own conversions are safe to run while a
+// currency is registered, but callers reading it directly, such as with
+// money.GetCurrency, should register currencies during program start-up.
+func RegisterCurrency(code string, fraction int) {
+	code = strings.ToUpper(code)
+	if _, ok := registered.Load(code); ok {
+		return
+	}
+
+	currencyMu.Lock()
+	defer currencyMu.Unlock()
+
+	registerCurrency(code, fraction)
+}
+
+func registerCurrency(code string, fraction int) {
+	code = strings.ToUpper(code)
+	if money.GetCurrency(code) == nil {
+		money.AddCurrency(code, code+" ", "$1", ".", ",", fraction)
+	}
+	registered.Store(code, true)
+}
+
+// CryptoResponse is the response from getting all crypto assets.
+type CryptoResponse struct {
+	Crypto []*Crypto `json:"crypto"`
+}
+
+// Crypto is a crypto asset, either synced from an exchange or wallet or
+// tracked manually.
+type Crypto struct {
+	ID              CryptoID  `json:"id"`
+	ZaboAccountID   *int64    `json:"zabo_account_id"`
+	Source          string    `json:"source"` // "synced" or "manual"
+	Name            string    `json:"name"`
+	DisplayName     string    `json:"display_name"`
+	Balance         Decimal   `json:"balance"`
+	BalanceAsOf     Timestamp `json:"balance_as_of"`
+	Currency        string    `json:"currency"`
+	Status          string    `json:"status"`
+	InstitutionName string    `json:"institution_name"`
+	CreatedAt       Timestamp `json:"created_at"`
+	ToBase          Decimal   `json:"to_base"` // the balance converted to the user's primary currency
+
+	// Extra holds the fields of the crypto balance the client does not know about
+	// yet, so they survive re-encoding.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes c, keeping unknown fields in Extra.
+func (c *Crypto) UnmarshalJSON(data []byte) error {
+	type plain Crypto
+	extra, err := decodeExtra(data, (*plain)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes c together with the fields in Extra.
+func (c Crypto) MarshalJSON() ([]byte, error) {
+	type plain Crypto
+	return encodeExtra(plain(c), c.Extra)
+}
+
+// String describes the crypto balance for logs and debugging, such as
+// "crypto 3 Cold wallet 0.5 BTC".
+func (c *Crypto) String() string {
+	return fmt.Sprintf("crypto %d %s %s %s", c.ID, c.Name, c.Balance, strings.ToUpper(c.Currency))
+}
+
+// ParsedAmount converts the crypto balance into a money.Money object. Codes
+// go-money does not know, such as newer tokens, are registered with
+// DefaultCryptoFraction decimal places on first use.
+func (c *Crypto) ParsedAmount() (*money.Money, error) {
+	RegisterCurrency(c.Currency, DefaultCryptoFraction)
+	return c.Balance.Money(c.Currency), nil
+}
+
+// ToBaseMoney is like Transaction.ToBaseMoney for the crypto balance. Manual
+// balances may have no to_base value until EnrichCrypto prices them.
+func (c *Crypto) ToBaseMoney(primaryCurrency string) *money.Money {
+	return c.ToBase.Money(primaryCurrency)
+}
+
+// GetCrypto retrieves all crypto assets from the Lunch Money API.
+func (c *Client) GetCrypto(ctx context.Context) ([]*Crypto, error) {
+	resp := &CryptoResponse{}
+	if err := c.getJSON(ctx, c.endpoint("crypto"), nil, resp); err != nil {
+		return nil, fmt.Errorf("get crypto: %w", err)
+	}
+
+	return validateRecords(ctx, c, resp.Crypto)
+}
+
+// PriceProvider quotes crypto prices, for valuing manually tracked crypto
+// the API reports without a to_base value.
+type PriceProvider interface {
+	// Price returns the price of one unit of symbol, such as "btc", in the
+	// fiat currency, such as "usd".
+	Price(ctx context.Context, symbol, fiat string) (Decimal, error)
+}
+
+// EnrichCrypto fills in ToBase for crypto balances that have a balance but
+// no to_base value, pricing them in the primary currency with p. Each symbol
+// is quoted once. Balances that cannot be priced are left as they are and
+// their errors joined.
+func EnrichCrypto(ctx context.Context, crypto []*Crypto, primary string, p PriceProvider) error {
+	primary = strings.ToLower(primary)
+	prices := map[string]Decimal{}
+	failed := map[string]bool{}
+
+	var errs []error
+	for _, cr := range crypto {
+		if !cr.ToBase.IsZero() || cr.Balance.IsZero() {
+			continue
+		}
+
+		symbol := strings.ToLower(cr.Currency)
+		if failed[symbol] {
+			continue
+		}
+		price, ok := prices[symbol]
+		if !ok {
+			var err error
+			if price, err = p.Price(ctx, symbol, primary); err != nil {
+				errs = append(errs, fmt.Errorf("price %s in %s: %w", symbol, primary, err))
+				failed[symbol] = true
+				continue
+			}
+			prices[symbol] = price
+		}
+
+		cr.ToBase = cr.Balance.Mul(price).Round(currencyFraction(strings.ToUpper(primary)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// UpdateCrypto contains the fields that can be updated for a manually
+// tracked crypto balance. Only non-nil fields will be sent in the update
+// request.
+type UpdateCrypto struct {
+	Name            *string  `json:"name,omitempty" validate:"omitnil,max=45"`
+	DisplayName     *string  `json:"display_name,omitempty"`
+	InstitutionName *string  `json:"institution_name,omitempty"`
+	Balance         *Decimal `json:"balance,omitempty"`
+	Currency        *string  `json:"currency,omitempty"`
+}
+
+// UpdateManualCrypto modifies the manually tracked crypto balance with the
+// specified ID and returns it. Balances synced from an exchange or wallet
+// cannot be updated.
+func (c *Client) UpdateManualCrypto(ctx context.Context, id CryptoID, crypto *UpdateCrypto) (*Crypto, error) {
+	if err := c.validateRequest(ctx, crypto); err != nil {
+		return nil, err
+	}
+
+	body, err := c.Put(ctx, c.endpoint("crypto/manual/%d", id), crypto)
+	if err != nil {
+		return nil, fmt.Errorf("put crypto %d: %w", id, err)
+	}
+
+	resp := &Crypto{}
+	if err := c.decode(body, resp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp, nil
+}
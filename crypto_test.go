@@ -0,0 +1,114 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoParsedAmount(t *testing.T) {
+	var cs []*Crypto
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id": 1, "source": "manual", "balance": "0.12345678", "currency": "btc"},
+		{"id": 2, "source": "synced", "balance": "1500.5", "currency": "doge"},
+		{"id": 3, "source": "manual", "balance": "2.000000019", "currency": "zzq"}
+	]`), &cs))
+
+	btc, err := cs[0].ParsedAmount()
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345678), btc.Amount())
+	assert.Equal(t, 8, btc.Currency().Fraction)
+
+	doge, err := cs[1].ParsedAmount()
+	require.NoError(t, err)
+	assert.Equal(t, int64(150050000000), doge.Amount())
+
+	unknown, err := cs[2].ParsedAmount()
+	require.NoError(t, err)
+	assert.Equal(t, "ZZQ", unknown.Currency().Code)
+	assert.Equal(t, int64(200000002), unknown.Amount())
+}
+
+// Run with -race: registering new codes must not race with amounts being
+// converted in other goroutines.
+func TestCryptoParsedAmountConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := &Crypto{Balance: MustParseDecimal("1.5"), Currency: fmt.Sprintf("qq%d", i%3)}
+			for range 50 {
+				m, err := c.ParsedAmount()
+				assert.NoError(t, err)
+				assert.Equal(t, int64(150000000), m.Amount())
+				assert.Equal(t, int64(150), MustParseDecimal("1.5").Money("USD").Amount())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type fakePrices map[string]string
+
+func (f fakePrices) Price(_ context.Context, symbol, fiat string) (Decimal, error) {
+	p, ok := f[symbol+"/"+fiat]
+	if !ok {
+		return Decimal{}, errors.New("unknown")
+	}
+	return MustParseDecimal(p), nil
+}
+
+func TestEnrichCrypto(t *testing.T) {
+	crypto := []*Crypto{
+		{ID: 1, Currency: "BTC", Balance: MustParseDecimal("0.5")},
+		{ID: 2, Currency: "btc", Balance: MustParseDecimal("0.1")},
+		{ID: 3, Currency: "eth", Balance: MustParseDecimal("2"), ToBase: MustParseDecimal("5000")},
+		{ID: 4, Currency: "xyz", Balance: MustParseDecimal("3")},
+		{ID: 5, Currency: "xyz", Balance: MustParseDecimal("4")},
+		{ID: 6, Currency: "sol"},
+	}
+
+	err := EnrichCrypto(context.Background(), crypto, "USD", fakePrices{"btc/usd": "60000.123", "eth/usd": "1"})
+	require.Error(t, err)
+	assert.Equal(t, "price xyz in usd: unknown", err.Error())
+
+	assert.Equal(t, "30000.06", crypto[0].ToBase.String())
+	assert.Equal(t, "6000.01", crypto[1].ToBase.String())
+	assert.Equal(t, "5000", crypto[2].ToBase.String())
+	assert.True(t, crypto[3].ToBase.IsZero())
+	assert.True(t, crypto[5].ToBase.IsZero())
+}
+
+func TestUpdateManualCrypto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1/crypto/manual/3", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"balance": "1.25"}`, string(body))
+		_, _ = w.Write([]byte(`{"id": 3, "source": "manual", "name": "Cold wallet", "balance": "1.250000000000000000", "currency": "btc"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	c, err := client.UpdateManualCrypto(context.Background(), 3, &UpdateCrypto{Balance: Ptr(MustParseDecimal("1.25"))})
+	require.NoError(t, err)
+	assert.Equal(t, CryptoID(3), c.ID)
+	assert.Equal(t, 0, c.Balance.Cmp(MustParseDecimal("1.25")))
+
+	_, err = client.UpdateManualCrypto(context.Background(), 3, &UpdateCrypto{Name: Ptr(strings.Repeat("x", 46))})
+	require.Error(t, err)
+}
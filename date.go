@@ -0,0 +1,199 @@
+package lunchmoney
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Date is a calendar date without a time or time zone, as used by the API for
+// transaction dates, filters, budgets and recurring items. It encodes to JSON
+// as "YYYY-MM-DD". The zero value means no date and encodes as null.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the date for the given year, month and day, normalizing
+// out-of-range values the same way time.Date does.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// Today returns the current date in the local time zone.
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// ParseDate parses a "YYYY-MM-DD" string.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("%q is not a valid date: %w", s, err)
+	}
+
+	return DateOf(t), nil
+}
+
+// MustParseDate is like ParseDate but panics on invalid input. It is intended
+// for constants and tests.
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// String returns the date as "YYYY-MM-DD", or "" for the zero date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.Time().Format(time.DateOnly)
+}
+
+// IsZero reports whether d is the zero date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Time returns midnight UTC at the start of d.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Time().AddDate(0, 0, n))
+}
+
+// AddMonths returns the date n months after d, normalized like time.AddDate.
+func (d Date) AddMonths(n int) Date {
+	return DateOf(d.Time().AddDate(0, n, 0))
+}
+
+// Sub returns the number of days from o to d.
+func (d Date) Sub(o Date) int {
+	return int(d.Time().Sub(o.Time()).Hours() / 24)
+}
+
+// Compare returns -1 if d is before o, 0 if they are equal and 1 if d is
+// after o.
+func (d Date) Compare(o Date) int {
+	return d.Time().Compare(o.Time())
+}
+
+// Before reports whether d is before o.
+func (d Date) Before(o Date) bool {
+	return d.Compare(o) < 0
+}
+
+// After reports whether d is after o.
+func (d Date) After(o Date) bool {
+	return d.Compare(o) > 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The empty string
+// decodes to the zero date.
+func (d *Date) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*d = Date{}
+		return nil
+	}
+
+	v, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes d as a "YYYY-MM-DD" string, or null for the zero date.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes a "YYYY-MM-DD" string. Null and the empty string
+// decode to the zero date.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*d = Date{}
+		return nil
+	}
+
+	s, err := strconv.Unquote(s)
+	if err != nil {
+		return fmt.Errorf("decode date: %w", err)
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
+
+// newValidator returns a validator that understands the package's custom
+// types, so tags like datetime and required work on Date and Timestamp
+// fields. Fields are reported by their JSON names. Without options the
+// validator is shared, since it caches what it learns about each struct type
+// and creating one per request dominated the cost of validating responses.
+func newValidator(opts ...validator.Option) *validator.Validate {
+	if len(opts) == 0 {
+		return defaultValidator()
+	}
+
+	v := validator.New(opts...)
+	registerTypes(v)
+
+	return v
+}
+
+// defaultValidator is the shared validator without options. A
+// validator.Validate is safe for concurrent use.
+var defaultValidator = sync.OnceValue(func() *validator.Validate {
+	v := validator.New()
+	registerTypes(v)
+
+	return v
+})
+
+// registerTypes teaches v the package's custom types and JSON field names.
+func registerTypes(v *validator.Validate) {
+	v.RegisterCustomTypeFunc(func(f reflect.Value) any {
+		return f.Interface().(Date).String()
+	}, Date{})
+	v.RegisterCustomTypeFunc(func(f reflect.Value) any {
+		return f.Interface().(Timestamp).Time
+	}, Timestamp{})
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || name == "" {
+			return f.Name
+		}
+		return name
+	})
+}
@@ -0,0 +1,47 @@
+package lunchmoney
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateJSON(t *testing.T) {
+	var v struct {
+		A Date  `json:"a"`
+		B Date  `json:"b"`
+		C *Date `json:"c"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"a": "2024-02-29", "b": null, "c": ""}`), &v))
+	assert.Equal(t, NewDate(2024, 2, 29), v.A)
+	assert.True(t, v.B.IsZero())
+	require.NotNil(t, v.C)
+	assert.True(t, v.C.IsZero())
+
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": "2024-02-29", "b": null, "c": null}`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"a": "2024-02-30"}`), &v))
+	assert.Error(t, json.Unmarshal([]byte(`{"a": 20240201}`), &v))
+}
+
+func TestDateArithmetic(t *testing.T) {
+	d := MustParseDate("2024-01-31")
+	assert.Equal(t, "2024-02-01", d.AddDays(1).String())
+	assert.Equal(t, "2024-03-02", d.AddMonths(1).String())
+	assert.Equal(t, 29, MustParseDate("2024-02-29").Sub(d))
+	assert.True(t, d.Before(d.AddDays(1)))
+	assert.True(t, d.After(d.AddDays(-1)))
+	assert.Equal(t, 0, d.Compare(NewDate(2024, 1, 31)))
+	assert.Equal(t, "", Date{}.String())
+}
+
+func TestDateValidation(t *testing.T) {
+	v := newValidator()
+	assert.NoError(t, v.Struct(InsertTransaction{Date: NewDate(2024, 1, 1), Status: "cleared"}))
+	assert.Error(t, v.Struct(InsertTransaction{Status: "cleared"}))
+	assert.Error(t, v.Struct(BudgetFilters{StartDate: NewDate(2024, 1, 1)}))
+}
@@ -0,0 +1,315 @@
+package lunchmoney
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Decimal is an arbitrary-precision decimal number used for amounts and
+// balances. Unlike float64 it represents values such as "0.1" exactly, and
+// unlike a plain string it supports arithmetic. It is encoded to JSON as a
+// string of its digits in plain notation, keeping trailing zeros, so no
+// precision is lost on a round trip. The text itself is not kept: "1.5e3"
+// is re-encoded as "1500", and null and "" decode to zero and re-encode as
+// "0".
+//
+// The zero value is 0. Decimals are immutable; arithmetic returns new values.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, so NewDecimal(1234, 2) is 12.34.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(unscaled), scale: scale}
+}
+
+// maxDecimalExponent bounds the exponent and the number of decimal places
+// ParseDecimal accepts, so input such as "1e2147483647" from a remote source
+// cannot make it allocate a huge number.
+const maxDecimalExponent = 1000
+
+// ParseDecimal parses a decimal string such as "-12.340" or "1.5e3". The
+// exponent and the number of decimal places may not exceed 1000.
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	exp := int64(0)
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%q is not a valid decimal: %w", s, err)
+		}
+		if e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("%q has an exponent outside ±%d", s, maxDecimalExponent)
+		}
+		exp = e
+		str = str[:i]
+	}
+
+	neg := false
+	if strings.HasPrefix(str, "-") {
+		neg = true
+		str = str[1:]
+	} else {
+		str = strings.TrimPrefix(str, "+")
+	}
+
+	intPart, frac, _ := strings.Cut(str, ".")
+	digits := intPart + frac
+	if digits == "" || strings.ContainsFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) {
+		return Decimal{}, fmt.Errorf("%q is not a valid decimal", s)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%q is not a valid decimal", s)
+	}
+
+	scale := int64(len(frac)) - exp
+	if scale > maxDecimalExponent {
+		return Decimal{}, fmt.Errorf("%q has more than %d decimal places", s, maxDecimalExponent)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+
+	if neg {
+		coef.Neg(coef)
+	}
+
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input. It is
+// intended for constants and tests.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+
+	return d.coef
+}
+
+// rescale returns the coefficient of d expressed with the given larger scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.int()
+	}
+
+	return new(big.Int).Mul(d.int(), pow10(int64(scale-d.scale)))
+}
+
+// String returns the decimal with all of its digits, such as "-12.340".
+func (d Decimal) String() string {
+	if d.scale < 0 {
+		return Decimal{coef: d.rescale(0)}.String()
+	}
+
+	abs := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		scale := int(d.scale)
+		if len(abs) <= scale {
+			abs = strings.Repeat("0", scale-len(abs)+1) + abs
+		}
+		abs = abs[:len(abs)-scale] + "." + abs[len(abs)-scale:]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + abs
+	}
+
+	return abs
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Add returns d + o.
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := max(d.scale, o.scale)
+	return Decimal{coef: new(big.Int).Add(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - o.
+func (d Decimal) Sub(o Decimal) Decimal {
+	return d.Add(o.Neg())
+}
+
+// Mul returns d * o.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Div returns d / o rounded half away from zero to the given number of
+// decimal places. It panics if o is zero.
+func (d Decimal) Div(o Decimal, places int32) Decimal {
+	if o.IsZero() {
+		panic("lunchmoney: division by zero")
+	}
+
+	return ratToDecimal(new(big.Rat).Quo(d.Rat(), o.Rat()), places)
+}
+
+// Cmp compares d and o numerically and returns -1, 0 or 1.
+func (d Decimal) Cmp(o Decimal) int {
+	scale := max(d.scale, o.scale)
+	return d.rescale(scale).Cmp(o.rescale(scale))
+}
+
+// Equal reports whether d and o are numerically equal, so "1.50" equals "1.5".
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Round returns d rounded half away from zero to the given number of decimal
+// places. Values that already have fewer places are returned unchanged.
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	div := pow10(int64(d.scale - places))
+	q, r := new(big.Int).QuoRem(d.int(), div, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(div) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+
+	return Decimal{coef: q, scale: places}
+}
+
+// Rat returns d as an exact big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	if d.scale < 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(d.int(), pow10(int64(-d.scale))))
+	}
+
+	return new(big.Rat).SetFrac(d.int(), pow10(int64(d.scale)))
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Money converts d into a money.Money of the given currency, rounding half
+// away from zero to the currency's minor unit. money.Money holds an int64 of
+// minor units, so amounts beyond its range saturate at the largest or
+// smallest value it can hold.
+func (d Decimal) Money(currency string) *money.Money {
+	fraction := currencyFraction(currency)
+	minor := d.Round(fraction).rescale(fraction)
+
+	var amount int64
+	switch {
+	case minor.IsInt64():
+		amount = minor.Int64()
+	case minor.Sign() > 0:
+		amount = math.MaxInt64
+	default:
+		amount = math.MinInt64
+	}
+
+	currencyMu.RLock()
+	defer currencyMu.RUnlock()
+	return money.New(amount, currency)
+}
+
+// currencyFraction returns the number of decimal places of the currency's
+// minor unit, defaulting to 2 for unknown currencies.
+func currencyFraction(currency string) int32 {
+	currencyMu.RLock()
+	defer currencyMu.RUnlock()
+
+	if c := money.GetCurrency(currency); c != nil {
+		return int32(c.Fraction)
+	}
+
+	return 2
+}
+
+func ratToDecimal(r *big.Rat, places int32) Decimal {
+	num := new(big.Int).Mul(r.Num(), pow10(int64(places)))
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	return Decimal{coef: q, scale: places}
+}
+
+// MarshalJSON encodes d as a JSON string, the format the API uses.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a JSON string or number. Null and the empty string
+// decode to zero.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	if strings.HasPrefix(s, `"`) {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return fmt.Errorf("decode decimal: %w", err)
+		}
+
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+	return nil
+}
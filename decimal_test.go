@@ -0,0 +1,102 @@
+package lunchmoney
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "12.34", want: "12.34"},
+		{in: "-0.50", want: "-0.50"},
+		{in: "+7", want: "7"},
+		{in: ".5", want: "0.5"},
+		{in: "1.5e3", want: "1500"},
+		{in: "25e-4", want: "0.0025"},
+		{in: "0.000000001", want: "0.000000001"},
+		{in: "", wantErr: true},
+		{in: "12.3.4", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1e1000", want: "1" + strings.Repeat("0", 1000)},
+		{in: "1e2147483647", wantErr: true},
+		{in: "1e-2147483647", wantErr: true},
+		{in: "1e-1001", wantErr: true},
+		{in: "0." + strings.Repeat("0", 1000) + "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDecimal(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.2")
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.33", MustParseDecimal("1").Div(MustParseDecimal("3"), 2).String())
+	assert.Equal(t, "-0.67", MustParseDecimal("-2").Div(MustParseDecimal("3"), 2).String())
+	assert.True(t, MustParseDecimal("1.50").Equal(MustParseDecimal("1.5")))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, "1.24", MustParseDecimal("1.235").Round(2).String())
+	assert.Equal(t, "-1.24", MustParseDecimal("-1.235").Round(2).String())
+	assert.Equal(t, "500", NewDecimal(5, -2).String())
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.InDelta(t, 12.34, MustParseDecimal("12.34").Float64(), 1e-9)
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+		C Decimal `json:"c"`
+		D Decimal `json:"d"`
+		E Decimal `json:"e"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"a": "12.3400", "b": -5.5, "c": null, "d": "", "e": "1.5e3"}`), &v))
+	assert.Equal(t, "12.3400", v.A.String())
+	assert.Equal(t, "-5.5", v.B.String())
+	assert.True(t, v.C.IsZero())
+	assert.True(t, v.D.IsZero())
+
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": "12.3400", "b": "-5.5", "c": "0", "d": "0", "e": "1500"}`, string(out))
+}
+
+func TestDecimalMoney(t *testing.T) {
+	m := MustParseDecimal("0.29").Money("usd")
+	assert.Equal(t, int64(29), m.Amount())
+	assert.Equal(t, "USD", m.Currency().Code)
+
+	m = MustParseDecimal("1000").Money("jpy")
+	assert.Equal(t, int64(1000), m.Amount())
+
+	m, err := ParseCurrency("-12.345", "usd")
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1235), m.Amount())
+
+	// Beyond the range of int64 minor units the amount saturates.
+	assert.Equal(t, int64(math.MaxInt64), MustParseDecimal("1e20").Money("usd").Amount())
+	assert.Equal(t, int64(math.MinInt64), MustParseDecimal("-1e20").Money("usd").Amount())
+}
@@ -0,0 +1,201 @@
+package lunchmoney
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Sentinel errors for classes of API failure. Errors returned by the client
+// match them with errors.Is, for example:
+//
+//	if errors.Is(err, lunchmoney.ErrNotFound) { ... }
+var (
+	// ErrNotFound means the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized means the access token was rejected.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited means the API asked the client to slow down.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrValidation means a request was rejected as invalid, either by the
+	// API or by the client's own validation before sending it.
+	ErrValidation = errors.New("validation failed")
+)
+
+// APIError is returned when the API responds with an error.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response. The API sometimes
+	// reports rejected input with 200 OK and an error body.
+	StatusCode int
+	// Status is the HTTP status line, such as "404 Not Found".
+	Status string
+	// Message is the error reported in the response body, if any.
+	Message string
+	// RequestID is the ID the client sent in the request's X-Request-ID
+	// header.
+	RequestID string
+	// Correlation holds the response's CorrelationHeaders, by canonical
+	// header name, if it had any.
+	Correlation map[string]string
+	// RetryAfter is how long the response's Retry-After header asked the
+	// client to wait before trying again, or zero if it had none.
+	RetryAfter time.Duration
+}
+
+func newAPIError(resp *http.Response, requestID, msg string) *APIError {
+	return &APIError{
+		StatusCode:  resp.StatusCode,
+		Status:      resp.Status,
+		Message:     msg,
+		RequestID:   requestID,
+		Correlation: correlation(resp.Header),
+		RetryAfter:  parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return e.Status
+	}
+
+	return e.Status + ": " + e.Message
+}
+
+// Is matches e against the package's sentinel errors based on its status.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity || e.StatusCode == http.StatusOK
+	default:
+		return false
+	}
+}
+
+// ResponseTooLargeError is returned when a response body is larger than the
+// limit set with WithMaxResponseSize.
+type ResponseTooLargeError struct {
+	// Path is the API path of the request.
+	Path string
+	// Limit is the maximum size of a response body in bytes.
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response of %s is larger than %d bytes", e.Path, e.Limit)
+}
+
+// FieldError describes one field that failed validation.
+type FieldError struct {
+	// Field is the JSON path of the field, such as "start_date" or
+	// "transactions[0].amount".
+	Field string
+	// Constraint is the rule that failed, such as "required" or "oneof".
+	Constraint string
+	// Param is the constraint's parameter, such as "cleared uncleared" for
+	// oneof. It is empty for constraints without one.
+	Param string
+	// Value is the value that was offered.
+	Value any
+}
+
+func (e FieldError) String() string {
+	rule := e.Constraint
+	if e.Param != "" {
+		rule += "=" + e.Param
+	}
+
+	return fmt.Sprintf("%s: failed %s (got %v)", e.Field, rule, e.Value)
+}
+
+// ValidationError is returned when the client rejects a request before
+// sending it. It matches ErrValidation.
+type ValidationError struct {
+	Fields []FieldError
+
+	// err holds the validator's errors the fields were built from, if any.
+	err validator.ValidationErrors
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.String()
+	}
+
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the validator.ValidationErrors the error was built from,
+// which can be translated into other languages. Errors from rules spanning
+// several fields have none.
+func (e *ValidationError) Unwrap() error {
+	if e.err == nil {
+		return nil
+	}
+
+	return e.err
+}
+
+// newValidationError converts errors from the validator into a
+// ValidationError. Other errors are returned unchanged.
+func newValidationError(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	ret := &ValidationError{Fields: make([]FieldError, len(verrs)), err: verrs}
+	for i, fe := range verrs {
+		field := fe.Namespace()
+		if _, rest, ok := strings.Cut(field, "."); ok {
+			field = rest
+		}
+		ret.Fields[i] = FieldError{
+			Field:      field,
+			Constraint: fe.Tag(),
+			Param:      fe.Param(),
+			Value:      fe.Value(),
+		}
+	}
+
+	return ret
+}
+
+// IsNotFound reports whether err means the requested resource does not
+// exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err means the access token was rejected.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited reports whether err means the API is rate limiting the
+// client.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsValidation reports whether err means a request was invalid, including
+// errors from the client's validation of requests before they are sent.
+func IsValidation(err error) bool {
+	var verrs validator.ValidationErrors
+	return errors.Is(err, ErrValidation) || errors.As(err, &verrs)
+}
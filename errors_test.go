@@ -0,0 +1,95 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	status := http.StatusNotFound
+	body := `{"error": "Transaction not found"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetTransaction(context.Background(), 123, nil)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsUnauthorized(err))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Transaction not found", apiErr.Message)
+
+	status, body = http.StatusTooManyRequests, `<html>slow down</html>`
+	_, err = client.GetTags(context.Background())
+	assert.True(t, IsRateLimited(err))
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "<html>slow down</html>", apiErr.Message)
+
+	status, body = http.StatusOK, `{"error": ["Invalid date"]}`
+	_, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Notes: Ptr("x")})
+	assert.True(t, IsValidation(err))
+
+	_, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Status: Ptr("bogus")})
+	assert.True(t, IsValidation(err))
+	assert.False(t, IsValidation(errors.New("boom")))
+}
+
+func TestValidationError(t *testing.T) {
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+
+	status := "bogus"
+	_, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Status: &status})
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, FieldError{Field: "status", Constraint: "oneof", Param: "cleared uncleared", Value: "bogus"}, verr.Fields[0])
+	assert.Equal(t, `invalid request: status: failed oneof=cleared uncleared (got bogus)`, err.Error())
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.True(t, IsValidation(err))
+}
+
+type closeErrBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeErrBody) Close() error {
+	b.closed = true
+	return errors.New("connection reset")
+}
+
+func TestCloseBodyError(t *testing.T) {
+	var body *closeErrBody
+	client, err := NewClient("test-token", WithTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		body = &closeErrBody{Reader: strings.NewReader(`[{"id": 1, "name": "food"}] `)}
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: body}, nil
+	})))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/tags", nil)
+	require.ErrorContains(t, err, "error closing response body: connection reset")
+	assert.True(t, body.closed)
+
+	_, err = client.GetTags(context.Background())
+	require.ErrorContains(t, err, "error closing response body: connection reset")
+	assert.True(t, body.closed)
+	n, _ := body.Read(make([]byte, 1))
+	assert.Equal(t, 0, n, "body is drained")
+}
@@ -0,0 +1,246 @@
+// Package events defines the domain events emitted when Lunch Money data
+// changes. The JSON encoding of every event is a documented, versioned
+// contract: fields are only ever added within a schema version, never renamed
+// or removed, so consumers should ignore fields they do not know.
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// SchemaVersion is the version of the event schemas in this package. It is
+// bumped only for incompatible changes.
+const SchemaVersion = 1
+
+// Type identifies the kind of event in an Envelope.
+type Type string
+
+// Event types.
+const (
+	TypeTransactionCreated Type = "transaction.created"
+	TypeTransactionUpdated Type = "transaction.updated"
+	TypeStatusChanged      Type = "transaction.status_changed"
+	TypeBalanceChanged     Type = "balance.changed"
+	TypeBudgetExceeded     Type = "budget.exceeded"
+)
+
+// ErrUnknownType is returned by Envelope.Decode for event types this version
+// of the package does not know. Consumers should skip such events.
+var ErrUnknownType = errors.New("unknown event type")
+
+// Event is implemented by all event payloads.
+type Event interface {
+	EventType() Type
+}
+
+// Envelope wraps an event payload with its metadata. It is the unit that is
+// serialized and delivered to consumers.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       Type            `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEnvelope encodes e into a new envelope with a random ID.
+func NewEnvelope(e Event, occurredAt time.Time) (*Envelope, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s event: %w", e.EventType(), err)
+	}
+
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("generate event id: %w", err)
+	}
+
+	return &Envelope{
+		ID:         hex.EncodeToString(id[:]),
+		Type:       e.EventType(),
+		Version:    SchemaVersion,
+		OccurredAt: occurredAt.UTC(),
+		Data:       data,
+	}, nil
+}
+
+// Decode returns the typed payload of the envelope.
+func (env *Envelope) Decode() (Event, error) {
+	var e Event
+	switch env.Type {
+	case TypeTransactionCreated:
+		e = &TransactionCreated{}
+	case TypeTransactionUpdated:
+		e = &TransactionUpdated{}
+	case TypeStatusChanged:
+		e = &StatusChanged{}
+	case TypeBalanceChanged:
+		e = &BalanceChanged{}
+	case TypeBudgetExceeded:
+		e = &BudgetExceeded{}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, env.Type)
+	}
+
+	if err := json.Unmarshal(env.Data, e); err != nil {
+		return nil, fmt.Errorf("decode %s event: %w", env.Type, err)
+	}
+
+	return e, nil
+}
+
+// Transaction is the stable representation of a transaction inside events.
+// It is deliberately separate from lunchmoney.Transaction so that changes to
+// the API model do not change the event schema.
+type Transaction struct {
+	ID             lunchmoney.TransactionID   `json:"id"`
+	Date           lunchmoney.Date            `json:"date"`
+	Payee          string                     `json:"payee"`
+	Amount         lunchmoney.Decimal         `json:"amount"`
+	Currency       string                     `json:"currency"`
+	Notes          string                     `json:"notes,omitempty"`
+	Status         string                     `json:"status"`
+	CategoryID     *lunchmoney.CategoryID     `json:"category_id,omitempty"`
+	AssetID        *lunchmoney.AssetID        `json:"asset_id,omitempty"`
+	PlaidAccountID *lunchmoney.PlaidAccountID `json:"plaid_account_id,omitempty"`
+	ExternalID     string                     `json:"external_id,omitempty"`
+	// TagIDs are the IDs of the transaction's tags, in ascending order.
+	TagIDs []lunchmoney.TagID `json:"tag_ids,omitempty"`
+}
+
+// FromTransaction converts an API transaction into its event representation.
+func FromTransaction(t *lunchmoney.Transaction) Transaction {
+	return Transaction{
+		ID:             t.ID,
+		Date:           t.Date,
+		Payee:          t.Payee,
+		Amount:         t.Amount,
+		Currency:       t.Currency,
+		Notes:          t.Notes,
+		Status:         t.Status,
+		CategoryID:     t.CategoryID,
+		AssetID:        t.AssetID,
+		PlaidAccountID: t.PlaidAccountID,
+		ExternalID:     t.ExternalID,
+		TagIDs:         tagIDs(t.Tags),
+	}
+}
+
+func tagIDs(tags []*lunchmoney.Tag) []lunchmoney.TagID {
+	var ids []lunchmoney.TagID
+	for _, tag := range tags {
+		if tag != nil {
+			ids = append(ids, tag.ID)
+		}
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+// TransactionCreated is emitted the first time a transaction is seen.
+type TransactionCreated struct {
+	Transaction Transaction `json:"transaction"`
+}
+
+// EventType implements Event.
+func (*TransactionCreated) EventType() Type { return TypeTransactionCreated }
+
+// TransactionUpdated is emitted when a known transaction changes. Changed
+// lists the JSON names of the fields that differ between Before and After.
+type TransactionUpdated struct {
+	Before  Transaction `json:"before"`
+	After   Transaction `json:"after"`
+	Changed []string    `json:"changed"`
+}
+
+// EventType implements Event.
+func (*TransactionUpdated) EventType() Type { return TypeTransactionUpdated }
+
+// StatusChanged is emitted alongside TransactionUpdated when a transaction
+// moves between statuses, for example from uncleared to cleared.
+type StatusChanged struct {
+	Transaction Transaction `json:"transaction"`
+	Previous    string      `json:"previous"`
+	Current     string      `json:"current"`
+}
+
+// EventType implements Event.
+func (*StatusChanged) EventType() Type { return TypeStatusChanged }
+
+// Changed returns the JSON names of the fields that differ between before and
+// after, in the order they appear in Transaction. Amounts are compared by
+// value, so "4.5" and "4.50" are equal.
+func Changed(before, after Transaction) []string {
+	var ret []string
+	add := func(name string, differ bool) {
+		if differ {
+			ret = append(ret, name)
+		}
+	}
+
+	add("id", before.ID != after.ID)
+	add("date", before.Date != after.Date)
+	add("payee", before.Payee != after.Payee)
+	add("amount", !before.Amount.Equal(after.Amount))
+	add("currency", before.Currency != after.Currency)
+	add("notes", before.Notes != after.Notes)
+	add("status", before.Status != after.Status)
+	add("category_id", !equalPtr(before.CategoryID, after.CategoryID))
+	add("asset_id", !equalPtr(before.AssetID, after.AssetID))
+	add("plaid_account_id", !equalPtr(before.PlaidAccountID, after.PlaidAccountID))
+	add("external_id", before.ExternalID != after.ExternalID)
+	add("tag_ids", !slices.Equal(before.TagIDs, after.TagIDs))
+
+	return slices.Clip(ret)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// Account kinds used by BalanceChanged.
+const (
+	AccountAsset        = "asset"
+	AccountPlaidAccount = "plaid_account"
+	AccountCrypto       = "crypto"
+)
+
+// BalanceChanged is emitted when the balance of an account moves.
+type BalanceChanged struct {
+	AccountType string             `json:"account_type"`
+	AccountID   int64              `json:"account_id"`
+	Name        string             `json:"name"`
+	Previous    lunchmoney.Decimal `json:"previous"`
+	Current     lunchmoney.Decimal `json:"current"`
+	Currency    string             `json:"currency"`
+}
+
+// EventType implements Event.
+func (*BalanceChanged) EventType() Type { return TypeBalanceChanged }
+
+// BudgetExceeded is emitted when spending in a category passes its budget
+// for a month.
+type BudgetExceeded struct {
+	CategoryID   lunchmoney.CategoryID `json:"category_id"`
+	CategoryName string                `json:"category_name"`
+	Month        string                `json:"month"`
+	Budgeted     lunchmoney.Decimal    `json:"budgeted"`
+	Spent        lunchmoney.Decimal    `json:"spent"`
+	Currency     string                `json:"currency"`
+}
+
+// EventType implements Event.
+func (*BudgetExceeded) EventType() Type { return TypeBudgetExceeded }
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	env, err := NewEnvelope(&TransactionCreated{Transaction: FromTransaction(&lunchmoney.Transaction{
+		ID:       42,
+		Date:     lunchmoney.NewDate(2024, 6, 1),
+		Payee:    "Coffee",
+		Amount:   lunchmoney.MustParseDecimal("4.50"),
+		Currency: "usd",
+		Status:   "cleared",
+	})}, at)
+	require.NoError(t, err)
+	assert.Len(t, env.ID, 32)
+	assert.Equal(t, SchemaVersion, env.Version)
+
+	b, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	var decoded Envelope
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	e, err := decoded.Decode()
+	require.NoError(t, err)
+
+	created, ok := e.(*TransactionCreated)
+	require.True(t, ok)
+	assert.Equal(t, lunchmoney.TransactionID(42), created.Transaction.ID)
+	assert.Equal(t, "4.50", created.Transaction.Amount.String())
+	assert.JSONEq(t, `{"transaction": {"id": 42, "date": "2024-06-01", "payee": "Coffee", "amount": "4.50", "currency": "usd", "status": "cleared"}}`, string(decoded.Data))
+}
+
+func TestEnvelopeForwardCompatibility(t *testing.T) {
+	var env Envelope
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "x", "type": "balance.changed", "version": 1, "new_field": true,
+		"data": {"account_type": "asset", "account_id": 3, "current": "10", "future": {"a": 1}}}`), &env))
+	e, err := env.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), e.(*BalanceChanged).AccountID)
+
+	env.Type = "account.archived"
+	_, err = env.Decode()
+	assert.ErrorIs(t, err, ErrUnknownType)
+}
+
+func TestChanged(t *testing.T) {
+	cat := lunchmoney.CategoryID(1)
+	before := FromTransaction(&lunchmoney.Transaction{ID: 1, Payee: "Coffee", Amount: lunchmoney.MustParseDecimal("4.5"), Status: "uncleared"})
+	after := before
+	after.Amount = lunchmoney.MustParseDecimal("4.50")
+	assert.Empty(t, Changed(before, after))
+
+	after.Status = "cleared"
+	after.CategoryID = &cat
+	assert.Equal(t, []string{"status", "category_id"}, Changed(before, after))
+
+	tagged := FromTransaction(&lunchmoney.Transaction{ID: 1, Tags: []*lunchmoney.Tag{{ID: 9}, {ID: 4}}})
+	assert.Equal(t, []lunchmoney.TagID{4, 9}, tagged.TagIDs)
+	retagged := FromTransaction(&lunchmoney.Transaction{ID: 1, Tags: []*lunchmoney.Tag{{ID: 4}}})
+	assert.Equal(t, []string{"tag_ids"}, Changed(tagged, retagged))
+	assert.Empty(t, Changed(tagged, FromTransaction(&lunchmoney.Transaction{ID: 1, Tags: []*lunchmoney.Tag{{ID: 4}, {ID: 9}}})))
+}
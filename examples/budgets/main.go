@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/icco/lunchmoney"
 )
@@ -17,8 +18,8 @@ func main() {
 	}
 
 	opts := &lunchmoney.BudgetFilters{
-		StartDate: "2021-01-01",
-		EndDate:   "2021-12-31",
+		StartDate: lunchmoney.NewDate(2021, time.January, 1),
+		EndDate:   lunchmoney.NewDate(2021, time.December, 31),
 	}
 
 	ts, err := client.GetBudgets(ctx, opts)
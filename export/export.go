@@ -0,0 +1,183 @@
+// Package export writes Lunch Money transactions in formats spreadsheets and
+// plain-text accounting tools understand.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/refdata"
+)
+
+// Column is a column of the exported CSV. Its value is used as the header.
+type Column string
+
+// Columns that can be exported.
+const (
+	ColumnID         Column = "id"
+	ColumnDate       Column = "date"
+	ColumnPayee      Column = "payee"
+	ColumnAmount     Column = "amount"
+	ColumnCurrency   Column = "currency"
+	ColumnToBase     Column = "to_base"
+	ColumnCategory   Column = "category"
+	ColumnTags       Column = "tags"
+	ColumnAccount    Column = "account"
+	ColumnNotes      Column = "notes"
+	ColumnStatus     Column = "status"
+	ColumnExternalID Column = "external_id"
+)
+
+// DefaultColumns are exported when Options.Columns is empty.
+var DefaultColumns = []Column{
+	ColumnDate, ColumnPayee, ColumnAmount, ColumnCurrency, ColumnCategory, ColumnTags, ColumnAccount, ColumnNotes,
+}
+
+// Options configure WriteCSV. The zero value is usable.
+type Options struct {
+	// Columns to write, in order. Defaults to DefaultColumns.
+	Columns []Column
+	// Names resolves category and account names. Without it those columns
+	// are empty and tags use the names embedded in the transaction.
+	Names *refdata.Cache
+	// DateFormat is a time layout for the date column. Defaults to
+	// time.DateOnly.
+	DateFormat string
+	// Places, if set, rounds amounts to this many decimal places. By default
+	// amounts keep the precision returned by the API.
+	Places *int32
+	// DebitAsNegative writes debits as negative amounts and credits as
+	// positive ones, the convention most spreadsheets expect. Lunch Money
+	// itself reports debits as positive.
+	DebitAsNegative bool
+	// TagSeparator joins tag names. Defaults to ", ".
+	TagSeparator string
+	// NoHeader omits the header row.
+	NoHeader bool
+}
+
+// WriteCSV writes txns to w as CSV, one row per transaction. Text cells that
+// start like a spreadsheet formula are prefixed with a quote.
+func WriteCSV(w io.Writer, txns []*lunchmoney.Transaction, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	cols := opts.Columns
+	if len(cols) == 0 {
+		cols = DefaultColumns
+	}
+	for _, c := range cols {
+		if !c.valid() {
+			return fmt.Errorf("unknown column %q", c)
+		}
+	}
+
+	names := opts.Names
+	if names == nil {
+		names = refdata.New(nil)
+	}
+
+	cw := csv.NewWriter(w)
+	if !opts.NoHeader {
+		header := make([]string, len(cols))
+		for i, c := range cols {
+			header[i] = string(c)
+		}
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range names.Decorate(txns) {
+		rec := make([]string, len(cols))
+		for i, c := range cols {
+			rec[i] = opts.value(d, c)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c Column) valid() bool {
+	switch c {
+	case ColumnID, ColumnDate, ColumnPayee, ColumnAmount, ColumnCurrency, ColumnToBase, ColumnCategory,
+		ColumnTags, ColumnAccount, ColumnNotes, ColumnStatus, ColumnExternalID:
+		return true
+	default:
+		return false
+	}
+}
+
+func (o *Options) value(d *refdata.Decorated, c Column) string {
+	switch c {
+	case ColumnID:
+		return strconv.FormatInt(int64(d.ID), 10)
+	case ColumnDate:
+		if d.Date.IsZero() {
+			return ""
+		}
+		layout := o.DateFormat
+		if layout == "" {
+			layout = time.DateOnly
+		}
+		return d.Date.Time().Format(layout)
+	case ColumnPayee:
+		return text(d.Payee)
+	case ColumnAmount:
+		return o.amount(d.Amount)
+	case ColumnCurrency:
+		return strings.ToUpper(d.Currency)
+	case ColumnToBase:
+		return o.amount(d.ToBase)
+	case ColumnCategory:
+		return text(d.CategoryName)
+	case ColumnTags:
+		sep := o.TagSeparator
+		if sep == "" {
+			sep = ", "
+		}
+		return text(strings.Join(d.TagNames, sep))
+	case ColumnAccount:
+		return text(d.AccountName)
+	case ColumnNotes:
+		return text(d.Notes)
+	case ColumnStatus:
+		return d.Status
+	case ColumnExternalID:
+		return text(d.ExternalID)
+	default:
+		return ""
+	}
+}
+
+// text returns a free-text cell, such as a payee or note, prefixed with a
+// quote if it starts like a formula. Spreadsheets would otherwise evaluate a
+// payee such as "=HYPERLINK(...)" when the file is opened.
+func text(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func (o *Options) amount(v lunchmoney.Decimal) string {
+	if o.DebitAsNegative {
+		v = v.Neg()
+	}
+	if o.Places != nil {
+		v = v.Round(*o.Places)
+	}
+
+	return v.String()
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/refdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type names struct{}
+
+func (names) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return []*lunchmoney.Category{{ID: 1, Name: "Dining"}}, nil
+}
+
+func (names) GetTags(context.Context) ([]*lunchmoney.Tag, error) {
+	return []*lunchmoney.Tag{{ID: 7, Name: "work"}, {ID: 8, Name: "travel"}}, nil
+}
+
+func (names) GetAssets(context.Context) ([]*lunchmoney.Asset, error) {
+	return []*lunchmoney.Asset{{ID: 3, Name: "Wallet"}}, nil
+}
+
+func (names) GetPlaidAccounts(context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	return nil, nil
+}
+
+func testTransactions() []*lunchmoney.Transaction {
+	cat := lunchmoney.CategoryID(1)
+	asset := lunchmoney.AssetID(3)
+	return []*lunchmoney.Transaction{
+		{
+			ID:         1,
+			Date:       lunchmoney.NewDate(2024, 5, 3),
+			Payee:      "Cafe, Inc",
+			Amount:     lunchmoney.MustParseDecimal("12.345"),
+			Currency:   "usd",
+			CategoryID: &cat,
+			AssetID:    &asset,
+			Tags:       []*lunchmoney.Tag{{ID: 7}, {ID: 8}},
+		},
+		{ID: 2, Date: lunchmoney.NewDate(2024, 5, 4), Payee: "Refund", Amount: lunchmoney.MustParseDecimal("-5.00"), Currency: "usd"},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	cache := refdata.New(names{})
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	places := int32(2)
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, testTransactions(), &Options{
+		Names:           cache,
+		DateFormat:      "01/02/2006",
+		Places:          &places,
+		DebitAsNegative: true,
+		TagSeparator:    ";",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "date,payee,amount,currency,category,tags,account,notes\n"+
+		"05/03/2024,\"Cafe, Inc\",-12.35,USD,Dining,work;travel,Wallet,\n"+
+		"05/04/2024,Refund,5.00,USD,,,,\n", buf.String())
+}
+
+func TestWriteCSVColumns(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, testTransactions(), &Options{Columns: []Column{ColumnID, ColumnAmount}, NoHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, "1,12.345\n2,-5.00\n", buf.String())
+
+	err = WriteCSV(&buf, nil, &Options{Columns: []Column{"bogus"}})
+	assert.Error(t, err)
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	txns := []*lunchmoney.Transaction{
+		{ID: 1, Payee: `=HYPERLINK("http://evil.example","x")`, Notes: "+1 for lunch", Amount: lunchmoney.MustParseDecimal("-3")},
+		{ID: 2, Payee: "@SUM(A1)", Notes: "-split", ExternalID: "Cafe"},
+	}
+
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, txns, &Options{Columns: []Column{ColumnPayee, ColumnNotes, ColumnAmount, ColumnExternalID}, NoHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, "\"'=HYPERLINK(\"\"http://evil.example\"\",\"\"x\"\")\",'+1 for lunch,-3,\n"+
+		"'@SUM(A1),'-split,0,Cafe\n", buf.String())
+}
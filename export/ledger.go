@@ -0,0 +1,216 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/refdata"
+)
+
+// LedgerOptions configure WriteBeancount and WriteLedger. The zero value is
+// usable.
+type LedgerOptions struct {
+	// Names resolves categories and accounts. Without it every transaction
+	// is booked against the Uncategorized and Unknown accounts.
+	Names *refdata.Cache
+	// OpenAccounts, for beancount, writes an open directive for every
+	// account used, dated on the first transaction. Beancount rejects
+	// postings to accounts that were never opened.
+	OpenAccounts bool
+}
+
+// WriteBeancount writes txns as beancount entries. Categories become
+// Expenses or Income accounts, assets and Plaid accounts become Assets or
+// Liabilities accounts, and tags become beancount tags.
+func WriteBeancount(w io.Writer, txns []*lunchmoney.Transaction, opts *LedgerOptions) error {
+	if opts == nil {
+		opts = &LedgerOptions{}
+	}
+	entries := ledgerEntries(txns, opts.Names)
+
+	bw := bufio.NewWriter(w)
+	if opts.OpenAccounts && len(entries) > 0 {
+		first := entries[0].date
+		for _, e := range entries {
+			if e.date.Before(first) {
+				first = e.date
+			}
+		}
+		for _, a := range usedAccounts(entries) {
+			fmt.Fprintf(bw, "%s open %s\n", first, a)
+		}
+		fmt.Fprintln(bw)
+	}
+
+	for _, e := range entries {
+		flag := "!"
+		if e.cleared {
+			flag = "*"
+		}
+		fmt.Fprintf(bw, "%s %s %s %s", e.date, flag, strconv.Quote(e.payee), strconv.Quote(e.notes))
+		for _, t := range e.tags {
+			fmt.Fprintf(bw, " #%s", t)
+		}
+		fmt.Fprintln(bw)
+		fmt.Fprintf(bw, "  %s  %s %s\n", e.category, e.amount, e.currency)
+		fmt.Fprintf(bw, "  %s\n\n", e.account)
+	}
+
+	return bw.Flush()
+}
+
+// WriteLedger writes txns as ledger-cli entries, with the same accounts as
+// WriteBeancount. Notes and tags are written as comments.
+func WriteLedger(w io.Writer, txns []*lunchmoney.Transaction, opts *LedgerOptions) error {
+	if opts == nil {
+		opts = &LedgerOptions{}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, e := range ledgerEntries(txns, opts.Names) {
+		flag := "!"
+		if e.cleared {
+			flag = "*"
+		}
+		fmt.Fprintf(bw, "%s %s %s\n", strings.ReplaceAll(e.date.String(), "-", "/"), flag, e.payee)
+		if e.notes != "" {
+			fmt.Fprintf(bw, "    ; %s\n", e.notes)
+		}
+		if len(e.tags) > 0 {
+			fmt.Fprintf(bw, "    ; :%s:\n", strings.Join(e.tags, ":"))
+		}
+		fmt.Fprintf(bw, "    %s  %s %s\n", e.category, e.amount, e.currency)
+		fmt.Fprintf(bw, "    %s\n\n", e.account)
+	}
+
+	return bw.Flush()
+}
+
+type ledgerEntry struct {
+	date     lunchmoney.Date
+	cleared  bool
+	payee    string
+	notes    string
+	tags     []string
+	category string
+	account  string
+	amount   string
+	currency string
+}
+
+func ledgerEntries(txns []*lunchmoney.Transaction, names *refdata.Cache) []ledgerEntry {
+	if names == nil {
+		names = refdata.New(nil)
+	}
+
+	ret := make([]ledgerEntry, 0, len(txns))
+	for _, d := range names.Decorate(txns) {
+		e := ledgerEntry{
+			date:     d.Date,
+			cleared:  d.Status == "cleared",
+			payee:    oneLine(d.Payee),
+			notes:    oneLine(d.Notes),
+			category: categoryAccount(d, names),
+			account:  fundingAccount(d, names),
+			amount:   d.Amount.String(),
+			currency: strings.ToUpper(d.Currency),
+		}
+		for _, t := range d.TagNames {
+			if t = accountPart(t); t != "" {
+				e.tags = append(e.tags, t)
+			}
+		}
+		ret = append(ret, e)
+	}
+
+	return ret
+}
+
+func categoryAccount(d *refdata.Decorated, names *refdata.Cache) string {
+	if d.CategoryID == nil {
+		return "Expenses:Uncategorized"
+	}
+	c, ok := names.Category(*d.CategoryID)
+	if !ok {
+		return "Expenses:Uncategorized"
+	}
+
+	root := "Expenses"
+	if c.IsIncome {
+		root = "Income"
+	}
+
+	return accountName(root, c.GroupCategoryName, c.Name)
+}
+
+func fundingAccount(d *refdata.Decorated, names *refdata.Cache) string {
+	if d.AssetID != nil {
+		if a, ok := names.Asset(*d.AssetID); ok {
+			root := "Assets"
+			if a.TypeName.IsLiability() {
+				root = "Liabilities"
+			}
+			return accountName(root, d.AccountName)
+		}
+	}
+	if d.PlaidAccountID != nil {
+		if a, ok := names.PlaidAccount(*d.PlaidAccountID); ok {
+			root := "Assets"
+			if lunchmoney.AssetType(a.Type).IsLiability() {
+				root = "Liabilities"
+			}
+			return accountName(root, d.AccountName)
+		}
+	}
+
+	return "Assets:Unknown"
+}
+
+// accountName joins the non-empty parts into an account name that both
+// beancount and ledger accept.
+func accountName(root string, parts ...string) string {
+	name := root
+	for _, p := range parts {
+		if p = accountPart(p); p != "" {
+			name += ":" + p
+		}
+	}
+
+	return name
+}
+
+// accountPart turns a display name such as "dining out" into an account
+// component such as "Dining-Out": words are capitalized and joined with
+// dashes, and anything but letters and digits is dropped.
+func accountPart(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+
+	return strings.Join(words, "-")
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func usedAccounts(entries []ledgerEntry) []string {
+	var ret []string
+	for _, e := range entries {
+		ret = append(ret, e.category, e.account)
+	}
+	slices.Sort(ret)
+
+	return slices.Compact(ret)
+}
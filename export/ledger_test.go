@@ -0,0 +1,62 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/refdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ledgerTransactions() []*lunchmoney.Transaction {
+	txns := testTransactions()
+	txns[0].Status = "cleared"
+	txns[0].Notes = "team\nlunch"
+	return txns
+}
+
+func TestWriteBeancount(t *testing.T) {
+	cache := refdata.New(names{})
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBeancount(&buf, ledgerTransactions(), &LedgerOptions{Names: cache, OpenAccounts: true}))
+	assert.Equal(t, `2024-05-03 open Assets:Unknown
+2024-05-03 open Assets:Wallet
+2024-05-03 open Expenses:Dining
+2024-05-03 open Expenses:Uncategorized
+
+2024-05-03 * "Cafe, Inc" "team lunch" #Work #Travel
+  Expenses:Dining  12.345 USD
+  Assets:Wallet
+
+2024-05-04 ! "Refund" ""
+  Expenses:Uncategorized  -5.00 USD
+  Assets:Unknown
+
+`, buf.String())
+}
+
+func TestWriteLedger(t *testing.T) {
+	cache := refdata.New(names{})
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteLedger(&buf, ledgerTransactions()[:1], &LedgerOptions{Names: cache}))
+	assert.Equal(t, `2024/05/03 * Cafe, Inc
+    ; team lunch
+    ; :Work:Travel:
+    Expenses:Dining  12.345 USD
+    Assets:Wallet
+
+`, buf.String())
+}
+
+func TestAccountPart(t *testing.T) {
+	assert.Equal(t, "Dining-Out", accountPart("dining out"))
+	assert.Equal(t, "Cafe-Bar", accountPart("Cafe & Bar"))
+	assert.Equal(t, "", accountPart("  "))
+}
@@ -0,0 +1,213 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// jsonFieldCache maps struct types to the indexes of their fields by JSON
+// name.
+var jsonFieldCache sync.Map
+
+// jsonFields returns the indexes of the exported fields of the struct type t
+// by their JSON names.
+func jsonFields(t reflect.Type) map[string]int {
+	if known, ok := jsonFieldCache.Load(t); ok {
+		return known.(map[string]int)
+	}
+
+	known := map[string]int{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		known[name] = i
+	}
+
+	jsonFieldCache.Store(t, known)
+	return known
+}
+
+// field returns the index of the field of known that the JSON member name
+// decodes into, matching case-insensitively like encoding/json does.
+func field(known map[string]int, name string) (int, bool) {
+	if i, ok := known[name]; ok {
+		return i, true
+	}
+	for k, i := range known {
+		if strings.EqualFold(k, name) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// decodeExtra decodes the JSON object data into v, a pointer to a struct
+// without an UnmarshalJSON method, and returns the members v has no field
+// for, or nil if there are none. The object is read once: each known member
+// is decoded straight into its field.
+func decodeExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		// null leaves v unchanged, like json.Unmarshal.
+		return nil, nil
+	}
+	if tok != json.Delim('{') {
+		return nil, &json.UnmarshalTypeError{Value: fmt.Sprint(tok), Type: reflect.TypeOf(v).Elem()}
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	known := jsonFields(rv.Type())
+	var extra map[string]json.RawMessage
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		k := tok.(string)
+
+		i, ok := field(known, k)
+		if !ok {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			if extra == nil {
+				extra = map[string]json.RawMessage{}
+			}
+			extra[k] = raw
+			continue
+		}
+
+		if err := dec.Decode(rv.Field(i).Addr().Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field == "" {
+				typeErr.Struct = rv.Type().Name()
+				typeErr.Field = k
+			}
+			return nil, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return extra, nil
+}
+
+// ErrUnknownField is returned under WithStrictDecoding when a response has
+// fields the client does not know about.
+var ErrUnknownField = errors.New("unknown field in response")
+
+// checkExtra returns an error naming the unknown fields kept in the Extra
+// maps of v and the values it refers to.
+func checkExtra(v any) error {
+	var unknown []string
+	var walk func(rv reflect.Value, path string)
+	walk = func(rv reflect.Value, path string) {
+		switch rv.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if !rv.IsNil() {
+				walk(rv.Elem(), path)
+			}
+		case reflect.Slice, reflect.Array:
+			for i := range rv.Len() {
+				walk(rv.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			}
+		case reflect.Map:
+			if rv.Type().Elem() == rawMessageType {
+				return
+			}
+			for _, k := range rv.MapKeys() {
+				walk(rv.MapIndex(k), fmt.Sprintf("%s[%v]", path, k))
+			}
+		case reflect.Struct:
+			for i := range rv.NumField() {
+				f := rv.Type().Field(i)
+				if !f.IsExported() {
+					continue
+				}
+				if f.Name == "Extra" && f.Type.Kind() == reflect.Map {
+					for _, k := range slices.Sorted(maps.Keys(rv.Field(i).Interface().(map[string]json.RawMessage))) {
+						unknown = append(unknown, strings.TrimPrefix(path+"."+k, "."))
+					}
+					continue
+				}
+				if f.Anonymous {
+					walk(rv.Field(i), path)
+					continue
+				}
+				name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+				if name == "" || name == "-" {
+					name = f.Name
+				}
+				walk(rv.Field(i), path+"."+name)
+			}
+		}
+	}
+	walk(reflect.ValueOf(v), "")
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%s: %w", strings.Join(unknown, ", "), ErrUnknownField)
+	}
+
+	return nil
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// encodeExtra encodes v, a struct without a MarshalJSON method, together with
+// the extra members, which are appended to the encoded object in key order.
+// Fields of v win over extra members of the same name.
+func encodeExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v))
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	empty := len(b) == 2
+	for _, k := range slices.Sorted(maps.Keys(extra)) {
+		if _, ok := field(known, k); ok {
+			continue
+		}
+
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		if err := json.Compact(buf, extra[k]); err != nil {
+			return nil, fmt.Errorf("extra member %s: %w", k, err)
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtraFields(t *testing.T) {
+	var c Category
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 3, "name": "Rent", "is_income": false, "emoji": "🏠", "rollover": {"enabled": true}}`), &c))
+	assert.Equal(t, "Rent", c.Name)
+	require.Len(t, c.Extra, 2)
+	assert.JSONEq(t, `{"enabled": true}`, string(c.Extra["rollover"]))
+
+	out, err := json.Marshal(c)
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, "🏠", got["emoji"])
+	assert.Equal(t, "Rent", got["name"])
+
+	var tag Tag
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 1, "name": "travel"}`), &tag))
+	assert.Nil(t, tag.Extra)
+
+	var txn Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 5, "amount": "1.00", "original_name": "SHOP"}`), &txn))
+	assert.JSONEq(t, `"SHOP"`, string(txn.Extra["original_name"]))
+	delete(txn.Extra, "original_name")
+	txn.Extra["source"] = json.RawMessage(`"csv"`)
+	out, err = json.Marshal(txn)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"source": "csv"}`, string(mustPick(t, out, "source", "original_name")))
+}
+
+// mustPick returns the JSON object of the given members of data that exist.
+func mustPick(t *testing.T, data []byte, keys ...string) []byte {
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &m))
+	ret := map[string]json.RawMessage{}
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			ret[k] = v
+		}
+	}
+	b, err := json.Marshal(ret)
+	require.NoError(t, err)
+	return b
+}
+
+func TestStrictDecoding(t *testing.T) {
+	body := `{"categories": [{"id": 1, "name": "Rent"}, {"id": 2, "name": "Food", "emoji": "🍎"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	cats, err := client.GetCategories(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, cats, 2)
+
+	strict, err := client.With(WithStrictDecoding(true))
+	require.NoError(t, err)
+	_, err = strict.GetCategories(context.Background())
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.ErrorContains(t, err, "categories[1].emoji")
+
+	body = `{"categories": [], "has_more": false}`
+	_, err = strict.GetCategories(context.Background())
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.ErrorContains(t, err, "has_more")
+
+	body = `{"categories": [{"id": 1, "name": "Rent"}]}`
+	_, err = strict.GetCategories(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,156 @@
+package lunchmoney
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Predicate reports whether a transaction matches. Predicates compose with
+// And, Or and Not and are applied to fetched transactions with Filter:
+//
+//	gifts := Filter(txns, And(InCategory(shopping), AmountGreaterThan(MustParseDecimal("50")), NotesContain("gift")))
+type Predicate func(t *Transaction) bool
+
+// Filter returns the transactions of txns matching p, in order.
+func Filter(txns []*Transaction, p Predicate) []*Transaction {
+	var ret []*Transaction
+	for _, t := range txns {
+		if p(t) {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret
+}
+
+// And matches transactions matching all of ps. It matches everything when
+// ps is empty.
+func And(ps ...Predicate) Predicate {
+	return func(t *Transaction) bool {
+		for _, p := range ps {
+			if !p(t) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches transactions matching any of ps. It matches nothing when ps is
+// empty.
+func Or(ps ...Predicate) Predicate {
+	return func(t *Transaction) bool {
+		for _, p := range ps {
+			if p(t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches transactions p does not match.
+func Not(p Predicate) Predicate {
+	return func(t *Transaction) bool {
+		return !p(t)
+	}
+}
+
+// InCategory matches transactions in any of the given categories.
+func InCategory(ids ...CategoryID) Predicate {
+	return func(t *Transaction) bool {
+		for _, id := range ids {
+			if t.CategoryID != nil && *t.CategoryID == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Uncategorized matches transactions without a category.
+func Uncategorized(t *Transaction) bool {
+	return !t.HasCategory()
+}
+
+// Tagged matches transactions with the given tag.
+func Tagged(id TagID) Predicate {
+	return func(t *Transaction) bool {
+		return t.HasTag(id)
+	}
+}
+
+// InAsset matches transactions of the manually-managed asset.
+func InAsset(id AssetID) Predicate {
+	return func(t *Transaction) bool {
+		return t.AssetID != nil && *t.AssetID == id
+	}
+}
+
+// InPlaidAccount matches transactions of the Plaid account.
+func InPlaidAccount(id PlaidAccountID) Predicate {
+	return func(t *Transaction) bool {
+		return t.PlaidAccountID != nil && *t.PlaidAccountID == id
+	}
+}
+
+// WithStatus matches transactions with the given status, such as "cleared".
+func WithStatus(status string) Predicate {
+	return func(t *Transaction) bool {
+		return t.Status == status
+	}
+}
+
+// AmountGreaterThan matches transactions whose amount is greater than m.
+// Debits are positive unless the transactions were fetched with
+// debit_as_negative.
+func AmountGreaterThan(m Decimal) Predicate {
+	return func(t *Transaction) bool {
+		return t.Amount.Cmp(m) > 0
+	}
+}
+
+// AmountLessThan matches transactions whose amount is less than m.
+func AmountLessThan(m Decimal) Predicate {
+	return func(t *Transaction) bool {
+		return t.Amount.Cmp(m) < 0
+	}
+}
+
+// AmountBetween matches transactions whose amount is from lo to hi,
+// inclusive.
+func AmountBetween(lo, hi Decimal) Predicate {
+	return func(t *Transaction) bool {
+		return t.Amount.Cmp(lo) >= 0 && t.Amount.Cmp(hi) <= 0
+	}
+}
+
+// DatedBetween matches transactions dated from start to end, inclusive.
+func DatedBetween(start, end Date) Predicate {
+	return func(t *Transaction) bool {
+		return !t.Date.Before(start) && !t.Date.After(end)
+	}
+}
+
+// PayeeContains matches transactions whose payee contains s, ignoring case.
+func PayeeContains(s string) Predicate {
+	s = strings.ToLower(s)
+	return func(t *Transaction) bool {
+		return strings.Contains(strings.ToLower(t.Payee), s)
+	}
+}
+
+// PayeeMatches matches transactions whose payee matches re.
+func PayeeMatches(re *regexp.Regexp) Predicate {
+	return func(t *Transaction) bool {
+		return re.MatchString(t.Payee)
+	}
+}
+
+// NotesContain matches transactions whose notes contain s, ignoring case.
+func NotesContain(s string) Predicate {
+	s = strings.ToLower(s)
+	return func(t *Transaction) bool {
+		return strings.Contains(strings.ToLower(t.Notes), s)
+	}
+}
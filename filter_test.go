@@ -0,0 +1,48 @@
+package lunchmoney
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	shopping, food := CategoryID(1), CategoryID(2)
+	txns := []*Transaction{
+		{ID: 1, Payee: "Bookshop", Amount: MustParseDecimal("75"), CategoryID: &shopping, Notes: "Birthday GIFT for Sam", Date: MustParseDate("2024-05-01")},
+		{ID: 2, Payee: "Bookshop", Amount: MustParseDecimal("20"), CategoryID: &shopping, Notes: "gift", Date: MustParseDate("2024-05-02")},
+		{ID: 3, Payee: "Grocer", Amount: MustParseDecimal("80"), CategoryID: &food, Status: "cleared", Date: MustParseDate("2024-05-03"), Tags: []*Tag{{ID: 7}}},
+		{ID: 4, Payee: "ATM", Amount: MustParseDecimal("-60"), Date: MustParseDate("2024-06-01")},
+	}
+	ids := func(txns []*Transaction) []TransactionID {
+		var ret []TransactionID
+		for _, t := range txns {
+			ret = append(ret, t.ID)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name string
+		p    Predicate
+		want []TransactionID
+	}{
+		{"and", And(InCategory(shopping), AmountGreaterThan(MustParseDecimal("50")), NotesContain("gift")), []TransactionID{1}},
+		{"empty and", And(), []TransactionID{1, 2, 3, 4}},
+		{"or", Or(Uncategorized, Tagged(7)), []TransactionID{3, 4}},
+		{"empty or", Or(), nil},
+		{"not", Not(InCategory(shopping, food)), []TransactionID{4}},
+		{"amount range", AmountBetween(MustParseDecimal("20"), MustParseDecimal("75")), []TransactionID{1, 2}},
+		{"less than", AmountLessThan(MustParseDecimal("0")), []TransactionID{4}},
+		{"dates", DatedBetween(MustParseDate("2024-05-02"), MustParseDate("2024-05-31")), []TransactionID{2, 3}},
+		{"payee", PayeeContains("book"), []TransactionID{1, 2}},
+		{"payee regexp", PayeeMatches(regexp.MustCompile(`^(ATM|Grocer)$`)), []TransactionID{3, 4}},
+		{"status", WithStatus("cleared"), []TransactionID{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(Filter(txns, tt.p)))
+		})
+	}
+}
@@ -0,0 +1,370 @@
+// Package gateway serves a read-only subset of the Lunch Money API through a
+// single client, with caching, request coalescing and rate-limit smoothing,
+// so several home-built dashboards can share one upstream quota.
+//
+// The gateway authenticates upstream with its client's token and does not
+// check who calls it, so it must only be reachable by trusted dashboards.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Getter is the subset of *lunchmoney.Client the gateway needs.
+type Getter interface {
+	GetValues(ctx context.Context, path string, query url.Values) (io.Reader, error)
+}
+
+// Pinger is implemented by getters that can check the API is reachable and
+// their token valid, such as *lunchmoney.Client.
+type Pinger interface {
+	Ping(ctx context.Context) (*lunchmoney.PingResult, error)
+}
+
+// HealthPath is the readiness probe. It answers 200 once the getter's Ping
+// succeeds, and 503 with the error otherwise. A successful ping is reused for
+// Options.TTL so frequent probes do not use up the upstream quota. Getters
+// that are not Pingers are always ready.
+const HealthPath = "/healthz"
+
+// DefaultPaths are the API paths proxied when Options.Paths is empty.
+var DefaultPaths = []string{
+	"/v1/me",
+	"/v1/categories",
+	"/v1/tags",
+	"/v1/transactions",
+	"/v1/budgets",
+	"/v1/assets",
+	"/v1/plaid_accounts",
+	"/v1/crypto",
+	"/v1/recurring_items",
+}
+
+// Options configure a Gateway.
+type Options struct {
+	// Paths are the API paths that are proxied. A path also covers the
+	// paths below it, so "/v1/transactions" covers "/v1/transactions/42".
+	// Defaults to DefaultPaths.
+	Paths []string
+	// TTL is how long responses are cached. Defaults to one minute.
+	TTL time.Duration
+	// TTLs override TTL for some paths, matched like Paths; the longest
+	// match wins. Slowly changing data such as categories can be cached for
+	// longer than transactions.
+	TTLs map[string]time.Duration
+	// MaxEntries bounds the cache. Defaults to 1000.
+	MaxEntries int
+	// StaleIfError serves an expired response when the upstream request
+	// fails, for at most this long after it expired.
+	StaleIfError time.Duration
+	// Limiter, if set, throttles upstream requests, so a burst of cache
+	// misses is spread out instead of exhausting the quota. Upstream
+	// requests use lunchmoney.PriorityInteractive.
+	Limiter *lunchmoney.RateLimiter
+}
+
+// Stats counts how requests were served.
+type Stats struct {
+	Hits      int
+	Misses    int
+	Coalesced int
+	Stale     int
+	Errors    int
+}
+
+type entry struct {
+	body    []byte
+	expires time.Time
+}
+
+// call is an upstream request shared by every request for the same key that
+// arrives while it is in flight.
+type call struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// Gateway is an http.Handler proxying GET requests to the API. Responses
+// carry an X-Cache header of HIT, MISS, COALESCED or STALE.
+type Gateway struct {
+	getter Getter
+	opts   Options
+	now    func() time.Time
+
+	mu       sync.Mutex
+	cache    map[string]*entry
+	inflight map[string]*call
+	stats    Stats
+	// healthy is when the last successful ping stops counting.
+	healthy time.Time
+}
+
+// New creates a gateway reading through g.
+func New(g Getter, opts Options) *Gateway {
+	if len(opts.Paths) == 0 {
+		opts.Paths = DefaultPaths
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = time.Minute
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 1000
+	}
+
+	return &Gateway{
+		getter:   g,
+		opts:     opts,
+		now:      time.Now,
+		cache:    map[string]*entry{},
+		inflight: map[string]*call{},
+	}
+}
+
+// Stats returns how requests were served so far.
+func (g *Gateway) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.stats
+}
+
+// Purge empties the cache, for example after the dashboards' data was
+// changed through another client.
+func (g *Gateway) Purge() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	clear(g.cache)
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "only GET requests are proxied")
+		return
+	}
+	if r.URL.Path == HealthPath {
+		g.health(w, r)
+		return
+	}
+	// A ".." segment could climb out of a proxied path once the API
+	// resolves it, so such paths are refused before the allow-list check.
+	if slices.Contains(strings.Split(r.URL.Path, "/"), "..") {
+		writeError(w, http.StatusBadRequest, "path must not contain .. segments")
+		return
+	}
+	if !slices.ContainsFunc(g.opts.Paths, func(p string) bool { return covers(p, r.URL.Path) }) {
+		writeError(w, http.StatusNotFound, "path is not proxied")
+		return
+	}
+
+	// Every value is forwarded, in order. Encode sorts by key, so
+	// equivalent queries share a cache entry.
+	query := r.URL.Query()
+	key := r.URL.Path + "?" + query.Encode()
+
+	body, source, err := g.fetch(r.Context(), key, r.URL.Path, query)
+	if err != nil {
+		status := http.StatusBadGateway
+		if apiErr := (&lunchmoney.APIError{}); errors.As(err, &apiErr) && apiErr.StatusCode >= 400 {
+			status = apiErr.StatusCode
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", source)
+	_, _ = w.Write(body)
+}
+
+func (g *Gateway) health(w http.ResponseWriter, r *http.Request) {
+	if err := g.ping(r.Context()); err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (g *Gateway) ping(ctx context.Context) error {
+	p, ok := g.getter.(Pinger)
+	if !ok {
+		return nil
+	}
+
+	g.mu.Lock()
+	fresh := g.now().Before(g.healthy)
+	g.mu.Unlock()
+	if fresh {
+		return nil
+	}
+
+	ctx = lunchmoney.WithOperation(ctx, "gateway")
+	if g.opts.Limiter != nil {
+		if err := g.opts.Limiter.Wait(ctx, lunchmoney.PriorityInteractive); err != nil {
+			return err
+		}
+	}
+	if _, err := p.Ping(ctx); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	g.healthy = g.now().Add(g.opts.TTL)
+	g.mu.Unlock()
+
+	return nil
+}
+
+func (g *Gateway) fetch(ctx context.Context, key, path string, query url.Values) ([]byte, string, error) {
+	g.mu.Lock()
+	now := g.now()
+	cached := g.cache[key]
+	if cached != nil && now.Before(cached.expires) {
+		g.stats.Hits++
+		g.mu.Unlock()
+		return cached.body, "HIT", nil
+	}
+	if c, ok := g.inflight[key]; ok {
+		g.stats.Coalesced++
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+		return g.result(c, key, "COALESCED")
+	}
+	c := &call{done: make(chan struct{})}
+	g.inflight[key] = c
+	g.stats.Misses++
+	g.mu.Unlock()
+
+	// The upstream request is shared, so it must not be cancelled when the
+	// request that started it goes away.
+	go g.do(context.WithoutCancel(ctx), c, key, path, query)
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+	return g.result(c, key, "MISS")
+}
+
+func (g *Gateway) do(ctx context.Context, c *call, key, path string, query url.Values) {
+	defer close(c.done)
+
+	ctx = lunchmoney.WithOperation(ctx, "gateway")
+	if g.opts.Limiter != nil {
+		if c.err = g.opts.Limiter.Wait(ctx, lunchmoney.PriorityInteractive); c.err != nil {
+			g.finish(key, c)
+			return
+		}
+	}
+
+	var r io.Reader
+	r, c.err = g.getter.GetValues(ctx, path, query)
+	if c.err == nil {
+		var buf bytes.Buffer
+		_, c.err = io.Copy(&buf, r)
+		c.body = buf.Bytes()
+	}
+	g.finish(key, c)
+}
+
+// finish records the outcome of an upstream request.
+func (g *Gateway) finish(key string, c *call) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.inflight, key)
+	if c.err != nil {
+		return
+	}
+
+	g.cache[key] = &entry{body: c.body, expires: g.now().Add(g.ttl(key))}
+	g.evict()
+}
+
+// result returns the outcome of c, falling back to a stale cache entry if
+// the upstream request failed.
+func (g *Gateway) result(c *call, key, source string) ([]byte, string, error) {
+	if c.err == nil {
+		return c.body, source, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if e := g.cache[key]; e != nil && g.now().Before(e.expires.Add(g.opts.StaleIfError)) {
+		g.stats.Stale++
+		return e.body, "STALE", nil
+	}
+	g.stats.Errors++
+
+	return nil, "", c.err
+}
+
+func (g *Gateway) ttl(key string) time.Duration {
+	path, _, _ := strings.Cut(key, "?")
+	ttl, best := g.opts.TTL, -1
+	for p, d := range g.opts.TTLs {
+		if covers(p, path) && len(p) > best {
+			ttl, best = d, len(p)
+		}
+	}
+
+	return ttl
+}
+
+// evict drops entries past their stale window and then, if the cache is
+// still too big, the entries closest to expiring.
+func (g *Gateway) evict() {
+	if len(g.cache) <= g.opts.MaxEntries {
+		return
+	}
+
+	now := g.now()
+	for k, e := range g.cache {
+		if !now.Before(e.expires.Add(g.opts.StaleIfError)) {
+			delete(g.cache, k)
+		}
+	}
+	for len(g.cache) > g.opts.MaxEntries {
+		var oldest string
+		for k, e := range g.cache {
+			if oldest == "" || e.expires.Before(g.cache[oldest].expires) {
+				oldest = k
+			}
+		}
+		delete(g.cache, oldest)
+	}
+}
+
+// covers reports whether the proxied path p covers the request path.
+func covers(p, path string) bool {
+	p = strings.TrimSuffix(p, "/")
+	return path == p || strings.HasPrefix(path, p+"/")
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,187 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGetter struct {
+	mu    sync.Mutex
+	calls []string
+	ops   []string
+	gate  chan struct{}
+	fail  error
+}
+
+func (f *fakeGetter) GetValues(ctx context.Context, path string, query url.Values) (io.Reader, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, path+" "+strings.Join(query["start_date"], ","))
+	f.ops = append(f.ops, lunchmoney.OperationFromContext(ctx))
+	fail := f.fail
+	f.mu.Unlock()
+
+	if f.gate != nil {
+		<-f.gate
+	}
+	if fail != nil {
+		return nil, fail
+	}
+	return strings.NewReader(`{"path": "` + path + `"}`), nil
+}
+
+func get(g *Gateway, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestGatewayCache(t *testing.T) {
+	f := &fakeGetter{}
+	g := New(f, Options{TTL: time.Minute, TTLs: map[string]time.Duration{"/v1/categories": time.Hour}, StaleIfError: time.Hour})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	w := get(g, "/v1/transactions?start_date=2024-01-01&end_date=2024-01-31")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+	assert.JSONEq(t, `{"path": "/v1/transactions"}`, w.Body.String())
+
+	// Same query in a different order.
+	w = get(g, "/v1/transactions?end_date=2024-01-31&start_date=2024-01-01")
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	get(g, "/v1/categories")
+	assert.Equal(t, []string{"/v1/transactions 2024-01-01", "/v1/categories "}, f.calls)
+	assert.Equal(t, []string{"gateway", "gateway"}, f.ops)
+
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "HIT", get(g, "/v1/categories").Header().Get("X-Cache"))
+
+	f.fail = &lunchmoney.APIError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}
+	assert.Equal(t, "STALE", get(g, "/v1/transactions?start_date=2024-01-01&end_date=2024-01-31").Header().Get("X-Cache"))
+	w = get(g, "/v1/tags")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "429")
+
+	f.fail = errors.New("connection refused")
+	assert.Equal(t, http.StatusBadGateway, get(g, "/v1/tags").Code)
+
+	assert.Equal(t, Stats{Hits: 2, Misses: 5, Stale: 1, Errors: 2}, g.Stats())
+
+	g.Purge()
+	f.fail = nil
+	assert.Equal(t, "MISS", get(g, "/v1/categories").Header().Get("X-Cache"))
+}
+
+func TestGatewayForwardsRepeatedParams(t *testing.T) {
+	f := &fakeGetter{}
+	g := New(f, Options{})
+
+	get(g, "/v1/transactions?start_date=2024-01-01&start_date=2024-02-01")
+	get(g, "/v1/transactions?start_date=2024-01-01")
+	assert.Equal(t, []string{"/v1/transactions 2024-01-01,2024-02-01", "/v1/transactions 2024-01-01"}, f.calls)
+}
+
+func TestGatewayRejectsDotDot(t *testing.T) {
+	f := &fakeGetter{}
+	g := New(f, Options{Paths: []string{"/v1/transactions"}})
+
+	assert.Equal(t, http.StatusBadRequest, get(g, "/v1/transactions/../assets").Code)
+	assert.Equal(t, http.StatusBadRequest, get(g, "/v1/transactions/%2e%2e/me").Code)
+	assert.Equal(t, http.StatusBadRequest, get(g, "/v1/transactions/..").Code)
+	assert.Empty(t, f.calls)
+
+	assert.Equal(t, http.StatusOK, get(g, "/v1/transactions/..42").Code)
+}
+
+func TestGatewayRejects(t *testing.T) {
+	g := New(&fakeGetter{}, Options{Paths: []string{"/v1/transactions"}})
+
+	assert.Equal(t, http.StatusNotFound, get(g, "/v1/assets").Code)
+	assert.Equal(t, http.StatusNotFound, get(g, "/v1/transactionsx").Code)
+	assert.Equal(t, http.StatusOK, get(g, "/v1/transactions/42").Code)
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/transactions/42", strings.NewReader("{}")))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestGatewayCoalesces(t *testing.T) {
+	f := &fakeGetter{gate: make(chan struct{})}
+	g := New(f, Options{})
+
+	var wg sync.WaitGroup
+	results := make([]*httptest.ResponseRecorder, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = get(g, "/v1/me")
+		}()
+	}
+	for g.Stats().Misses+g.Stats().Coalesced < len(results) {
+		time.Sleep(time.Millisecond)
+	}
+	close(f.gate)
+	wg.Wait()
+
+	assert.Len(t, f.calls, 1)
+	for _, w := range results {
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	assert.Equal(t, Stats{Misses: 1, Coalesced: 4}, g.Stats())
+}
+
+func TestGatewayLimiter(t *testing.T) {
+	l, err := lunchmoney.NewRateLimiter(1000, 1)
+	require.NoError(t, err)
+	g := New(&fakeGetter{}, Options{Limiter: l})
+
+	assert.Equal(t, http.StatusOK, get(g, "/v1/me").Code)
+	assert.Equal(t, http.StatusOK, get(g, "/v1/tags").Code)
+}
+
+type fakePinger struct {
+	fakeGetter
+	pings int
+	err   error
+}
+
+func (f *fakePinger) Ping(context.Context) (*lunchmoney.PingResult, error) {
+	f.pings++
+	return &lunchmoney.PingResult{}, f.err
+}
+
+func TestGatewayHealth(t *testing.T) {
+	assert.Equal(t, http.StatusOK, get(New(&fakeGetter{}, Options{}), HealthPath).Code)
+
+	f := &fakePinger{err: lunchmoney.ErrInvalidToken}
+	g := New(f, Options{TTL: time.Minute})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	w := get(g, HealthPath)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "access token invalid")
+
+	f.err = nil
+	assert.Equal(t, http.StatusOK, get(g, HealthPath).Code)
+	assert.Equal(t, http.StatusOK, get(g, HealthPath).Code)
+	assert.Equal(t, 2, f.pings)
+
+	now = now.Add(2 * time.Minute)
+	get(g, HealthPath)
+	assert.Equal(t, 3, f.pings)
+	assert.Empty(t, f.calls)
+}
@@ -0,0 +1,22 @@
+package lunchmoney
+
+// Distinct ID types keep the IDs of different resources apart, so passing a
+// category ID where an asset ID is expected fails to compile. They encode as
+// plain JSON numbers; convert with e.g. CategoryID(n) or int64(id).
+type (
+	// TransactionID identifies a transaction, including transaction groups
+	// and the parents of split transactions.
+	TransactionID int64
+	// CategoryID identifies a category or category group.
+	CategoryID int64
+	// TagID identifies a tag.
+	TagID int64
+	// AssetID identifies a manually-managed asset.
+	AssetID int64
+	// PlaidAccountID identifies a Plaid-synced account.
+	PlaidAccountID int64
+	// RecurringID identifies a recurring item.
+	RecurringID int64
+	// CryptoID identifies a manually-managed crypto asset.
+	CryptoID int64
+)
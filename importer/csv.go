@@ -0,0 +1,269 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/payees"
+)
+
+// AmountSign is the sign convention of amounts in a bank export.
+type AmountSign int
+
+const (
+	// DebitsPositive means money spent is positive, as in Lunch Money.
+	DebitsPositive AmountSign = iota
+	// DebitsNegative means money spent is negative, as in most bank exports.
+	DebitsNegative
+)
+
+// CSVConfig maps the columns of a bank's CSV export onto transactions.
+// Columns are named by their header.
+type CSVConfig struct {
+	// DateColumn and PayeeColumn are required.
+	DateColumn  string
+	PayeeColumn string
+	// AmountColumn holds signed amounts. Banks that split money in and out
+	// into two unsigned columns set DebitColumn and CreditColumn instead.
+	AmountColumn string
+	DebitColumn  string
+	CreditColumn string
+	// NotesColumn and ExternalIDColumn are optional.
+	NotesColumn      string
+	ExternalIDColumn string
+
+	// DateFormat is the time layout of the date column. Defaults to
+	// time.DateOnly.
+	DateFormat string
+	// Sign is the convention of AmountColumn.
+	Sign AmountSign
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+	// DecimalSeparator separates the whole and fractional parts of amounts.
+	// Defaults to '.'; European exports such as "1.234,50" use ','.
+	DecimalSeparator rune
+	// ThousandsSeparator groups the digits of amounts and is ignored.
+	// Defaults to ',', or to '.' if DecimalSeparator is ','. Spaces are
+	// always ignored.
+	ThousandsSeparator rune
+	// Currency, AssetID and Status are set on every transaction.
+	Currency string
+	AssetID  *lunchmoney.AssetID
+	Status   string
+}
+
+func (cfg *CSVConfig) validate() error {
+	var errs []error
+	if cfg.DateColumn == "" {
+		errs = append(errs, errors.New("date column is required"))
+	}
+	if cfg.PayeeColumn == "" {
+		errs = append(errs, errors.New("payee column is required"))
+	}
+	if (cfg.AmountColumn == "") == (cfg.DebitColumn == "" && cfg.CreditColumn == "") {
+		errs = append(errs, errors.New("set either an amount column or debit/credit columns"))
+	}
+	if f := cfg.amountFormat(); f.decimal == f.thousands {
+		errs = append(errs, fmt.Errorf("decimal and thousands separators are both %q", f.decimal))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReadCSV reads a bank's CSV export and normalizes each row into a
+// transaction with Lunch Money's sign convention. Rows without an external ID
+// column get one derived from their date, amount, payee and position among
+// identical rows, so importing the same file twice yields the same IDs.
+func ReadCSV(r io.Reader, cfg CSVConfig) ([]lunchmoney.InsertTransaction, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("csv config: %w", err)
+	}
+
+	cr := csv.NewReader(r)
+	if cfg.Comma != 0 {
+		cr.Comma = cfg.Comma
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))] = i
+	}
+	for _, name := range []string{cfg.DateColumn, cfg.PayeeColumn, cfg.AmountColumn, cfg.DebitColumn, cfg.CreditColumn, cfg.NotesColumn, cfg.ExternalIDColumn} {
+		if _, ok := cols[name]; name != "" && !ok {
+			return nil, fmt.Errorf("column %q not found in header", name)
+		}
+	}
+
+	layout := cfg.DateFormat
+	if layout == "" {
+		layout = time.DateOnly
+	}
+
+	var ret []lunchmoney.InsertTransaction
+	seen := map[string]int{}
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && name != "" && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+
+		if strings.Join(rec, "") == "" {
+			continue
+		}
+
+		t, err := time.Parse(layout, field(cfg.DateColumn))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: date: %w", line, err)
+		}
+
+		amount, err := cfg.amount(field)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		it := lunchmoney.InsertTransaction{
+			Date:       lunchmoney.DateOf(t),
+			Amount:     amount,
+			Payee:      field(cfg.PayeeColumn),
+			Notes:      field(cfg.NotesColumn),
+			ExternalID: field(cfg.ExternalIDColumn),
+			Currency:   strings.ToLower(cfg.Currency),
+			AssetID:    cfg.AssetID,
+			Status:     cfg.Status,
+		}
+		if it.ExternalID == "" {
+			key := it.Date.String() + "|" + it.Amount.String() + "|" + payees.Normalize(it.Payee)
+			it.ExternalID = rowID("csv", key, seen[key])
+			seen[key]++
+		}
+		ret = append(ret, it)
+	}
+
+	return ret, nil
+}
+
+func (cfg *CSVConfig) amount(field func(string) string) (lunchmoney.Decimal, error) {
+	if cfg.AmountColumn != "" {
+		d, err := cfg.amountFormat().parse(field(cfg.AmountColumn))
+		if err != nil {
+			return d, fmt.Errorf("amount: %w", err)
+		}
+		if cfg.Sign == DebitsNegative {
+			d = d.Neg()
+		}
+		return d, nil
+	}
+
+	f := cfg.amountFormat()
+	debit, err := f.parse(field(cfg.DebitColumn))
+	if err != nil {
+		return debit, fmt.Errorf("debit: %w", err)
+	}
+	credit, err := f.parse(field(cfg.CreditColumn))
+	if err != nil {
+		return credit, fmt.Errorf("credit: %w", err)
+	}
+
+	return debit.Abs().Sub(credit.Abs()), nil
+}
+
+func (cfg *CSVConfig) amountFormat() amountFormat {
+	f := amountFormat{decimal: cfg.DecimalSeparator, thousands: cfg.ThousandsSeparator}
+	if f.decimal == 0 {
+		f.decimal = '.'
+	}
+	if f.thousands == 0 {
+		f.thousands = ','
+		if f.decimal == ',' {
+			f.thousands = '.'
+		}
+	}
+
+	return f
+}
+
+// amountFormat describes how amounts are written.
+type amountFormat struct {
+	decimal   rune
+	thousands rune
+}
+
+// defaultAmountFormat reads amounts such as "1,234.50".
+var defaultAmountFormat = amountFormat{decimal: '.', thousands: ','}
+
+// parseAmount parses an amount in the default format.
+func parseAmount(s string) (lunchmoney.Decimal, error) {
+	return defaultAmountFormat.parse(s)
+}
+
+// parse parses amounts as banks write them, such as "$1,234.50", "(12.00)"
+// or "-3". Currency symbols and spaces are ignored; any other character that
+// is not a digit, sign or separator is an error. An empty string is zero.
+func (f amountFormat) parse(s string) (lunchmoney.Decimal, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return lunchmoney.Decimal{}, nil
+	}
+
+	neg := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		neg = true
+		s = s[1 : len(s)-1]
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == '+':
+			b.WriteRune(r)
+		case r == f.decimal:
+			b.WriteByte('.')
+		case r == f.thousands, unicode.IsSpace(r), unicode.Is(unicode.Sc, r):
+		default:
+			return lunchmoney.Decimal{}, fmt.Errorf("unexpected %q in amount %q", r, orig)
+		}
+	}
+
+	d, err := lunchmoney.ParseDecimal(b.String())
+	if err != nil {
+		return d, err
+	}
+	if neg {
+		d = d.Neg()
+	}
+
+	return d, nil
+}
+
+// rowID derives a stable external ID for the nth row of a file with the given
+// key.
+func rowID(prefix, key string, n int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, n)))
+	return prefix + "-" + hex.EncodeToString(sum[:16])
+}
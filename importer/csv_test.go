@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	in := "Posted,Description,Amount,Memo\n" +
+		"03/01/2024,COFFEE SHOP,-4.50,latte\n" +
+		"03/01/2024,COFFEE SHOP,-4.50,\n" +
+		"03/02/2024,Payroll,\"$1,200.00\",\n" +
+		",,,\n"
+
+	got, err := ReadCSV(strings.NewReader(in), CSVConfig{
+		DateColumn:   "Posted",
+		PayeeColumn:  "Description",
+		AmountColumn: "Amount",
+		NotesColumn:  "Memo",
+		DateFormat:   "01/02/2006",
+		Sign:         DebitsNegative,
+		Currency:     "USD",
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, "2024-03-01", got[0].Date.String())
+	assert.Equal(t, "4.50", got[0].Amount.String())
+	assert.Equal(t, "latte", got[0].Notes)
+	assert.Equal(t, "usd", got[0].Currency)
+	assert.Equal(t, "-1200.00", got[2].Amount.String())
+
+	// Identical rows get distinct, stable external IDs.
+	assert.NotEqual(t, got[0].ExternalID, got[1].ExternalID)
+	again, err := ReadCSV(strings.NewReader(in), CSVConfig{
+		DateColumn: "Posted", PayeeColumn: "Description", AmountColumn: "Amount", DateFormat: "01/02/2006", Sign: DebitsNegative,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, got[1].ExternalID, again[1].ExternalID)
+	assert.LessOrEqual(t, len(got[0].ExternalID), 75)
+}
+
+func TestReadCSVDebitCredit(t *testing.T) {
+	in := "Date;Payee;Out;In;Ref\n2024-03-01;Rent;(900.00);;r1\n2024-03-02;Refund;;12.5;r2\n"
+
+	got, err := ReadCSV(strings.NewReader(in), CSVConfig{
+		DateColumn:       "Date",
+		PayeeColumn:      "Payee",
+		DebitColumn:      "Out",
+		CreditColumn:     "In",
+		ExternalIDColumn: "Ref",
+		Comma:            ';',
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, got[0].Amount.Equal(lunchmoney.MustParseDecimal("900")))
+	assert.True(t, got[1].Amount.Equal(lunchmoney.MustParseDecimal("-12.5")))
+	assert.Equal(t, "r2", got[1].ExternalID)
+}
+
+func TestReadCSVAmountFormat(t *testing.T) {
+	in := "Date;Payee;Amount\n2024-03-01;Miete;-1.234,50 €\n2024-03-02;Bäcker;12,5\n"
+
+	got, err := ReadCSV(strings.NewReader(in), CSVConfig{
+		DateColumn:       "Date",
+		PayeeColumn:      "Payee",
+		AmountColumn:     "Amount",
+		Sign:             DebitsNegative,
+		Comma:            ';',
+		DecimalSeparator: ',',
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "1234.50", got[0].Amount.String())
+	assert.Equal(t, "-12.5", got[1].Amount.String())
+
+	for _, amount := range []string{"12abc", "1O0", "12#"} {
+		_, err = ReadCSV(strings.NewReader("a,b,c\n2024-03-01,p,"+amount+"\n"), CSVConfig{DateColumn: "a", PayeeColumn: "b", AmountColumn: "c"})
+		assert.ErrorContains(t, err, "line 2: amount: unexpected", amount)
+	}
+
+	_, err = ReadCSV(strings.NewReader("a,b,c\n"), CSVConfig{DateColumn: "a", PayeeColumn: "b", AmountColumn: "c", DecimalSeparator: ',', ThousandsSeparator: ','})
+	assert.ErrorContains(t, err, "separators")
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("a,b\n"), CSVConfig{DateColumn: "a", PayeeColumn: "b"})
+	assert.ErrorContains(t, err, "amount column")
+
+	_, err = ReadCSV(strings.NewReader("a,b,c\n"), CSVConfig{DateColumn: "a", PayeeColumn: "b", AmountColumn: "x"})
+	assert.ErrorContains(t, err, `column "x" not found`)
+
+	_, err = ReadCSV(strings.NewReader("a,b,c\nnope,p,1\n"), CSVConfig{DateColumn: "a", PayeeColumn: "b", AmountColumn: "c"})
+	assert.ErrorContains(t, err, "line 2: date")
+}
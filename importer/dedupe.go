@@ -0,0 +1,143 @@
+// Package importer turns external bank data into Lunch Money transactions.
+package importer
+
+import (
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/payees"
+)
+
+// Deduper decides whether an incoming transaction is already present in
+// Lunch Money. Different banks need different notions of "same transaction",
+// so the policy is pluggable.
+type Deduper interface {
+	// Match returns the existing transaction that candidate duplicates, or
+	// nil if it is new.
+	Match(candidate *lunchmoney.InsertTransaction, existing []*lunchmoney.Transaction) *lunchmoney.Transaction
+}
+
+// TieBreak chooses between several existing transactions that all match.
+type TieBreak int
+
+const (
+	// ClosestDate prefers the match whose date is nearest the candidate's,
+	// then the lowest ID.
+	ClosestDate TieBreak = iota
+	// LowestID prefers the oldest existing transaction.
+	LowestID
+)
+
+func (tb TieBreak) pick(candidate *lunchmoney.InsertTransaction, matches []*lunchmoney.Transaction) *lunchmoney.Transaction {
+	var best *lunchmoney.Transaction
+	bestDist := 0
+	for _, m := range matches {
+		dist := 0
+		if tb == ClosestDate {
+			dist, _ = daysApart(candidate.Date, m.Date)
+		}
+
+		if best == nil || dist < bestDist || (dist == bestDist && m.ID < best.ID) {
+			best, bestDist = m, dist
+		}
+	}
+
+	return best
+}
+
+// ExternalID matches only on the external ID, for banks that provide a stable
+// unique identifier per transaction.
+type ExternalID struct{}
+
+// Match implements Deduper.
+func (ExternalID) Match(candidate *lunchmoney.InsertTransaction, existing []*lunchmoney.Transaction) *lunchmoney.Transaction {
+	if candidate.ExternalID == "" {
+		return nil
+	}
+
+	for _, e := range existing {
+		if e.ExternalID == candidate.ExternalID {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Fingerprint matches transactions with the same amount and normalized payee
+// whose dates are at most Window days apart, for banks without stable IDs.
+type Fingerprint struct {
+	Window   int
+	TieBreak TieBreak
+}
+
+// Match implements Deduper.
+func (f Fingerprint) Match(candidate *lunchmoney.InsertTransaction, existing []*lunchmoney.Transaction) *lunchmoney.Transaction {
+	payee := payees.Normalize(candidate.Payee)
+	var matches []*lunchmoney.Transaction
+	for _, e := range existing {
+		if !candidate.Amount.Equal(e.Amount) || payees.Normalize(e.Payee) != payee {
+			continue
+		}
+
+		if d, ok := daysApart(candidate.Date, e.Date); ok && d <= f.Window {
+			matches = append(matches, e)
+		}
+	}
+
+	return f.TieBreak.pick(candidate, matches)
+}
+
+// Fuzzy matches transactions with the same amount, dates at most Window days
+// apart and payees whose payees.Similarity is at least MinSimilarity, for banks that
+// rewrite payee descriptions between statement and feed.
+type Fuzzy struct {
+	Window        int
+	MinSimilarity float64
+	TieBreak      TieBreak
+}
+
+// Match implements Deduper.
+func (f Fuzzy) Match(candidate *lunchmoney.InsertTransaction, existing []*lunchmoney.Transaction) *lunchmoney.Transaction {
+	var matches []*lunchmoney.Transaction
+	for _, e := range existing {
+		if !candidate.Amount.Equal(e.Amount) {
+			continue
+		}
+
+		if d, ok := daysApart(candidate.Date, e.Date); !ok || d > f.Window {
+			continue
+		}
+
+		if payees.Similarity(candidate.Payee, e.Payee) >= f.MinSimilarity {
+			matches = append(matches, e)
+		}
+	}
+
+	return f.TieBreak.pick(candidate, matches)
+}
+
+// Chain tries each deduper in order and returns the first match.
+type Chain []Deduper
+
+// Match implements Deduper.
+func (c Chain) Match(candidate *lunchmoney.InsertTransaction, existing []*lunchmoney.Transaction) *lunchmoney.Transaction {
+	for _, d := range c {
+		if m := d.Match(candidate, existing); m != nil {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func daysApart(a, b lunchmoney.Date) (int, bool) {
+	if a.IsZero() || b.IsZero() {
+		return 0, false
+	}
+
+	d := a.Sub(b)
+	if d < 0 {
+		d = -d
+	}
+
+	return d, true
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupers(t *testing.T) {
+	existing := []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-03-01"), Payee: "AMZN Mktp US", Amount: lunchmoney.MustParseDecimal("12.00"), ExternalID: "abc"},
+		{ID: 2, Date: lunchmoney.MustParseDate("2024-03-04"), Payee: "Amazon.com", Amount: lunchmoney.MustParseDecimal("12"), ExternalID: ""},
+		{ID: 3, Date: lunchmoney.MustParseDate("2024-03-02"), Payee: "amazon com", Amount: lunchmoney.MustParseDecimal("12.00")},
+	}
+
+	tests := []struct {
+		name      string
+		deduper   Deduper
+		candidate lunchmoney.InsertTransaction
+		want      lunchmoney.TransactionID
+	}{
+		{
+			name:      "external id",
+			deduper:   ExternalID{},
+			candidate: lunchmoney.InsertTransaction{ExternalID: "abc"},
+			want:      1,
+		},
+		{
+			name:      "external id missing",
+			deduper:   ExternalID{},
+			candidate: lunchmoney.InsertTransaction{},
+		},
+		{
+			name:      "fingerprint closest date",
+			deduper:   Fingerprint{Window: 3},
+			candidate: lunchmoney.InsertTransaction{Date: lunchmoney.MustParseDate("2024-03-03"), Payee: "AMAZON.COM", Amount: lunchmoney.MustParseDecimal("12")},
+			want:      2,
+		},
+		{
+			name:      "fingerprint lowest id",
+			deduper:   Fingerprint{Window: 3, TieBreak: LowestID},
+			candidate: lunchmoney.InsertTransaction{Date: lunchmoney.MustParseDate("2024-03-03"), Payee: "AMAZON.COM", Amount: lunchmoney.MustParseDecimal("12")},
+			want:      2,
+		},
+		{
+			name:      "fingerprint outside window",
+			deduper:   Fingerprint{Window: 0},
+			candidate: lunchmoney.InsertTransaction{Date: lunchmoney.MustParseDate("2024-03-03"), Payee: "AMAZON.COM", Amount: lunchmoney.MustParseDecimal("12")},
+		},
+		{
+			name:      "fuzzy payee",
+			deduper:   Fuzzy{Window: 1, MinSimilarity: 0.5},
+			candidate: lunchmoney.InsertTransaction{Date: lunchmoney.MustParseDate("2024-03-01"), Payee: "AMZN MKTP US*2K3", Amount: lunchmoney.MustParseDecimal("12")},
+			want:      1,
+		},
+		{
+			name:      "chain falls through",
+			deduper:   Chain{ExternalID{}, Fingerprint{Window: 1}},
+			candidate: lunchmoney.InsertTransaction{ExternalID: "zzz", Date: lunchmoney.MustParseDate("2024-03-02"), Payee: "Amazon Com", Amount: lunchmoney.MustParseDecimal("12")},
+			want:      3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.deduper.Match(&tt.candidate, existing)
+			if tt.want == 0 {
+				assert.Nil(t, got)
+				return
+			}
+
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.ID)
+			}
+		})
+	}
+}
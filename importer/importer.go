@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icco/lunchmoney"
+)
+
+// DefaultBatchSize is the number of transactions sent per insert request.
+const DefaultBatchSize = 100
+
+// Inserter inserts transactions. *lunchmoney.Client implements it.
+type Inserter interface {
+	InsertTransactions(ctx context.Context, req lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error)
+}
+
+// Importer inserts normalized transactions in batches, skipping those that
+// are already in Lunch Money.
+type Importer struct {
+	Client Inserter
+	// Existing are transactions already in Lunch Money, typically fetched for
+	// the date range being imported. Candidates matching one are skipped.
+	Existing []*lunchmoney.Transaction
+	// Deduper matches candidates against Existing. Defaults to ExternalID.
+	Deduper Deduper
+	// BatchSize defaults to DefaultBatchSize.
+	BatchSize int
+	// Request carries the insert options, such as ApplyRules. Its
+	// Transactions are replaced by each batch.
+	Request lunchmoney.InsertTransactionsRequest
+}
+
+// ImportResult describes what an Import call did.
+type ImportResult struct {
+	Inserted []lunchmoney.TransactionID
+	// Skipped are the candidates that duplicated an existing transaction or
+	// an earlier candidate with the same external ID.
+	Skipped []lunchmoney.InsertTransaction
+}
+
+// Import inserts txns. If a batch fails, the result so far is returned along
+// with the error, so the import can be retried with the remaining rows.
+func (im *Importer) Import(ctx context.Context, txns []lunchmoney.InsertTransaction) (*ImportResult, error) {
+	dedup := im.Deduper
+	if dedup == nil {
+		dedup = ExternalID{}
+	}
+	size := im.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	res := &ImportResult{}
+	var pending []lunchmoney.InsertTransaction
+	seen := map[string]bool{}
+	for _, t := range txns {
+		if t.ExternalID != "" && seen[t.ExternalID] {
+			res.Skipped = append(res.Skipped, t)
+			continue
+		}
+		if dedup.Match(&t, im.Existing) != nil {
+			res.Skipped = append(res.Skipped, t)
+			continue
+		}
+
+		if t.ExternalID != "" {
+			seen[t.ExternalID] = true
+		}
+		pending = append(pending, t)
+	}
+
+	for start := 0; start < len(pending); start += size {
+		batch := pending[start:min(start+size, len(pending))]
+
+		req := im.Request
+		req.Transactions = batch
+		resp, err := im.Client.InsertTransactions(ctx, req)
+		if err != nil {
+			return res, fmt.Errorf("insert rows %d to %d: %w", start+1, start+len(batch), err)
+		}
+		res.Inserted = append(res.Inserted, resp.IDs...)
+	}
+
+	return res, nil
+}
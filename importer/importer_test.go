@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInserter struct {
+	batches []lunchmoney.InsertTransactionsRequest
+	failAt  int
+	nextID  lunchmoney.TransactionID
+}
+
+func (f *fakeInserter) InsertTransactions(_ context.Context, req lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+	if f.failAt > 0 && len(f.batches)+1 == f.failAt {
+		return nil, errors.New("boom")
+	}
+
+	f.batches = append(f.batches, req)
+	resp := &lunchmoney.InsertTransactionsResponse{}
+	for range req.Transactions {
+		f.nextID++
+		resp.IDs = append(resp.IDs, f.nextID)
+	}
+
+	return resp, nil
+}
+
+func TestImport(t *testing.T) {
+	f := &fakeInserter{}
+	im := &Importer{
+		Client:    f,
+		Existing:  []*lunchmoney.Transaction{{ID: 99, ExternalID: "a"}},
+		BatchSize: 2,
+		Request:   lunchmoney.InsertTransactionsRequest{ApplyRules: true},
+	}
+
+	res, err := im.Import(context.Background(), []lunchmoney.InsertTransaction{
+		{ExternalID: "a"}, {ExternalID: "b"}, {ExternalID: "b"}, {ExternalID: "c"}, {ExternalID: "d"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []lunchmoney.TransactionID{1, 2, 3}, res.Inserted)
+	assert.Len(t, res.Skipped, 2)
+	require.Len(t, f.batches, 2)
+	assert.True(t, f.batches[0].ApplyRules)
+	assert.Len(t, f.batches[0].Transactions, 2)
+	assert.Len(t, f.batches[1].Transactions, 1)
+
+	f = &fakeInserter{failAt: 2}
+	im = &Importer{Client: f, BatchSize: 1}
+	res, err = im.Import(context.Background(), []lunchmoney.InsertTransaction{{ExternalID: "x"}, {ExternalID: "y"}})
+	assert.ErrorContains(t, err, "insert rows 2 to 2")
+	assert.Len(t, res.Inserted, 1)
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// OFXConfig configures ReadOFX.
+type OFXConfig struct {
+	// Currency is used when the file has no CURDEF. AssetID is set on every
+	// transaction.
+	Currency string
+	AssetID  *lunchmoney.AssetID
+}
+
+var (
+	ofxStart = regexp.MustCompile(`(?i)<STMTTRN>`)
+	ofxEnd   = regexp.MustCompile(`(?i)</STMTTRN>|</BANKTRANLIST>`)
+	ofxField = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+	ofxCur   = regexp.MustCompile(`(?i)<CURDEF>([A-Z]{3})`)
+)
+
+// ReadOFX reads the transactions of a bank or credit card statement in Open
+// Financial Exchange format, either the SGML (1.x) or XML (2.x) flavor.
+// Amounts are converted to Lunch Money's sign convention and the FITID of
+// each transaction becomes its external ID.
+func ReadOFX(r io.Reader, cfg OFXConfig) ([]lunchmoney.InsertTransaction, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	doc := string(b)
+
+	currency := cfg.Currency
+	if m := ofxCur.FindStringSubmatch(doc); m != nil {
+		currency = m[1]
+	}
+
+	var ret []lunchmoney.InsertTransaction
+	// SGML files may omit closing tags, so each transaction runs until the
+	// next one starts or the list ends.
+	blocks := ofxStart.Split(doc, -1)[1:]
+	for i, block := range blocks {
+		if loc := ofxEnd.FindStringIndex(block); loc != nil {
+			block = block[:loc[0]]
+		}
+
+		fields := map[string]string{}
+		for _, f := range ofxField.FindAllStringSubmatch(block, -1) {
+			fields[strings.ToUpper(f[1])] = strings.TrimSpace(f[2])
+		}
+
+		d, err := parseOFXDate(fields["DTPOSTED"])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+		amount, err := parseAmount(fields["TRNAMT"])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: amount: %w", i+1, err)
+		}
+
+		payee := fields["NAME"]
+		if payee == "" {
+			payee = fields["PAYEE"]
+		}
+		ret = append(ret, lunchmoney.InsertTransaction{
+			Date:       d,
+			Amount:     amount.Neg(),
+			Payee:      payee,
+			Notes:      fields["MEMO"],
+			ExternalID: fields["FITID"],
+			Currency:   strings.ToLower(currency),
+			AssetID:    cfg.AssetID,
+		})
+	}
+
+	return ret, nil
+}
+
+// parseOFXDate parses the date part of an OFX datetime such as
+// "20240301120000.000[-5:EST]".
+func parseOFXDate(s string) (lunchmoney.Date, error) {
+	if len(s) < 8 {
+		return lunchmoney.Date{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	t, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return lunchmoney.Date{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	return lunchmoney.DateOf(t), nil
+}
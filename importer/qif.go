@@ -0,0 +1,185 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/payees"
+)
+
+// qifDateLayouts are the date formats Quicken and its imitators write, tried
+// in order when QIFConfig.DateFormat is empty.
+var qifDateLayouts = []string{
+	"01/02/2006",
+	"1/2/2006",
+	"1/2'06",
+	"01/02'06",
+	"1/ 2'06",
+	"01/02/06",
+	"2006-01-02",
+}
+
+// QIFConfig configures ReadQIF.
+type QIFConfig struct {
+	// DateFormat is the time layout of D lines. If empty, the common
+	// US-style layouts are tried.
+	DateFormat string
+	// Currency and AssetID are set on every transaction.
+	Currency string
+	AssetID  *lunchmoney.AssetID
+	// Category, if set, resolves the L (category) line to a category ID,
+	// for example with refdata's CategoryByName. Unresolved categories are
+	// left unset.
+	Category func(name string) (lunchmoney.CategoryID, bool)
+}
+
+// ReadQIF reads the bank, cash and credit card transactions of a Quicken
+// Interchange Format file. Amounts are converted to Lunch Money's sign
+// convention, cleared records (C*, CX) are marked cleared and every
+// transaction gets a stable external ID like ReadCSV. Splits and investment
+// records are not supported; investment sections are skipped.
+func ReadQIF(r io.Reader, cfg QIFConfig) ([]lunchmoney.InsertTransaction, error) {
+	sc := bufio.NewScanner(r)
+
+	var (
+		ret      []lunchmoney.InsertTransaction
+		cur      qifRecord
+		start    int
+		skipping bool
+	)
+	seen := map[string]int{}
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimRight(sc.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		code, val := text[0], strings.TrimSpace(text[1:])
+		switch {
+		case code == '!':
+			typ := strings.ToLower(val)
+			skipping = !strings.HasPrefix(typ, "type:bank") && !strings.HasPrefix(typ, "type:cash") &&
+				!strings.HasPrefix(typ, "type:ccard") && !strings.HasPrefix(typ, "type:oth")
+			cur = qifRecord{}
+			continue
+		case skipping:
+			continue
+		case code == '^':
+			if cur.empty() {
+				continue
+			}
+			it, err := cur.transaction(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("record at line %d: %w", start, err)
+			}
+			key := it.Date.String() + "|" + it.Amount.String() + "|" + payees.Normalize(it.Payee) + "|" + cur.number
+			it.ExternalID = rowID("qif", key, seen[key])
+			seen[key]++
+			ret = append(ret, it)
+			cur = qifRecord{}
+			continue
+		}
+
+		if cur.empty() {
+			start = line
+		}
+		switch code {
+		case 'D':
+			cur.date = val
+		case 'T', 'U':
+			cur.amount = val
+		case 'P':
+			cur.payee = val
+		case 'M':
+			cur.memo = val
+		case 'N':
+			cur.number = val
+		case 'L':
+			cur.category = val
+		case 'C':
+			cur.cleared = val == "*" || strings.EqualFold(val, "x")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if !cur.empty() {
+		return nil, fmt.Errorf("record at line %d: missing ^ terminator", start)
+	}
+
+	return ret, nil
+}
+
+type qifRecord struct {
+	date, amount, payee, memo, number, category string
+	cleared                                     bool
+}
+
+func (q *qifRecord) empty() bool {
+	return *q == qifRecord{}
+}
+
+func (q *qifRecord) transaction(cfg QIFConfig) (lunchmoney.InsertTransaction, error) {
+	var it lunchmoney.InsertTransaction
+	if q.date == "" {
+		return it, errors.New("missing date")
+	}
+	if q.amount == "" {
+		return it, errors.New("missing amount")
+	}
+
+	d, err := parseQIFDate(q.date, cfg.DateFormat)
+	if err != nil {
+		return it, err
+	}
+	amount, err := parseAmount(q.amount)
+	if err != nil {
+		return it, fmt.Errorf("amount: %w", err)
+	}
+
+	it = lunchmoney.InsertTransaction{
+		Date:     d,
+		Amount:   amount.Neg(),
+		Payee:    q.payee,
+		Notes:    q.memo,
+		Currency: strings.ToLower(cfg.Currency),
+		AssetID:  cfg.AssetID,
+	}
+	if q.cleared {
+		it.Status = "cleared"
+	}
+	if q.category != "" && cfg.Category != nil {
+		// Transfers are written as [Account name] and have no category.
+		if !strings.HasPrefix(q.category, "[") {
+			name, _, _ := strings.Cut(q.category, "/")
+			if id, ok := cfg.Category(name); ok {
+				it.CategoryID = &id
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func parseQIFDate(s, layout string) (lunchmoney.Date, error) {
+	layouts := qifDateLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return lunchmoney.DateOf(t), nil
+		}
+	}
+
+	return lunchmoney.Date{}, fmt.Errorf("unrecognized date %q", s)
+}
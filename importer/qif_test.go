@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testQIF = `!Type:Bank
+D03/01/2024
+T-1,234.56
+PLANDLORD LLC
+MMarch rent
+LHousing:Rent
+CX
+N1001
+^
+D3/ 2'24
+U250.00
+PPayroll
+LIncome
+^
+!Type:Invst
+D03/03/2024
+NBuy
+T100.00
+^
+`
+
+func TestReadQIF(t *testing.T) {
+	got, err := ReadQIF(strings.NewReader(testQIF), QIFConfig{
+		Currency: "USD",
+		Category: func(name string) (lunchmoney.CategoryID, bool) {
+			return 7, name == "Housing:Rent"
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "2024-03-01", got[0].Date.String())
+	assert.Equal(t, "1234.56", got[0].Amount.String())
+	assert.Equal(t, "LANDLORD LLC", got[0].Payee)
+	assert.Equal(t, "March rent", got[0].Notes)
+	assert.Equal(t, "cleared", got[0].Status)
+	assert.Equal(t, "usd", got[0].Currency)
+	require.NotNil(t, got[0].CategoryID)
+	assert.Equal(t, lunchmoney.CategoryID(7), *got[0].CategoryID)
+	assert.NotEmpty(t, got[0].ExternalID)
+
+	assert.Equal(t, "2024-03-02", got[1].Date.String())
+	assert.Equal(t, "-250.00", got[1].Amount.String())
+	assert.Equal(t, "", got[1].Status)
+	assert.Nil(t, got[1].CategoryID)
+}
+
+func TestReadQIFErrors(t *testing.T) {
+	_, err := ReadQIF(strings.NewReader("!Type:Bank\nD03/01/2024\n^\n"), QIFConfig{})
+	assert.ErrorContains(t, err, "missing amount")
+
+	_, err = ReadQIF(strings.NewReader("!Type:Bank\nD03/01/2024\nT1\n"), QIFConfig{})
+	assert.ErrorContains(t, err, "missing ^")
+
+	_, err = ReadQIF(strings.NewReader("!Type:Bank\nDyesterday\nT1\n^\n"), QIFConfig{})
+	assert.ErrorContains(t, err, `unrecognized date "yesterday"`)
+}
@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"slices"
+
+	"github.com/icco/lunchmoney"
+)
+
+// DefaultReconcileDeduper is used by Reconcile when no Deduper is given: a
+// matching external ID wins, then the same amount and payee within three
+// days, then the same amount within three days and a loosely similar payee.
+var DefaultReconcileDeduper Deduper = Chain{
+	ExternalID{},
+	Fingerprint{Window: 3},
+	Fuzzy{Window: 3, MinSimilarity: 0.3},
+}
+
+// Match pairs a statement entry with the Lunch Money transaction it matched.
+type Match struct {
+	Statement lunchmoney.InsertTransaction
+	Existing  *lunchmoney.Transaction
+}
+
+// Reconciliation is the result of comparing a statement with Lunch Money.
+type Reconciliation struct {
+	Matched []Match
+	// MissingInLunchMoney are statement entries with no matching
+	// transaction.
+	MissingInLunchMoney []lunchmoney.InsertTransaction
+	// OnlyInLunchMoney are transactions no statement entry matched.
+	OnlyInLunchMoney []*lunchmoney.Transaction
+}
+
+// Balanced reports whether every entry on both sides was matched.
+func (r *Reconciliation) Balanced() bool {
+	return len(r.MissingInLunchMoney) == 0 && len(r.OnlyInLunchMoney) == 0
+}
+
+// Reconcile matches statement entries, as read by ReadCSV, ReadQIF or
+// ReadOFX, against the existing transactions of the same account and date
+// range. Each existing transaction matches at most one entry. dedup decides
+// what counts as a match; nil means DefaultReconcileDeduper.
+func Reconcile(statement []lunchmoney.InsertTransaction, existing []*lunchmoney.Transaction, dedup Deduper) *Reconciliation {
+	if dedup == nil {
+		dedup = DefaultReconcileDeduper
+	}
+
+	r := &Reconciliation{}
+	remaining := slices.Clone(existing)
+	for _, s := range statement {
+		m := dedup.Match(&s, remaining)
+		if m == nil {
+			r.MissingInLunchMoney = append(r.MissingInLunchMoney, s)
+			continue
+		}
+
+		r.Matched = append(r.Matched, Match{Statement: s, Existing: m})
+		remaining = slices.DeleteFunc(remaining, func(t *lunchmoney.Transaction) bool { return t == m })
+	}
+	r.OnlyInLunchMoney = remaining
+
+	return r
+}
@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOFX = `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240301120000.000[-5:EST]
+<TRNAMT>-4.50
+<FITID>A1
+<NAME>BLUE BOTTLE COFFEE #12
+<MEMO>card 1234
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240303
+<TRNAMT>-900.00
+<FITID>A2
+<NAME>RENT
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240305
+<TRNAMT>2000.00
+<FITID>A3
+<NAME>PAYROLL
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+func TestReadOFX(t *testing.T) {
+	got, err := ReadOFX(strings.NewReader(testOFX), OFXConfig{})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, "2024-03-01", got[0].Date.String())
+	assert.Equal(t, "4.50", got[0].Amount.String())
+	assert.Equal(t, "BLUE BOTTLE COFFEE #12", got[0].Payee)
+	assert.Equal(t, "card 1234", got[0].Notes)
+	assert.Equal(t, "A1", got[0].ExternalID)
+	assert.Equal(t, "usd", got[0].Currency)
+	assert.Equal(t, "-2000.00", got[2].Amount.String())
+}
+
+func TestReconcile(t *testing.T) {
+	statement, err := ReadOFX(strings.NewReader(testOFX), OFXConfig{})
+	require.NoError(t, err)
+
+	existing := []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-03-02"), Payee: "Blue Bottle Coffee", Amount: lunchmoney.MustParseDecimal("4.5")},
+		{ID: 2, Date: lunchmoney.MustParseDate("2024-03-03"), Payee: "Landlord", Amount: lunchmoney.MustParseDecimal("900"), ExternalID: "A2"},
+		{ID: 3, Date: lunchmoney.MustParseDate("2024-03-04"), Payee: "Cash withdrawal", Amount: lunchmoney.MustParseDecimal("60")},
+	}
+
+	r := Reconcile(statement, existing, nil)
+	require.Len(t, r.Matched, 2)
+	assert.Equal(t, lunchmoney.TransactionID(1), r.Matched[0].Existing.ID)
+	assert.Equal(t, lunchmoney.TransactionID(2), r.Matched[1].Existing.ID)
+	require.Len(t, r.MissingInLunchMoney, 1)
+	assert.Equal(t, "PAYROLL", r.MissingInLunchMoney[0].Payee)
+	require.Len(t, r.OnlyInLunchMoney, 1)
+	assert.Equal(t, lunchmoney.TransactionID(3), r.OnlyInLunchMoney[0].ID)
+	assert.False(t, r.Balanced())
+
+	// An existing transaction matches at most one statement entry.
+	dup := append(statement[:1:1], statement[0])
+	dup[1].ExternalID = "other"
+	r = Reconcile(dup, existing[:1], nil)
+	assert.Len(t, r.Matched, 1)
+	assert.Len(t, r.MissingInLunchMoney, 1)
+	assert.Empty(t, r.OnlyInLunchMoney)
+}
@@ -0,0 +1,188 @@
+// Package output implements the output contract shared by all CLI
+// subcommands: every command can print json, csv or a table, JSON output has
+// a stable versioned envelope, and exit codes tell API errors, validation
+// errors and partial failures apart so the CLI can be scripted.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/icco/lunchmoney"
+)
+
+// SchemaVersion is the version of the JSON envelope. It only changes for
+// incompatible changes; new fields may be added at any time.
+const SchemaVersion = 1
+
+// Format is an output format.
+type Format string
+
+// Supported formats.
+const (
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	Table Format = "table"
+)
+
+// ParseFormat validates a --output flag value.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case JSON, CSV, Table:
+		return f, nil
+	default:
+		return "", &UsageError{Err: fmt.Errorf("unknown output format %q, want json, csv or table", s)}
+	}
+}
+
+// Exit codes returned by the CLI.
+const (
+	ExitOK         = 0
+	ExitError      = 1
+	ExitUsage      = 2
+	ExitAPI        = 3
+	ExitValidation = 4
+	ExitPartial    = 5
+)
+
+// Result is the output of a command. Columns and Rows are used for csv and
+// table output; Data is what is encoded for json output.
+type Result struct {
+	Columns []string
+	Rows    [][]string
+	Data    any
+}
+
+type envelope struct {
+	SchemaVersion int        `json:"schema_version"`
+	Data          any        `json:"data,omitempty"`
+	Error         *errorBody `json:"error,omitempty"`
+}
+
+type errorBody struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Write renders r to w in the given format.
+func Write(w io.Writer, f Format, r Result) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(envelope{SchemaVersion: SchemaVersion, Data: r.Data})
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(r.Columns); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(r.Rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case Table:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if _, err := fmt.Fprintln(tw, strings.Join(r.Columns, "\t")); err != nil {
+			return err
+		}
+		for _, row := range r.Rows {
+			if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", f)
+	}
+}
+
+// WriteError reports err on w. In json format the error is written as a JSON
+// envelope so scripts can parse it; otherwise it is a line of text, followed
+// by one indented line per failed item of a PartialError.
+// It returns the exit code the process should use.
+func WriteError(w io.Writer, f Format, err error) int {
+	code := ExitCode(err)
+	if f == JSON {
+		body := &errorBody{Code: errorCode(code), Message: err.Error()}
+		var pe *PartialError
+		if errors.As(err, &pe) {
+			for _, e := range pe.Errs {
+				body.Details = append(body.Details, e.Error())
+			}
+		}
+		_ = json.NewEncoder(w).Encode(envelope{SchemaVersion: SchemaVersion, Error: body})
+		return code
+	}
+
+	_, _ = fmt.Fprintf(w, "error: %v\n", err)
+	var pe *PartialError
+	if errors.As(err, &pe) {
+		for _, e := range pe.Errs {
+			_, _ = fmt.Fprintf(w, "  %v\n", e)
+		}
+	}
+	return code
+}
+
+// UsageError is an error in how the CLI was invoked.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string { return e.Err.Error() }
+func (e *UsageError) Unwrap() error { return e.Err }
+
+// PartialError reports a batch operation where some items failed.
+type PartialError struct {
+	Succeeded int
+	Errs      []error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d succeeded, %d failed", e.Succeeded, len(e.Errs))
+}
+
+// ExitCode maps an error to the CLI exit code.
+func ExitCode(err error) int {
+	var (
+		usage   *UsageError
+		partial *PartialError
+		lmErr   *lunchmoney.APIError
+	)
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usage):
+		return ExitUsage
+	case errors.As(err, &partial):
+		return ExitPartial
+	case lunchmoney.IsValidation(err):
+		return ExitValidation
+	case errors.As(err, &lmErr):
+		return ExitAPI
+	default:
+		return ExitError
+	}
+}
+
+func errorCode(exit int) string {
+	switch exit {
+	case ExitUsage:
+		return "usage_error"
+	case ExitPartial:
+		return "partial_failure"
+	case ExitValidation:
+		return "validation_error"
+	case ExitAPI:
+		return "api_error"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	r := Result{
+		Columns: []string{"id", "payee"},
+		Rows:    [][]string{{"1", "Coffee, Inc"}},
+		Data:    []map[string]any{{"id": 1, "payee": "Coffee, Inc"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, JSON, r))
+	assert.JSONEq(t, `{"schema_version": 1, "data": [{"id": 1, "payee": "Coffee, Inc"}]}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, CSV, r))
+	assert.Equal(t, "id,payee\n1,\"Coffee, Inc\"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, Table, r))
+	assert.Equal(t, "id  payee\n1   Coffee, Inc\n", buf.String())
+}
+
+func TestExitCodes(t *testing.T) {
+	_, err := ParseFormat("yaml")
+	assert.Equal(t, ExitUsage, ExitCode(err))
+	assert.Equal(t, ExitOK, ExitCode(nil))
+	assert.Equal(t, ExitError, ExitCode(errors.New("disk full")))
+	assert.Equal(t, ExitAPI, ExitCode(fmt.Errorf("get tags: %w", &lunchmoney.APIError{StatusCode: 500, Status: "500 Internal Server Error"})))
+	assert.Equal(t, ExitValidation, ExitCode(&lunchmoney.APIError{StatusCode: 400, Status: "400 Bad Request"}))
+
+	var buf bytes.Buffer
+	code := WriteError(&buf, JSON, &PartialError{Succeeded: 2, Errs: []error{errors.New("txn 3: 404")}})
+	assert.Equal(t, ExitPartial, code)
+	assert.JSONEq(t, `{"schema_version": 1, "error": {"code": "partial_failure", "message": "2 succeeded, 1 failed", "details": ["txn 3: 404"]}}`, buf.String())
+
+	buf.Reset()
+	WriteError(&buf, Table, &PartialError{Succeeded: 2, Errs: []error{errors.New("txn 3: 404")}})
+	assert.Equal(t, "error: 2 succeeded, 1 failed\n  txn 3: 404\n", buf.String())
+}
@@ -0,0 +1,275 @@
+// Package mcp serves Lunch Money operations to LLM assistants over the Model
+// Context Protocol. The server speaks JSON-RPC 2.0 over a stream of
+// newline-delimited messages, such as the stdio transport, and only exposes
+// the tools allowed by its scopes, so an assistant can be given read-only
+// access to a budget.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/icco/lunchmoney"
+)
+
+// ProtocolVersion is the MCP revision the server implements.
+const ProtocolVersion = "2024-11-05"
+
+// Scope is a permission granted to the assistant.
+type Scope string
+
+// Supported scopes.
+const (
+	// ScopeRead allows searching transactions and reading budgets and
+	// categories.
+	ScopeRead Scope = "read"
+	// ScopeWrite allows changing transactions.
+	ScopeWrite Scope = "write"
+)
+
+// ParseScope validates a scope name.
+func ParseScope(s string) (Scope, error) {
+	switch sc := Scope(s); sc {
+	case ScopeRead, ScopeWrite:
+		return sc, nil
+	default:
+		return "", fmt.Errorf("unknown scope %q, want read or write", s)
+	}
+}
+
+// Client is the subset of *lunchmoney.Client the tools need.
+type Client interface {
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+	GetBudgets(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+	GetCategories(ctx context.Context) ([]*lunchmoney.Category, error)
+	UpdateTransaction(ctx context.Context, id lunchmoney.TransactionID, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error)
+}
+
+// Options configure a Server.
+type Options struct {
+	// Scopes are the permissions granted to the assistant. Defaults to
+	// ScopeRead only.
+	Scopes []Scope
+	// Name and Version identify the server to the assistant. Name defaults
+	// to "lunchmoney".
+	Name    string
+	Version string
+}
+
+// Server is an MCP server exposing Lunch Money tools.
+type Server struct {
+	client Client
+	opts   Options
+	tools  []*tool
+}
+
+// NewServer creates a server calling c.
+func NewServer(c Client, opts Options) *Server {
+	if len(opts.Scopes) == 0 {
+		opts.Scopes = []Scope{ScopeRead}
+	}
+	if opts.Name == "" {
+		opts.Name = "lunchmoney"
+	}
+
+	s := &Server{client: c, opts: opts}
+	for _, t := range s.allTools() {
+		if slices.Contains(opts.Scopes, t.scope) {
+			s.tools = append(s.tools, t)
+		}
+	}
+
+	return s
+}
+
+// Tools returns the names of the tools allowed by the server's scopes.
+func (s *Server) Tools() []string {
+	ret := make([]string, len(s.tools))
+	for i, t := range s.tools {
+		ret[i] = t.name
+	}
+
+	return ret
+}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted or ctx is done. Requests are handled concurrently, so a slow
+// API call does not hold up pings.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		enc = json.NewEncoder(w)
+	)
+	defer wg.Wait()
+	send := func(resp *response) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = enc.Encode(resp)
+	}
+
+	lines := make(chan []byte)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(r)
+		sc.Buffer(nil, 16<<20)
+		for sc.Scan() {
+			select {
+			case lines <- slices.Clone(sc.Bytes()):
+			case <-ctx.Done():
+				scanErr <- ctx.Err()
+				return
+			}
+		}
+		scanErr <- sc.Err()
+	}()
+
+	for {
+		var line []byte
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case l, ok := <-lines:
+			if !ok {
+				return <-scanErr
+			}
+			line = l
+		}
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			send(&response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := s.handle(ctx, &req)
+			if req.ID == nil {
+				return // notifications get no response
+			}
+
+			resp := &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+			if err != nil {
+				rerr := &rpcError{}
+				if !errors.As(err, &rerr) {
+					rerr = &rpcError{Code: codeInvalidRequest, Message: err.Error()}
+				}
+				resp.Result, resp.Error = nil, rerr
+			}
+			send(resp)
+		}()
+	}
+}
+
+func (s *Server) handle(ctx context.Context, req *request) (any, error) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{Code: codeInvalidRequest, Message: `jsonrpc must be "2.0"`}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": s.opts.Name, "version": s.opts.Version},
+		}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		list := make([]map[string]any, len(s.tools))
+		for i, t := range s.tools {
+			list[i] = map[string]any{"name": t.name, "description": t.description, "inputSchema": t.schema}
+		}
+		return map[string]any{"tools": list}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		i := slices.IndexFunc(s.tools, func(t *tool) bool { return t.name == params.Name })
+		if i < 0 {
+			return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)}
+		}
+		return s.call(ctx, s.tools[i], params.Arguments), nil
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// call runs a tool. Tool failures are reported in the result, as the
+// protocol asks, so the assistant can see them and recover.
+func (s *Server) call(ctx context.Context, t *tool, args json.RawMessage) *callResult {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "mcp."+t.name)
+
+	v, err := t.call(ctx, args)
+	if err != nil {
+		return &callResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return &callResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+
+	return &callResult{Content: []content{{Type: "text", Text: string(b)}}}
+}
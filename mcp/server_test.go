@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	filters *lunchmoney.TransactionFilters
+	budgets *lunchmoney.BudgetFilters
+	updated map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction
+	op      string
+}
+
+func (f *fakeClient) GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, _ *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	f.filters = filters
+	f.op = lunchmoney.OperationFromContext(ctx)
+	return []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-05-01"), Payee: "Blue Bottle Coffee", Amount: lunchmoney.MustParseDecimal("4.50"), CategoryID: lunchmoney.Ptr(lunchmoney.CategoryID(3))},
+		{ID: 2, Date: lunchmoney.MustParseDate("2024-05-02"), Payee: "Grocer", Notes: "coffee beans", Amount: lunchmoney.MustParseDecimal("12")},
+		{ID: 3, Date: lunchmoney.MustParseDate("2024-05-03"), Payee: "Rent", Amount: lunchmoney.MustParseDecimal("900")},
+	}, nil
+}
+
+func (f *fakeClient) GetBudgets(_ context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	f.budgets = filters
+	return []*lunchmoney.Budget{{CategoryID: 3, CategoryName: "Coffee", Data: map[string]*lunchmoney.BudgetData{
+		"2024-02-01": {BudgetToBase: lunchmoney.MustParseDecimal("50"), SpendingToBase: lunchmoney.MustParseDecimal("60"), NumTransactions: 4},
+	}}}, nil
+}
+
+func (f *fakeClient) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return []*lunchmoney.Category{{ID: 1, Name: "Food", IsGroup: true}, {ID: 3, Name: "Coffee", GroupID: lunchmoney.Ptr(lunchmoney.CategoryID(1))}}, nil
+}
+
+func (f *fakeClient) UpdateTransaction(_ context.Context, id lunchmoney.TransactionID, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	f.updated[id] = ut
+	return &lunchmoney.UpdateTransactionResp{Updated: true}, nil
+}
+
+// exchange sends requests to a server and returns its responses by ID.
+func exchange(t *testing.T, s *Server, requests ...string) map[string]map[string]any {
+	t.Helper()
+
+	var out bytes.Buffer
+	require.NoError(t, s.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out))
+
+	ret := map[string]map[string]any{}
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]any
+		require.NoError(t, dec.Decode(&resp))
+		id, _ := json.Marshal(resp["id"])
+		ret[string(id)] = resp
+	}
+	return ret
+}
+
+// toolText decodes the text content of a tools/call response.
+func toolText(t *testing.T, resp map[string]any, v any) bool {
+	t.Helper()
+
+	result := resp["result"].(map[string]any)
+	text := result["content"].([]any)[0].(map[string]any)["text"].(string)
+	if v != nil {
+		require.NoError(t, json.Unmarshal([]byte(text), v), text)
+	}
+	isErr, _ := result["isError"].(bool)
+	return isErr
+}
+
+func TestServer(t *testing.T) {
+	f := &fakeClient{updated: map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction{}}
+	s := NewServer(f, Options{Version: "test"})
+	assert.Equal(t, []string{"search_transactions", "get_budgets", "list_categories"}, s.Tools())
+
+	resps := exchange(t, s,
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2024-11-05"}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "search_transactions", "arguments": {"start_date": "2024-05-01", "end_date": "2024-05-31", "query": "COFFEE", "limit": 1}}}`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "get_budgets", "arguments": {"month": "2024-02"}}}`,
+		`{"jsonrpc": "2.0", "id": 5, "method": "tools/call", "params": {"name": "update_transaction_category", "arguments": {"transaction_id": 2, "category_id": 3}}}`,
+		`{"jsonrpc": "2.0", "id": "six", "method": "tools/call", "params": {"name": "search_transactions", "arguments": {"start": "2024-05-01"}}}`,
+		`{"jsonrpc": "2.0", "id": 7, "method": "resources/list"}`,
+		`not json`,
+	)
+	require.Len(t, resps, 8)
+
+	info := resps["1"]["result"].(map[string]any)
+	assert.Equal(t, ProtocolVersion, info["protocolVersion"])
+	assert.Equal(t, "test", info["serverInfo"].(map[string]any)["version"])
+	assert.Len(t, resps["2"]["result"].(map[string]any)["tools"], 3)
+
+	var search struct {
+		Transactions []transactionResult
+		Total        int
+		Truncated    bool
+	}
+	assert.False(t, toolText(t, resps["3"], &search))
+	assert.Equal(t, 2, search.Total)
+	assert.True(t, search.Truncated)
+	require.Len(t, search.Transactions, 1)
+	assert.Equal(t, lunchmoney.TransactionID(1), search.Transactions[0].ID)
+	assert.Equal(t, "2024-05-31", f.filters.EndDate.String())
+	assert.Equal(t, "mcp.search_transactions", f.op)
+
+	var budgets []map[string]any
+	assert.False(t, toolText(t, resps["4"], &budgets))
+	assert.Equal(t, "2024-02-29", f.budgets.EndDate.String())
+	require.Len(t, budgets, 1)
+	assert.Equal(t, "-10", budgets[0]["remaining"])
+
+	// Write tools are not offered without the write scope.
+	assert.Equal(t, float64(codeInvalidParams), resps["5"]["error"].(map[string]any)["code"])
+	assert.Empty(t, f.updated)
+
+	assert.True(t, toolText(t, resps[`"six"`], nil))
+	assert.Equal(t, float64(codeMethodNotFound), resps["7"]["error"].(map[string]any)["code"])
+	assert.Equal(t, float64(codeParseError), resps["null"]["error"].(map[string]any)["code"])
+}
+
+func TestServerWriteScope(t *testing.T) {
+	f := &fakeClient{updated: map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction{}}
+	s := NewServer(f, Options{Scopes: []Scope{ScopeRead, ScopeWrite}})
+	assert.Contains(t, s.Tools(), "update_transaction_category")
+
+	resps := exchange(t, s,
+		`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "update_transaction_category", "arguments": {"transaction_id": 2, "category_id": 3}}}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "update_transaction_category", "arguments": {"transaction_id": 3, "category_id": 1}}}`,
+	)
+
+	var res map[string]any
+	assert.False(t, toolText(t, resps["1"], &res))
+	assert.Equal(t, "Coffee", res["category"])
+	require.Contains(t, f.updated, lunchmoney.TransactionID(2))
+	assert.Equal(t, lunchmoney.CategoryID(3), *f.updated[2].CategoryID)
+
+	assert.True(t, toolText(t, resps["2"], nil))
+	assert.NotContains(t, f.updated, lunchmoney.TransactionID(3))
+
+	_, err := ParseScope("admin")
+	assert.Error(t, err)
+}
@@ -0,0 +1,266 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// tool is an operation offered to the assistant.
+type tool struct {
+	name        string
+	description string
+	scope       Scope
+	schema      json.RawMessage
+	call        func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// maxSearchResults caps search_transactions, so a broad query does not flood
+// the assistant's context.
+const maxSearchResults = 500
+
+func (s *Server) allTools() []*tool {
+	return []*tool{
+		{
+			name:        "search_transactions",
+			description: "Search transactions in a date range, optionally by text in the payee or notes and by category. Amounts are positive for spending and negative for income.",
+			scope:       ScopeRead,
+			schema: json.RawMessage(`{"type": "object", "properties": {
+				"start_date": {"type": "string", "format": "date", "description": "first date, YYYY-MM-DD"},
+				"end_date": {"type": "string", "format": "date", "description": "last date, YYYY-MM-DD"},
+				"query": {"type": "string", "description": "case-insensitive text to find in the payee or notes"},
+				"category_id": {"type": "integer"},
+				"limit": {"type": "integer", "minimum": 1, "maximum": 500, "description": "defaults to 50"}
+			}, "required": ["start_date", "end_date"]}`),
+			call: s.searchTransactions,
+		},
+		{
+			name:        "get_budgets",
+			description: "Get the budgeted and spent amounts of every category for a month, in the primary currency.",
+			scope:       ScopeRead,
+			schema: json.RawMessage(`{"type": "object", "properties": {
+				"month": {"type": "string", "pattern": "^[0-9]{4}-[0-9]{2}$", "description": "YYYY-MM"}
+			}, "required": ["month"]}`),
+			call: s.getBudgets,
+		},
+		{
+			name:        "list_categories",
+			description: "List the categories and category groups of the budget.",
+			scope:       ScopeRead,
+			schema:      json.RawMessage(`{"type": "object", "properties": {}}`),
+			call:        s.listCategories,
+		},
+		{
+			name:        "update_transaction_category",
+			description: "Set the category of a transaction. The category must exist and must not be a group or archived.",
+			scope:       ScopeWrite,
+			schema: json.RawMessage(`{"type": "object", "properties": {
+				"transaction_id": {"type": "integer"},
+				"category_id": {"type": "integer"}
+			}, "required": ["transaction_id", "category_id"]}`),
+			call: s.updateTransactionCategory,
+		},
+	}
+}
+
+// decodeArgs strictly decodes tool arguments, so a misspelled argument is
+// reported instead of silently widening a search.
+func decodeArgs(args json.RawMessage, v any) error {
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(args))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	return nil
+}
+
+type transactionResult struct {
+	ID         lunchmoney.TransactionID `json:"id"`
+	Date       lunchmoney.Date          `json:"date"`
+	Payee      string                   `json:"payee"`
+	Amount     lunchmoney.Decimal       `json:"amount"`
+	Currency   string                   `json:"currency"`
+	CategoryID *lunchmoney.CategoryID   `json:"category_id,omitempty"`
+	Notes      string                   `json:"notes,omitempty"`
+	Status     string                   `json:"status"`
+}
+
+func (s *Server) searchTransactions(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		StartDate  lunchmoney.Date        `json:"start_date"`
+		EndDate    lunchmoney.Date        `json:"end_date"`
+		Query      string                 `json:"query"`
+		CategoryID *lunchmoney.CategoryID `json:"category_id"`
+		Limit      int                    `json:"limit"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	switch {
+	case args.StartDate.IsZero() || args.EndDate.IsZero():
+		return nil, errors.New("start_date and end_date are required")
+	case args.EndDate.Before(args.StartDate):
+		return nil, errors.New("end_date is before start_date")
+	case args.Limit < 0 || args.Limit > maxSearchResults:
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxSearchResults)
+	case args.Limit == 0:
+		args.Limit = 50
+	}
+
+	txns, err := s.client.GetAllTransactions(ctx, &lunchmoney.TransactionFilters{StartDate: &args.StartDate, EndDate: &args.EndDate}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var preds []lunchmoney.Predicate
+	if q := strings.TrimSpace(args.Query); q != "" {
+		preds = append(preds, lunchmoney.Or(lunchmoney.PayeeContains(q), lunchmoney.NotesContain(q)))
+	}
+	if args.CategoryID != nil {
+		preds = append(preds, lunchmoney.InCategory(*args.CategoryID))
+	}
+	txns = lunchmoney.Filter(txns, lunchmoney.And(preds...))
+
+	ret := struct {
+		Transactions []transactionResult `json:"transactions"`
+		Total        int                 `json:"total"`
+		Truncated    bool                `json:"truncated,omitempty"`
+	}{Transactions: []transactionResult{}, Total: len(txns)}
+	for _, t := range txns {
+		if len(ret.Transactions) == args.Limit {
+			ret.Truncated = true
+			break
+		}
+		ret.Transactions = append(ret.Transactions, transactionResult{
+			ID:         t.ID,
+			Date:       t.Date,
+			Payee:      t.Payee,
+			Amount:     t.Amount,
+			Currency:   t.Currency,
+			CategoryID: t.CategoryID,
+			Notes:      t.Notes,
+			Status:     t.Status,
+		})
+	}
+
+	return ret, nil
+}
+
+func (s *Server) getBudgets(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		Month string `json:"month"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	m, err := time.Parse("2006-01", args.Month)
+	if err != nil {
+		return nil, fmt.Errorf("month %q is not YYYY-MM", args.Month)
+	}
+	start := lunchmoney.DateOf(m)
+	end := start.AddMonths(1).AddDays(-1)
+
+	budgets, err := s.client.GetBudgets(ctx, &lunchmoney.BudgetFilters{StartDate: start, EndDate: end})
+	if err != nil {
+		return nil, err
+	}
+
+	type budgetResult struct {
+		CategoryID   lunchmoney.CategoryID `json:"category_id"`
+		Category     string                `json:"category"`
+		Group        string                `json:"group,omitempty"`
+		IsIncome     bool                  `json:"is_income,omitempty"`
+		Budgeted     *lunchmoney.Decimal   `json:"budgeted,omitempty"`
+		Spent        *lunchmoney.Decimal   `json:"spent,omitempty"`
+		Remaining    *lunchmoney.Decimal   `json:"remaining,omitempty"`
+		Transactions int                   `json:"transactions"`
+	}
+	ret := []budgetResult{}
+	for _, b := range budgets {
+		r := budgetResult{CategoryID: b.CategoryID, Category: b.CategoryName, Group: b.CategoryGroupName, IsIncome: b.IsIncome}
+		if bd, ok := b.Month(start); ok {
+			remaining := bd.Remaining()
+			r.Budgeted, r.Spent, r.Remaining = &bd.BudgetToBase, &bd.SpendingToBase, &remaining
+			r.Transactions = bd.NumTransactions
+		}
+		ret = append(ret, r)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) listCategories(ctx context.Context, raw json.RawMessage) (any, error) {
+	if err := decodeArgs(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	cats, err := s.client.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type categoryResult struct {
+		ID          lunchmoney.CategoryID  `json:"id"`
+		Name        string                 `json:"name"`
+		Description string                 `json:"description,omitempty"`
+		IsGroup     bool                   `json:"is_group,omitempty"`
+		GroupID     *lunchmoney.CategoryID `json:"group_id,omitempty"`
+		IsIncome    bool                   `json:"is_income,omitempty"`
+		Archived    bool                   `json:"archived,omitempty"`
+	}
+	ret := make([]categoryResult, len(cats))
+	for i, c := range cats {
+		ret[i] = categoryResult{ID: c.ID, Name: c.Name, Description: c.Description, IsGroup: c.IsGroup, GroupID: c.GroupID, IsIncome: c.IsIncome, Archived: c.Archived}
+	}
+
+	return ret, nil
+}
+
+func (s *Server) updateTransactionCategory(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		TransactionID lunchmoney.TransactionID `json:"transaction_id"`
+		CategoryID    lunchmoney.CategoryID    `json:"category_id"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if args.TransactionID == 0 || args.CategoryID == 0 {
+		return nil, errors.New("transaction_id and category_id are required")
+	}
+
+	cats, err := s.client.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cat *lunchmoney.Category
+	for _, c := range cats {
+		if c.ID == args.CategoryID {
+			cat = c
+			break
+		}
+	}
+	switch {
+	case cat == nil:
+		return nil, fmt.Errorf("category %d does not exist", args.CategoryID)
+	case cat.IsGroup:
+		return nil, fmt.Errorf("category %d (%s) is a group", cat.ID, cat.Name)
+	case cat.Archived:
+		return nil, fmt.Errorf("category %d (%s) is archived", cat.ID, cat.Name)
+	}
+
+	resp, err := s.client.UpdateTransaction(ctx, args.TransactionID, &lunchmoney.UpdateTransaction{CategoryID: &args.CategoryID})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"updated": resp.Updated, "transaction_id": args.TransactionID, "category": cat.Name}, nil
+}
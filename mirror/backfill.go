@@ -0,0 +1,74 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// BackfillOptions configure a Backfill run.
+type BackfillOptions struct {
+	// Start and End bound the history to pull, inclusive.
+	Start time.Time
+	End   time.Time
+	// ShardBy is the size of each batch. Defaults to lunchmoney.PeriodMonth.
+	ShardBy lunchmoney.Period
+	// Resume, if set, is the Next date of the last Progress reported by an
+	// interrupted run. Shards ending before it are skipped.
+	Resume *time.Time
+	// Progress, if set, is called after each shard is stored.
+	Progress func(BackfillProgress)
+}
+
+// BackfillProgress reports the state of a Backfill run after a shard.
+type BackfillProgress struct {
+	// Shard is the range that was just pulled.
+	Shard lunchmoney.DateRange
+	// Done and Total count shards, including skipped ones.
+	Done  int
+	Total int
+	// Transactions is the number of transactions the shard contained.
+	Transactions int
+	// Next is the first date not yet pulled. Persist it and pass it back as
+	// BackfillOptions.Resume to continue after an interruption.
+	Next time.Time
+}
+
+// Backfill pulls the complete history between opts.Start and opts.End into
+// the mirror in sequential batches. It is meant for first-time setup of a
+// local copy; use Sync for incremental updates afterwards. If the run fails
+// part-way, everything up to the last reported progress is kept and the run
+// can be resumed from there.
+func (m *Mirror) Backfill(ctx context.Context, opts BackfillOptions) error {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "mirror.backfill")
+
+	shards, err := lunchmoney.SplitRange(opts.Start, opts.End, opts.ShardBy)
+	if err != nil {
+		return fmt.Errorf("backfill: %w", err)
+	}
+
+	for i, s := range shards {
+		if opts.Resume != nil && s.End.Before(*opts.Resume) {
+			continue
+		}
+
+		res, err := m.Sync(ctx, s.Start, s.End)
+		if err != nil {
+			return fmt.Errorf("backfill: %w", err)
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(BackfillProgress{
+				Shard:        s,
+				Done:         i + 1,
+				Total:        len(shards),
+				Transactions: len(res.Upserted),
+				Next:         s.End.AddDate(0, 0, 1),
+			})
+		}
+	}
+
+	return nil
+}
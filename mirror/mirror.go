@@ -0,0 +1,205 @@
+// Package mirror keeps a local copy of Lunch Money transactions up to date by
+// re-reading date windows from the API.
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/store"
+)
+
+// Fetcher is the subset of *lunchmoney.Client the mirror needs.
+type Fetcher interface {
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+}
+
+// Record is a mirrored transaction plus local bookkeeping.
+type Record struct {
+	Transaction *lunchmoney.Transaction `json:"transaction"`
+	// SeenAt is the last time the transaction was returned by the API.
+	SeenAt time.Time `json:"seen_at"`
+	// Deleted is set once a sync of a window containing the transaction's
+	// date no longer returned it, because it was deleted or merged into a
+	// transaction group.
+	Deleted   bool      `json:"deleted"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Tombstones is the entity a mirror opened with Open keeps the records of
+// deleted transactions under. Live transactions are kept under
+// store.Transactions.
+const Tombstones store.Entity = "transaction_tombstones"
+
+// SyncResult describes what a Sync call changed.
+type SyncResult struct {
+	Upserted []lunchmoney.TransactionID
+	Deleted  []lunchmoney.TransactionID
+}
+
+// Mirror is a mirror of the transactions of one budget, kept in memory and,
+// if opened with Open, in a store. It is safe for concurrent use.
+type Mirror struct {
+	fetcher Fetcher
+	store   store.Store
+	now     func() time.Time
+
+	mu      sync.RWMutex
+	records map[lunchmoney.TransactionID]*Record
+}
+
+// New creates an empty mirror that reads from f.
+func New(f Fetcher) *Mirror {
+	return &Mirror{
+		fetcher: f,
+		now:     time.Now,
+		records: map[lunchmoney.TransactionID]*Record{},
+	}
+}
+
+// Open creates a mirror that reads from f and keeps its records in s, loading
+// the ones a previous run left there. Each Sync saves the transactions it
+// fetched and replaces the ones it finds deleted with a tombstone, so a
+// deletion is not undone by the next run.
+func Open(ctx context.Context, f Fetcher, s store.Store) (*Mirror, error) {
+	m := New(f)
+	m.store = s
+
+	txns, err := store.LoadTransactions(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("load mirror: %w", err)
+	}
+	for _, t := range txns {
+		m.records[t.ID] = &Record{Transaction: t}
+	}
+
+	dead, err := store.ListJSON[Record](ctx, s, Tombstones)
+	if err != nil {
+		return nil, fmt.Errorf("load mirror: %w", err)
+	}
+	for _, r := range dead {
+		m.records[r.Transaction.ID] = r
+	}
+
+	return m, nil
+}
+
+// Sync fetches every transaction dated between start and end (inclusive) and
+// reconciles the mirror with the result. Transactions returned by the API are
+// inserted or updated. Local transactions dated inside the window that the
+// API no longer returns are marked deleted rather than left stale forever.
+func (m *Mirror) Sync(ctx context.Context, start, end time.Time) (*SyncResult, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "mirror.sync")
+
+	from := lunchmoney.DateOf(start)
+	to := lunchmoney.DateOf(end)
+	if to.Before(from) {
+		return nil, fmt.Errorf("end date %s is before start date %s", to, from)
+	}
+
+	txns, err := m.fetcher.GetAllTransactions(ctx, &lunchmoney.TransactionFilters{StartDate: &from, EndDate: &to}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sync %s to %s: %w", from, to, err)
+	}
+
+	now := m.now()
+	res := &SyncResult{}
+	seen := make(map[lunchmoney.TransactionID]struct{}, len(txns))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var revived []lunchmoney.TransactionID
+	for _, t := range txns {
+		seen[t.ID] = struct{}{}
+		if old, ok := m.records[t.ID]; ok && old.Deleted {
+			revived = append(revived, t.ID)
+		}
+		m.records[t.ID] = &Record{Transaction: t, SeenAt: now}
+		res.Upserted = append(res.Upserted, t.ID)
+	}
+
+	for id, r := range m.records {
+		if _, ok := seen[id]; ok || r.Deleted {
+			continue
+		}
+
+		if d := r.Transaction.Date; d.Before(from) || d.After(to) {
+			continue
+		}
+
+		r.Deleted = true
+		r.DeletedAt = now
+		res.Deleted = append(res.Deleted, id)
+	}
+	sort.Slice(res.Deleted, func(i, j int) bool { return res.Deleted[i] < res.Deleted[j] })
+
+	if err := m.persist(ctx, txns, revived, res.Deleted); err != nil {
+		return nil, fmt.Errorf("sync %s to %s: %w", from, to, err)
+	}
+
+	return res, nil
+}
+
+// persist writes the outcome of a sync to the store, if there is one.
+// m.mu must be held.
+func (m *Mirror) persist(ctx context.Context, txns []*lunchmoney.Transaction, revived, deleted []lunchmoney.TransactionID) error {
+	if m.store == nil {
+		return nil
+	}
+
+	if err := store.SaveTransactions(ctx, m.store, txns); err != nil {
+		return err
+	}
+	for _, id := range revived {
+		if err := m.store.Delete(ctx, Tombstones, store.Key(id)); err != nil {
+			return err
+		}
+	}
+	for _, id := range deleted {
+		if err := store.PutJSON(ctx, m.store, Tombstones, store.Key(id), m.records[id]); err != nil {
+			return err
+		}
+		if err := m.store.Delete(ctx, store.Transactions, store.Key(id)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get returns the record for the transaction with the given ID.
+func (m *Mirror) Get(id lunchmoney.TransactionID) (*Record, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	r, ok := m.records[id]
+	return r, ok
+}
+
+// Transactions returns the transactions that are not marked deleted, ordered
+// by date and then ID.
+func (m *Mirror) Transactions() []*lunchmoney.Transaction {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make([]*lunchmoney.Transaction, 0, len(m.records))
+	for _, r := range m.records {
+		if !r.Deleted {
+			ret = append(ret, r.Transaction)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Date != ret[j].Date {
+			return ret[i].Date.Before(ret[j].Date)
+		}
+		return ret[i].ID < ret[j].ID
+	})
+
+	return ret
+}
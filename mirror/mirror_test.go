@@ -0,0 +1,141 @@
+package mirror
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	txns []*lunchmoney.Transaction
+}
+
+func (f *fakeFetcher) GetAllTransactions(_ context.Context, filters *lunchmoney.TransactionFilters, _ *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	var ret []*lunchmoney.Transaction
+	for _, t := range f.txns {
+		if !t.Date.Before(*filters.StartDate) && !t.Date.After(*filters.EndDate) {
+			ret = append(ret, t)
+		}
+	}
+	return ret, nil
+}
+
+func TestSyncMarksMissingTransactionsDeleted(t *testing.T) {
+	f := &fakeFetcher{txns: []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-01-05")},
+		{ID: 2, Date: lunchmoney.MustParseDate("2024-01-10")},
+		{ID: 3, Date: lunchmoney.MustParseDate("2024-02-01")},
+	}}
+	m := New(f)
+	ctx := context.Background()
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	res, err := m.Sync(ctx, jan, feb)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []lunchmoney.TransactionID{1, 2, 3}, res.Upserted)
+	assert.Empty(t, res.Deleted)
+
+	// Transaction 2 is deleted upstream; 3 falls outside the next window.
+	f.txns = []*lunchmoney.Transaction{{ID: 1, Date: lunchmoney.MustParseDate("2024-01-05")}}
+	res, err = m.Sync(ctx, jan, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []lunchmoney.TransactionID{2}, res.Deleted)
+
+	r, ok := m.Get(2)
+	require.True(t, ok)
+	assert.True(t, r.Deleted)
+
+	r, ok = m.Get(3)
+	require.True(t, ok)
+	assert.False(t, r.Deleted)
+
+	ids := []lunchmoney.TransactionID{}
+	for _, txn := range m.Transactions() {
+		ids = append(ids, txn.ID)
+	}
+	assert.Equal(t, []lunchmoney.TransactionID{1, 3}, ids)
+}
+
+func TestOpenKeepsDeletions(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	f := &fakeFetcher{txns: []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-01-05")},
+		{ID: 2, Date: lunchmoney.MustParseDate("2024-01-10")},
+	}}
+
+	s, err := store.OpenDir(dir)
+	require.NoError(t, err)
+	m, err := Open(ctx, f, s)
+	require.NoError(t, err)
+	_, err = m.Sync(ctx, jan, end)
+	require.NoError(t, err)
+
+	f.txns = f.txns[:1]
+	res, err := m.Sync(ctx, jan, end)
+	require.NoError(t, err)
+	assert.Equal(t, []lunchmoney.TransactionID{2}, res.Deleted)
+
+	// After a restart the deletion is still known and the transaction is
+	// gone from the stored transactions.
+	s, err = store.OpenDir(dir)
+	require.NoError(t, err)
+	m, err = Open(ctx, f, s)
+	require.NoError(t, err)
+	r, ok := m.Get(2)
+	require.True(t, ok)
+	assert.True(t, r.Deleted)
+	assert.Len(t, m.Transactions(), 1)
+	stored, err := store.LoadTransactions(ctx, s)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, lunchmoney.TransactionID(1), stored[0].ID)
+
+	// A transaction that comes back loses its tombstone.
+	f.txns = append(f.txns, &lunchmoney.Transaction{ID: 2, Date: lunchmoney.MustParseDate("2024-01-10")})
+	_, err = m.Sync(ctx, jan, end)
+	require.NoError(t, err)
+	m, err = Open(ctx, f, s)
+	require.NoError(t, err)
+	assert.Len(t, m.Transactions(), 2)
+	dead, err := s.List(ctx, Tombstones)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
+
+func TestBackfillResume(t *testing.T) {
+	f := &fakeFetcher{txns: []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.MustParseDate("2024-01-05")},
+		{ID: 2, Date: lunchmoney.MustParseDate("2024-02-10")},
+		{ID: 3, Date: lunchmoney.MustParseDate("2024-03-01")},
+	}}
+	m := New(f)
+
+	resume := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	var progress []BackfillProgress
+	err := m.Backfill(context.Background(), BackfillOptions{
+		Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		Resume:   &resume,
+		Progress: func(p BackfillProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+
+	require.Len(t, progress, 2)
+	assert.Equal(t, 2, progress[0].Done)
+	assert.Equal(t, 3, progress[0].Total)
+	assert.Equal(t, 1, progress[0].Transactions)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), progress[1].Next)
+
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+	assert.Len(t, m.Transactions(), 2)
+}
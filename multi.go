@@ -0,0 +1,156 @@
+package lunchmoney
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// BudgetClient is a named budget of a MultiClient.
+type BudgetClient struct {
+	Name   string
+	Client *Client
+}
+
+// Labeled is a record fetched through a MultiClient, labeled with the
+// budget it came from.
+type Labeled[T any] struct {
+	Budget string
+	Value  T
+}
+
+// MultiClient fans requests out over several budgets, each with its own
+// access token, and merges the results, for example to report across a
+// personal and a shared budget.
+type MultiClient struct {
+	budgets []BudgetClient
+}
+
+// NewMultiClient returns a client for the given budgets. Names must be
+// unique and non-empty; results are merged in the order budgets are given.
+func NewMultiClient(budgets ...BudgetClient) (*MultiClient, error) {
+	seen := map[string]bool{}
+	for _, b := range budgets {
+		switch {
+		case b.Name == "":
+			return nil, errors.New("budget name is empty")
+		case seen[b.Name]:
+			return nil, fmt.Errorf("duplicate budget %q", b.Name)
+		case b.Client == nil:
+			return nil, fmt.Errorf("budget %q has no client", b.Name)
+		}
+		seen[b.Name] = true
+	}
+
+	return &MultiClient{budgets: slices.Clone(budgets)}, nil
+}
+
+// NewMultiClientFromTokens creates a client per access token, keyed by
+// budget name, each configured with opts. Budgets are ordered by name.
+func NewMultiClientFromTokens(tokens map[string]string, opts ...Option) (*MultiClient, error) {
+	var budgets []BudgetClient
+	for name, token := range tokens {
+		c, err := NewClient(token, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("budget %q: %w", name, err)
+		}
+		budgets = append(budgets, BudgetClient{Name: name, Client: c})
+	}
+	slices.SortFunc(budgets, func(a, b BudgetClient) int { return cmp.Compare(a.Name, b.Name) })
+
+	return NewMultiClient(budgets...)
+}
+
+// Budgets returns the budget names in order.
+func (m *MultiClient) Budgets() []string {
+	ret := make([]string, len(m.budgets))
+	for i, b := range m.budgets {
+		ret[i] = b.Name
+	}
+
+	return ret
+}
+
+// Client returns the client of the named budget.
+func (m *MultiClient) Client(name string) (*Client, bool) {
+	for _, b := range m.budgets {
+		if b.Name == name {
+			return b.Client, true
+		}
+	}
+
+	return nil, false
+}
+
+// FanOut calls fn for every budget of m concurrently and merges the results
+// in budget order. Failures are joined into the returned error, each naming
+// its budget, and the results of the other budgets are still returned.
+func FanOut[T any](ctx context.Context, m *MultiClient, fn func(ctx context.Context, c *Client) ([]T, error)) ([]Labeled[T], error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]T, len(m.budgets))
+		errs    = make([]error, len(m.budgets))
+	)
+	for i, b := range m.budgets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fn(ctx, b.Client)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("budget %q: %w", b.Name, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	var ret []Labeled[T]
+	for i, b := range m.budgets {
+		for _, v := range results[i] {
+			ret = append(ret, Labeled[T]{Budget: b.Name, Value: v})
+		}
+	}
+
+	return ret, errors.Join(errs...)
+}
+
+// GetUsers returns the user of every budget.
+func (m *MultiClient) GetUsers(ctx context.Context) ([]Labeled[*User], error) {
+	return FanOut(ctx, m, func(ctx context.Context, c *Client) ([]*User, error) {
+		u, err := c.GetUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []*User{u}, nil
+	})
+}
+
+// GetAllTransactions returns the transactions of every budget matching
+// filters, merged in date order. Transactions on the same date keep their
+// budget order.
+func (m *MultiClient) GetAllTransactions(ctx context.Context, filters *TransactionFilters, opts *PaginationOptions) ([]Labeled[*Transaction], error) {
+	ret, err := FanOut(ctx, m, func(ctx context.Context, c *Client) ([]*Transaction, error) {
+		return c.GetAllTransactions(ctx, filters, opts)
+	})
+	slices.SortStableFunc(ret, func(a, b Labeled[*Transaction]) int {
+		return a.Value.Date.Compare(b.Value.Date)
+	})
+
+	return ret, err
+}
+
+// GetAssets returns the manually-managed assets of every budget.
+func (m *MultiClient) GetAssets(ctx context.Context) ([]Labeled[*Asset], error) {
+	return FanOut(ctx, m, func(ctx context.Context, c *Client) ([]*Asset, error) {
+		return c.GetAssets(ctx)
+	})
+}
+
+// GetPlaidAccounts returns the Plaid accounts of every budget.
+func (m *MultiClient) GetPlaidAccounts(ctx context.Context) ([]Labeled[*PlaidAccount], error) {
+	return FanOut(ctx, m, func(ctx context.Context, c *Client) ([]*PlaidAccount, error) {
+		return c.GetPlaidAccounts(ctx)
+	})
+}
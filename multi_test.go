@@ -0,0 +1,62 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case token == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error": "down"}`))
+		case r.URL.Path == "/v1/transactions" && token == "personal":
+			_, _ = w.Write([]byte(`{"transactions": [{"id": 1, "date": "2024-01-03"}, {"id": 2, "date": "2024-01-01"}]}`))
+		case r.URL.Path == "/v1/transactions":
+			_, _ = w.Write([]byte(`{"transactions": [{"id": 1, "date": "2024-01-02"}]}`))
+		case r.URL.Path == "/v1/assets":
+			_, _ = w.Write([]byte(`{"assets": [{"id": 1, "name": "` + token + ` cash"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	m, err := NewMultiClientFromTokens(map[string]string{"shared": "shared", "personal": "personal"}, WithBaseURL(server.URL))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"personal", "shared"}, m.Budgets())
+
+	txns, err := m.GetAllTransactions(context.Background(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, txns, 3)
+	assert.Equal(t, "personal", txns[0].Budget)
+	assert.Equal(t, TransactionID(2), txns[0].Value.ID)
+	assert.Equal(t, "shared", txns[1].Budget)
+	assert.Equal(t, "personal", txns[2].Budget)
+
+	assets, err := m.GetAssets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, assets, 2)
+	assert.Equal(t, "shared cash", assets[1].Value.Name)
+
+	broken, err := NewClient("broken", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	personal, _ := m.Client("personal")
+	m, err = NewMultiClient(BudgetClient{Name: "personal", Client: personal}, BudgetClient{Name: "old", Client: broken})
+	require.NoError(t, err)
+
+	assets, err = m.GetAssets(context.Background())
+	assert.ErrorContains(t, err, `budget "old"`)
+	assert.Len(t, assets, 1)
+
+	_, err = NewMultiClient(BudgetClient{Name: "a", Client: personal}, BudgetClient{Name: "a", Client: broken})
+	assert.ErrorContains(t, err, "duplicate")
+}
@@ -0,0 +1,122 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AccountKind says which API an account in a NetWorth comes from.
+type AccountKind string
+
+// Account kinds.
+const (
+	AccountAsset  AccountKind = "asset"
+	AccountPlaid  AccountKind = "plaid"
+	AccountCrypto AccountKind = "crypto"
+)
+
+// NetWorthAccount is one account's contribution to a NetWorth.
+type NetWorthAccount struct {
+	Kind AccountKind
+	// ID is the asset, Plaid account or crypto ID, depending on Kind.
+	ID          int64
+	Name        string
+	Type        AssetType
+	Balance     Decimal
+	Currency    string
+	ToBase      Decimal
+	IsLiability bool
+}
+
+// NetWorth is a snapshot of every account, with totals in the user's primary
+// currency. Liabilities are amounts owed, as the API reports them: a credit
+// card paid beyond its balance has a negative balance and lowers them.
+type NetWorth struct {
+	Assets      Decimal
+	Liabilities Decimal
+	Net         Decimal
+	// Accounts are ordered with the largest balances first.
+	Accounts []*NetWorthAccount
+}
+
+// NetWorth fetches manual assets, Plaid accounts and crypto balances
+// concurrently and totals them using their to_base values. Closed accounts
+// are left out. The first failed request cancels the others.
+func (c *Client) NetWorth(ctx context.Context) (*NetWorth, error) {
+	ctx = WithDefaultOperation(ctx, "networth")
+
+	var (
+		assets []*Asset
+		plaid  []*PlaidAccount
+		crypto []*Crypto
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) { assets, err = c.GetAssets(gctx); return err })
+	g.Go(func() (err error) { plaid, err = c.GetPlaidAccounts(gctx); return err })
+	g.Go(func() (err error) { crypto, err = c.GetCrypto(gctx); return err })
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("net worth: %w", err)
+	}
+
+	return NewNetWorth(assets, plaid, crypto), nil
+}
+
+// NewNetWorth totals accounts that were already fetched.
+func NewNetWorth(assets []*Asset, plaid []*PlaidAccount, crypto []*Crypto) *NetWorth {
+	nw := &NetWorth{}
+	for _, a := range assets {
+		if a.Status == "closed" {
+			continue
+		}
+		nw.add(&NetWorthAccount{
+			Kind: AccountAsset, ID: int64(a.ID), Name: nameOr(a.DisplayName, a.Name), Type: a.TypeName,
+			Balance: a.Balance, Currency: a.Currency, ToBase: a.ToBase, IsLiability: a.TypeName.IsLiability(),
+		})
+	}
+	for _, p := range plaid {
+		if p.Status == "closed" {
+			continue
+		}
+		t := AssetType(p.Type)
+		nw.add(&NetWorthAccount{
+			Kind: AccountPlaid, ID: int64(p.ID), Name: nameOr(p.DisplayName, p.Name), Type: t,
+			Balance: p.Balance, Currency: p.Currency, ToBase: p.ToBase, IsLiability: t.IsLiability(),
+		})
+	}
+	for _, cr := range crypto {
+		if cr.Status == "closed" {
+			continue
+		}
+		nw.add(&NetWorthAccount{
+			Kind: AccountCrypto, ID: int64(cr.ID), Name: nameOr(cr.DisplayName, cr.Name), Type: AssetCryptocurrency,
+			Balance: cr.Balance, Currency: cr.Currency, ToBase: cr.ToBase,
+		})
+	}
+
+	nw.Net = nw.Assets.Sub(nw.Liabilities)
+	slices.SortStableFunc(nw.Accounts, func(a, b *NetWorthAccount) int {
+		return b.ToBase.Abs().Cmp(a.ToBase.Abs())
+	})
+
+	return nw
+}
+
+func (nw *NetWorth) add(a *NetWorthAccount) {
+	if a.IsLiability {
+		nw.Liabilities = nw.Liabilities.Add(a.ToBase)
+	} else {
+		nw.Assets = nw.Assets.Add(a.ToBase)
+	}
+	nw.Accounts = append(nw.Accounts, a)
+}
+
+func nameOr(display, name string) string {
+	if display != "" {
+		return display
+	}
+
+	return name
+}
@@ -0,0 +1,54 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetWorth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/assets":
+			_, _ = w.Write([]byte(`{"assets": [
+				{"id": 1, "type_name": "cash", "name": "Wallet", "balance": "100.00", "to_base": "100.00", "currency": "usd", "status": "active"},
+				{"id": 2, "type_name": "loan", "name": "Car loan", "balance": "5000.00", "to_base": "5000.00", "currency": "usd", "status": "active"},
+				{"id": 3, "type_name": "cash", "name": "Old", "balance": "9.00", "to_base": "9.00", "currency": "usd", "status": "closed"}
+			]}`))
+		case "/v1/plaid_accounts":
+			_, _ = w.Write([]byte(`{"plaid_accounts": [
+				{"id": 4, "type": "depository", "name": "Checking", "display_name": "Main", "balance": "2500.00", "to_base": "2500.00", "currency": "usd", "status": "active"},
+				{"id": 5, "type": "credit", "name": "Visa", "balance": "300.00", "to_base": "300.00", "currency": "usd", "status": "active"},
+				{"id": 7, "type": "credit", "name": "Amex", "balance": "-40.00", "to_base": "-40.00", "currency": "usd", "status": "active"}
+			]}`))
+		case "/v1/crypto":
+			_, _ = w.Write([]byte(`{"crypto": [
+				{"id": 6, "name": "Bitcoin", "balance": "0.01", "to_base": "650.00", "currency": "btc", "status": "active"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	nw, err := client.NetWorth(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "3250.00", nw.Assets.String())
+	// The overpaid Amex card is a credit, not another 40 owed.
+	assert.Equal(t, "5260.00", nw.Liabilities.String())
+	assert.Equal(t, "-2010.00", nw.Net.String())
+	require.Len(t, nw.Accounts, 6)
+	assert.Equal(t, "Car loan", nw.Accounts[0].Name)
+	assert.True(t, nw.Accounts[0].IsLiability)
+	assert.Equal(t, "Main", nw.Accounts[1].Name)
+	assert.Equal(t, AccountCrypto, nw.Accounts[2].Kind)
+	assert.Equal(t, int64(3), client.Stats()["networth"].Calls)
+}
@@ -0,0 +1,190 @@
+package lunchmoney
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*Client) error
+
+// WithTransport sets the http.RoundTripper used to send requests. The client
+// still adds its authorization header on top of it. Use this to plug in
+// instrumentation, test doubles or a fully custom transport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) error {
+		if rt == nil {
+			return fmt.Errorf("transport must not be nil")
+		}
+
+		a, err := c.authTransport()
+		if err != nil {
+			return err
+		}
+
+		a.T = rt
+		return nil
+	}
+}
+
+// WithBaseURL sends requests to the API at rawURL instead of BaseAPIURL,
+// for example a test server or a proxy such as the gateway package.
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid base URL %q: scheme and host are required", rawURL)
+		}
+
+		c.base = u
+		return nil
+	}
+}
+
+// WithTimeout limits the total time of each request, including reading the
+// response body. Zero means no timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		if d < 0 {
+			return fmt.Errorf("timeout must not be negative, got %s", d)
+		}
+
+		c.httpClient.Timeout = d
+		return nil
+	}
+}
+
+// WithMaxResponseSize fails requests whose response body is larger than n
+// bytes with a ResponseTooLargeError, instead of reading all of it into
+// memory. Zero means no limit.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("max response size must not be negative, got %d", n)
+		}
+
+		c.maxResponseSize = n
+		return nil
+	}
+}
+
+// WithStrictDecoding makes decoding fail when a response has fields the
+// client does not know about, with an error wrapping ErrUnknownField,
+// instead of keeping them in Extra or dropping them. It is meant for canaries
+// that should notice API changes early, such as a scheduled CI job.
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Client) error {
+		c.strictDecoding = strict
+		return nil
+	}
+}
+
+// WithLogger makes the client log each request it sends at debug level.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) error {
+		c.logger = l
+		return nil
+	}
+}
+
+// WithDryRun stops the client from sending requests that modify data. Writes
+// are logged instead and behave as if the API returned an empty object, while
+// reads are still sent.
+func WithDryRun(dryRun bool) Option {
+	return func(c *Client) error {
+		c.dryRun = dryRun
+		return nil
+	}
+}
+
+// WithProxy routes all requests through the proxy at proxyURL, for example
+// "http://proxy.corp.example:3128". By default the client honours the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+
+		t, err := c.httpTransport()
+		if err != nil {
+			return err
+		}
+
+		t.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used when connecting to the API.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) error {
+		t, err := c.httpTransport()
+		if err != nil {
+			return err
+		}
+
+		t.TLSClientConfig = cfg
+		return nil
+	}
+}
+
+// NewTLSConfig returns a TLS configuration that trusts the system roots plus
+// the PEM encoded certificates in caFiles, for environments that intercept
+// TLS with a private certificate authority.
+func NewTLSConfig(caFiles ...string) (*tls.Config, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, f := range caFiles {
+		pem, err := os.ReadFile(f) // #nosec G304 -- the caller chooses which CA files to trust.
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", f)
+		}
+	}
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+func (c *Client) authTransport() (*addAuthHeaderTransport, error) {
+	a, ok := c.httpClient.Transport.(*addAuthHeaderTransport)
+	if !ok {
+		return nil, fmt.Errorf("client transport is %T, not the default authenticating transport", c.httpClient.Transport)
+	}
+
+	return a, nil
+}
+
+// httpTransport returns the *http.Transport underneath the authenticating
+// transport, cloning it first so shared transports are never modified.
+func (c *Client) httpTransport() (*http.Transport, error) {
+	a, err := c.authTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := a.T.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("transport is %T, not *http.Transport", a.T)
+	}
+
+	t = t.Clone()
+	a.T = t
+	return t, nil
+}
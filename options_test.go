@@ -0,0 +1,288 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		_, err := w.Write([]byte(`[]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	called := false
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return http.DefaultTransport.RoundTrip(r)
+	})))
+	require.NoError(t, err)
+
+	_, err = client.GetTags(context.Background())
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestWithMaxResponseSize(t *testing.T) {
+	body := `[{"id": 1, "name": "food"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("chunked") != "" {
+			// Flushing before writing leaves the length unknown.
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithMaxResponseSize(int64(len(body))))
+	require.NoError(t, err)
+
+	tags, err := client.GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+
+	small, err := client.With(WithMaxResponseSize(10))
+	require.NoError(t, err)
+	var tooLarge *ResponseTooLargeError
+	_, err = small.GetTags(context.Background())
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, &ResponseTooLargeError{Path: "/v1/tags", Limit: 10}, tooLarge)
+
+	_, err = small.Get(context.Background(), "/v1/tags", map[string]string{"chunked": "1"})
+	require.ErrorAs(t, err, &tooLarge)
+	_, err = small.Post(context.Background(), "/v1/tags", nil)
+	require.ErrorAs(t, err, &tooLarge)
+	assert.EqualError(t, err, "response of /v1/tags is larger than 10 bytes")
+
+	_, err = NewClient("test-token", WithMaxResponseSize(-1))
+	assert.Error(t, err)
+
+	// Reads after the limit was passed must not report a negative count,
+	// which makes bytes.Buffer.ReadFrom panic.
+	b := &limitedBody{
+		r:   io.LimitReader(iotest.OneByteReader(strings.NewReader(body)), 4),
+		err: &ResponseTooLargeError{Path: "/v1/tags", Limit: 2},
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(b)
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, "[{", buf.String())
+	n, err := b.Read(make([]byte, 8))
+	assert.Equal(t, 0, n)
+	require.ErrorAs(t, err, &tooLarge)
+}
+
+func TestWithProxyAndTLSConfig(t *testing.T) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS13}
+	client, err := NewClient("test-token", WithProxy("http://proxy.example:3128"), WithTLSConfig(cfg))
+	require.NoError(t, err)
+
+	a, err := client.authTransport()
+	require.NoError(t, err)
+	tr, ok := a.T.(*http.Transport)
+	require.True(t, ok)
+	assert.NotSame(t, http.DefaultTransport, tr)
+	assert.Equal(t, cfg, tr.TLSClientConfig)
+
+	req := httptest.NewRequest(http.MethodGet, "https://dev.lunchmoney.app/v1/me", nil)
+	proxy, err := tr.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.example:3128", proxy.Host)
+
+	_, err = NewClient("test-token", WithTransport(roundTripFunc(nil)), WithProxy("http://proxy.example:3128"))
+	assert.Error(t, err)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	_, err := NewTLSConfig("testdata/does-not-exist.pem")
+	assert.Error(t, err)
+
+	_, err = NewTLSConfig("testdata/error.json")
+	assert.Error(t, err)
+}
+
+func TestClientWith(t *testing.T) {
+	writes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writes++
+		_, err := w.Write([]byte(`{"updated": true}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithTimeout(time.Minute))
+	require.NoError(t, err)
+
+	dry, err := client.With(WithDryRun(true), WithTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, client.httpClient.Timeout)
+	assert.Equal(t, time.Second, dry.httpClient.Timeout)
+	assert.False(t, client.dryRun)
+
+	a, err := client.authTransport()
+	require.NoError(t, err)
+	b, err := dry.authTransport()
+	require.NoError(t, err)
+	assert.NotSame(t, a, b)
+	assert.Equal(t, a.T, b.T)
+
+	resp, err := dry.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Notes: Ptr("hi")})
+	require.NoError(t, err)
+	assert.False(t, resp.Updated)
+	assert.Equal(t, 0, writes)
+
+	resp, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Notes: Ptr("hi")})
+	require.NoError(t, err)
+	assert.True(t, resp.Updated)
+	assert.Equal(t, 1, writes)
+}
+
+func TestWithBaseURL(t *testing.T) {
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	assert.Equal(t, BaseAPIURL, client.BaseURL().String())
+
+	client, err = NewClient("test-token", WithBaseURL("http://localhost:8080/"))
+	require.NoError(t, err)
+	u := client.BaseURL()
+	u.Host = "example.com"
+	assert.Equal(t, "http://localhost:8080/", client.BaseURL().String())
+
+	_, err = NewClient("test-token", WithBaseURL("localhost:8080"))
+	assert.Error(t, err)
+	_, err = NewClient("test-token", WithBaseURL("http://%zz"))
+	assert.Error(t, err)
+}
+
+func TestGetValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL+"?debug=1"))
+	require.NoError(t, err)
+
+	body, err := client.GetValues(context.Background(), "/v1/tags", url.Values{"id": {"1", "2"}})
+	require.NoError(t, err)
+	b, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "debug=1&id=1&id=2", string(b))
+}
+
+// TestClientConcurrentUse is meant to be run with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "food"}]`))
+	}))
+	defer server.Close()
+
+	l, err := NewRateLimiter(1000, 10)
+	require.NoError(t, err)
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithLimiter(l))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := client
+			if i%2 == 0 {
+				var err error
+				c, err = client.With(WithTimeout(time.Minute), WithRetries(1, time.Millisecond))
+				assert.NoError(t, err)
+			}
+			for range 5 {
+				_, err := c.GetTags(WithOperation(context.Background(), "tags"))
+				assert.NoError(t, err)
+				_, err = c.Capabilities(context.Background())
+				assert.NoError(t, err)
+				_ = c.Stats()
+				_ = c.BaseURL()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(40), client.Stats()["tags"].Calls)
+	assert.Equal(t, time.Duration(0), client.httpClient.Timeout)
+}
+
+func TestUserAgent(t *testing.T) {
+	var agents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	app, err := client.With(WithApplication("budget-sync/1.2"))
+	require.NoError(t, err)
+
+	_, err = client.GetTags(context.Background())
+	require.NoError(t, err)
+	_, err = app.GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lunchmoney-go/devel", "lunchmoney-go/devel budget-sync/1.2"}, agents)
+
+	_, err = NewClient("test-token", WithApplication(" "))
+	assert.Error(t, err)
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	v2, err := client.With(WithAPIVersion("v2", "/recurring_items/"))
+	require.NoError(t, err)
+	all, err := v2.With(WithAPIVersion("v3"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, c := range []*Client{client, v2, all} {
+		_, err = c.GetTags(ctx)
+		require.NoError(t, err)
+		_, err = c.GetRecurringItems(ctx, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{
+		"/v1/tags", "/v1/recurring_items",
+		"/v1/tags", "/v2/recurring_items",
+		"/v3/tags", "/v2/recurring_items",
+	}, paths)
+
+	for _, opt := range []Option{WithAPIVersion(""), WithAPIVersion("v2/x"), WithAPIVersion("v2", "transactions/group")} {
+		_, err = NewClient("test-token", opt)
+		assert.Error(t, err)
+	}
+}
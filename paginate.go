@@ -0,0 +1,144 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// DefaultPageSize is the number of transactions requested per page by
+	// GetAllTransactions when no page size is given.
+	DefaultPageSize = 500
+	// DefaultPageOverlap is the number of rows each page re-reads from the
+	// end of the previous one when no overlap is given.
+	DefaultPageOverlap = 25
+)
+
+// PaginationOptions control how GetAllTransactions walks through pages.
+type PaginationOptions struct {
+	// PageSize is the limit sent with each request.
+	PageSize int64
+	// Overlap is how many rows of the previous page are fetched again. Up to
+	// this many rows can be deleted between two page requests without rows
+	// being skipped. Rows inserted mid-pagination shift later rows forward
+	// and are removed as duplicates by ID.
+	Overlap int64
+}
+
+func (o *PaginationOptions) withDefaults() (PaginationOptions, error) {
+	ret := PaginationOptions{PageSize: DefaultPageSize, Overlap: DefaultPageOverlap}
+	if o != nil {
+		if o.PageSize > 0 {
+			ret.PageSize = o.PageSize
+		}
+
+		if o.Overlap > 0 {
+			ret.Overlap = o.Overlap
+		}
+	}
+
+	if ret.Overlap >= ret.PageSize {
+		return ret, fmt.Errorf("overlap %d must be smaller than page size %d", ret.Overlap, ret.PageSize)
+	}
+
+	return ret, nil
+}
+
+// ListOptions select one page of a paginated endpoint.
+type ListOptions struct {
+	// Limit is the maximum number of rows returned. Zero leaves it to the
+	// API's default.
+	Limit int64
+	// Offset is the number of rows skipped.
+	Offset int64
+}
+
+// Page is one page of results of a paginated endpoint.
+type Page[T any] struct {
+	Items []*T
+	// Offset and Limit are the options the page was requested with.
+	Offset int64
+	Limit  int64
+	// Returned is the number of rows the API returned. It can be larger
+	// than len(Items) when invalid records were skipped.
+	Returned int
+	// HasMore reports whether rows may follow this page. It is taken from
+	// the response if the API reports it and otherwise assumed when the
+	// page is full.
+	HasMore bool
+}
+
+// Next returns the options of the page after p.
+func (p *Page[T]) Next() ListOptions {
+	return ListOptions{Limit: p.Limit, Offset: p.Offset + int64(p.Returned)}
+}
+
+func newPage[T any](items []*T, returned int, opts ListOptions, hasMore *bool) *Page[T] {
+	ret := &Page[T]{Items: items, Offset: opts.Offset, Limit: opts.Limit, Returned: returned}
+	if hasMore != nil {
+		ret.HasMore = *hasMore
+	} else {
+		ret.HasMore = opts.Limit > 0 && int64(returned) >= opts.Limit
+	}
+
+	return ret
+}
+
+// paginate lists every row through list, requesting overlapping pages as
+// described by PaginationOptions and dropping rows already seen by key.
+func paginate[T any, K comparable](ctx context.Context, opts *PaginationOptions, list func(ctx context.Context, opts ListOptions) (*Page[T], error), key func(*T) K) ([]*T, error) {
+	o, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*T
+	seen := map[K]struct{}{}
+	for offset := int64(0); ; offset += o.PageSize - o.Overlap {
+		page, err := list(ctx, ListOptions{Limit: o.PageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("page at offset %d: %w", offset, err)
+		}
+
+		for _, item := range page.Items {
+			k := key(item)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			ret = append(ret, item)
+		}
+
+		if !page.HasMore {
+			return ret, nil
+		}
+	}
+}
+
+// ListTransactions retrieves one page of the transactions matching filters.
+// The Offset and Limit fields of filters are replaced by opts.
+func (c *Client) ListTransactions(ctx context.Context, filters *TransactionFilters, opts ListOptions) (*Page[Transaction], error) {
+	f := TransactionFilters{}
+	if filters != nil {
+		f = *filters
+	}
+	f.Offset, f.Limit = &opts.Offset, nil
+	if opts.Limit > 0 {
+		f.Limit = &opts.Limit
+	}
+
+	return c.getTransactions(ctx, &f)
+}
+
+// GetAllTransactions retrieves every transaction matching filters by
+// requesting consecutive pages until one reports no more rows. Pages
+// overlap and results are deduplicated by ID, so transactions inserted or
+// deleted while paginating do not cause rows to be skipped or returned twice.
+// The Offset and Limit fields of filters are ignored.
+func (c *Client) GetAllTransactions(ctx context.Context, filters *TransactionFilters, opts *PaginationOptions) ([]*Transaction, error) {
+	list := func(ctx context.Context, o ListOptions) (*Page[Transaction], error) {
+		return c.ListTransactions(ctx, filters, o)
+	}
+
+	return paginate(ctx, opts, list, func(t *Transaction) TransactionID { return t.ID })
+}
@@ -0,0 +1,98 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllTransactions(t *testing.T) {
+	rows := []*Transaction{}
+	for i := TransactionID(1); i <= 10; i++ {
+		rows = append(rows, &Transaction{ID: i})
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		require.NoError(t, err)
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+
+		// Simulate a transaction being deleted after the first page was read.
+		requests++
+		if requests == 2 {
+			rows = append(rows[:1], rows[2:]...)
+		}
+
+		end := min(offset+limit, len(rows))
+		page := []*Transaction{}
+		if offset < len(rows) {
+			page = rows[offset:end]
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(TransactionsResponse{Transactions: page}))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	got, err := client.GetAllTransactions(context.Background(), nil, &PaginationOptions{PageSize: 4, Overlap: 1})
+	require.NoError(t, err)
+
+	ids := []TransactionID{}
+	for _, txn := range got {
+		ids = append(ids, txn.ID)
+	}
+	assert.Equal(t, []TransactionID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids)
+}
+
+func TestPaginationOptionsDefaults(t *testing.T) {
+	var o *PaginationOptions
+	got, err := o.withDefaults()
+	require.NoError(t, err)
+	assert.Equal(t, PaginationOptions{PageSize: DefaultPageSize, Overlap: DefaultPageOverlap}, got)
+
+	_, err = (&PaginationOptions{PageSize: 5, Overlap: 5}).withDefaults()
+	assert.Error(t, err)
+}
+
+func TestListTransactions(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if r.URL.Query().Get("offset") == "2" {
+			_, _ = w.Write([]byte(`{"transactions": [{"id": 3}], "has_more": false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"transactions": [{"id": 1}, {"id": 2}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	filters := &TransactionFilters{CategoryID: Ptr(CategoryID(4)), Offset: Ptr(int64(50))}
+	page, err := client.ListTransactions(context.Background(), filters, ListOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, 2, page.Returned)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, ListOptions{Limit: 2, Offset: 2}, page.Next())
+
+	page, err = client.ListTransactions(context.Background(), filters, page.Next())
+	require.NoError(t, err)
+	assert.Equal(t, TransactionID(3), page.Items[0].ID)
+	assert.False(t, page.HasMore)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, url.Values{"category_id": {"4"}, "offset": {"0"}, "limit": {"2"}}, queries[0])
+	assert.Equal(t, "2", queries[1].Get("offset"))
+}
@@ -0,0 +1,131 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Period is the size of the date windows a long range is split into.
+type Period int
+
+const (
+	// PeriodMonth splits a range on calendar month boundaries.
+	PeriodMonth Period = iota
+	// PeriodWeek splits a range into seven day windows.
+	PeriodWeek
+)
+
+// rateLimitBackoff is how long a shard waits before retrying after the API
+// responded with 429 Too Many Requests.
+var rateLimitBackoff = 2 * time.Second
+
+const maxShardAttempts = 3
+
+// DateRange is an inclusive range of dates.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// SplitRange splits the inclusive range [start, end] into consecutive windows
+// of the given period. Times are truncated to dates in the location of start.
+func SplitRange(start, end time.Time, by Period) ([]DateRange, error) {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, start.Location())
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", end.Format(time.DateOnly), start.Format(time.DateOnly))
+	}
+
+	var shards []DateRange
+	for cur := start; !cur.After(end); {
+		var next time.Time
+		switch by {
+		case PeriodMonth:
+			next = time.Date(cur.Year(), cur.Month()+1, 1, 0, 0, 0, 0, cur.Location())
+		case PeriodWeek:
+			next = cur.AddDate(0, 0, 7)
+		default:
+			return nil, fmt.Errorf("unknown period %d", by)
+		}
+
+		last := next.AddDate(0, 0, -1)
+		if last.After(end) {
+			last = end
+		}
+		shards = append(shards, DateRange{Start: cur, End: last})
+		cur = next
+	}
+
+	return shards, nil
+}
+
+// GetTransactionsParallel fetches all transactions between start and end
+// (inclusive) by splitting the range into shards of the given period and
+// fetching up to workers shards concurrently. Shards that hit the API rate
+// limit are retried after a short backoff. The merged result is ordered by
+// shard, so transactions come back in the same order as sequential requests.
+// The first error cancels all outstanding requests and is returned.
+func (c *Client) GetTransactionsParallel(ctx context.Context, start, end time.Time, shardBy Period, workers int) ([]*Transaction, error) {
+	shards, err := SplitRange(start, end, shardBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	results := make([][]*Transaction, len(shards))
+	for i, s := range shards {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			txns, err := c.fetchShard(gctx, s)
+			results[i] = txns
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Shards may have been left out because ctx was cancelled.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var ret []*Transaction
+	for _, r := range results {
+		ret = append(ret, r...)
+	}
+
+	return ret, nil
+}
+
+func (c *Client) fetchShard(ctx context.Context, s DateRange) ([]*Transaction, error) {
+	startDate := DateOf(s.Start)
+	endDate := DateOf(s.End)
+	filters := &TransactionFilters{StartDate: &startDate, EndDate: &endDate}
+
+	for attempt := 1; ; attempt++ {
+		txns, err := c.GetTransactions(ctx, filters)
+		if err == nil {
+			return txns, nil
+		}
+
+		if attempt >= maxShardAttempts || !errors.Is(err, ErrRateLimited) {
+			return nil, fmt.Errorf("shard %s to %s: %w", startDate, endDate, err)
+		}
+
+		if err := c.retryWait(ctx, err, rateLimitBackoff*time.Duration(attempt)); err != nil {
+			return nil, fmt.Errorf("shard %s to %s: %w", startDate, endDate, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRange(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		end     time.Time
+		by      Period
+		want    []DateRange
+		wantErr bool
+	}{
+		{
+			name:  "months",
+			start: day(2024, 1, 15),
+			end:   day(2024, 3, 10),
+			by:    PeriodMonth,
+			want: []DateRange{
+				{Start: day(2024, 1, 15), End: day(2024, 1, 31)},
+				{Start: day(2024, 2, 1), End: day(2024, 2, 29)},
+				{Start: day(2024, 3, 1), End: day(2024, 3, 10)},
+			},
+		},
+		{
+			name:  "weeks",
+			start: day(2024, 1, 1),
+			end:   day(2024, 1, 10),
+			by:    PeriodWeek,
+			want: []DateRange{
+				{Start: day(2024, 1, 1), End: day(2024, 1, 7)},
+				{Start: day(2024, 1, 8), End: day(2024, 1, 10)},
+			},
+		},
+		{
+			name:  "single day",
+			start: day(2024, 5, 5),
+			end:   day(2024, 5, 5),
+			by:    PeriodMonth,
+			want:  []DateRange{{Start: day(2024, 5, 5), End: day(2024, 5, 5)}},
+		},
+		{
+			name:    "end before start",
+			start:   day(2024, 5, 5),
+			end:     day(2024, 5, 4),
+			by:      PeriodMonth,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitRange(tt.start, tt.end, tt.by)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetTransactionsParallel(t *testing.T) {
+	rateLimitBackoff = time.Millisecond
+	var limited atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions", r.URL.Path)
+		start := r.URL.Query().Get("start_date")
+		if start == "2024-02-01" && limited.CompareAndSwap(false, true) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`{"error": "Too many requests"}`))
+			assert.NoError(t, err)
+			return
+		}
+
+		_, err := fmt.Fprintf(w, `{"transactions": [{"date": %q}]}`, start)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	got, err := client.GetTransactionsParallel(
+		context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		PeriodMonth,
+		3,
+	)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, NewDate(2024, 1, 1), got[0].Date)
+	assert.Equal(t, NewDate(2024, 2, 1), got[1].Date)
+	assert.Equal(t, NewDate(2024, 3, 1), got[2].Date)
+	assert.True(t, limited.Load())
+}
+
+func TestGetTransactionsParallelCancels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start_date") == "2024-01-01" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "bad shard"}`))
+			return
+		}
+
+		// The other shards only finish when the failure cancels them.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.GetTransactionsParallel(
+		context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		PeriodMonth,
+		3,
+	)
+	assert.ErrorContains(t, err, "shard 2024-01-01 to 2024-01-31")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,358 @@
+// Package payees indexes the payees of fetched transactions for fuzzy
+// search and for grouping the variants a bank reports for the same
+// merchant, such as "AMZN Mktp US*2K3LL" and "Amazon.com". It is meant for
+// cleaning up payee names and writing rules.
+package payees
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/icco/lunchmoney"
+)
+
+// DefaultAliases map common abbreviations in bank payee strings to the
+// merchant name. Keys and values are lower case words.
+var DefaultAliases = map[string]string{
+	"amzn": "amazon",
+	"amz":  "amazon",
+	"wm":   "walmart",
+	"wmt":  "walmart",
+	"mcd":  "mcdonalds",
+	"sbux": "starbucks",
+	"goog": "google",
+	"msft": "microsoft",
+}
+
+// noise are words that say nothing about the merchant: payment processors,
+// marketplace markers, company suffixes and domains.
+var noise = map[string]bool{
+	"sq": true, "tst": true, "pp": true, "paypal": true, "pos": true, "ach": true,
+	"debit": true, "purchase": true, "mktp": true, "mktplace": true, "marketplace": true,
+	"com": true, "www": true, "inc": true, "llc": true, "ltd": true, "co": true,
+	"us": true, "usa": true,
+}
+
+// minSearchScore is the lowest score Search returns, which keeps out
+// payees that only share a common trigram with the query.
+const minSearchScore = 0.3
+
+// Options configure an Index.
+type Options struct {
+	// Aliases replace words before payees are compared. Defaults to
+	// DefaultAliases.
+	Aliases map[string]string
+	// GroupThreshold is the similarity at which Groups merges payees whose
+	// keys differ. Defaults to 0.7.
+	GroupThreshold float64
+}
+
+// Payee is a distinct payee string and the transactions using it.
+type Payee struct {
+	Name string
+	// Key is the canonical form used for grouping, such as "amazon".
+	Key          string
+	Transactions int
+	// Total adds up the to_base amounts of the transactions.
+	Total lunchmoney.Decimal
+}
+
+// Match is a search result.
+type Match struct {
+	*Payee
+	// Score is between 0 and 1, higher meaning a better match.
+	Score float64
+}
+
+// Group is a set of payees that are probably the same merchant.
+type Group struct {
+	// Key is the canonical form shared by the group.
+	Key          string
+	Payees       []*Payee
+	Transactions int
+	Total        lunchmoney.Decimal
+}
+
+// Index is a searchable set of payees. It is safe for concurrent reads.
+type Index struct {
+	opts     Options
+	payees   []*Payee
+	grams    [][]string
+	byGram   map[string][]int
+	byName   map[string]*Payee
+	keyGrams map[string][]string
+}
+
+// NewIndex indexes the payees of txns. opts may be nil.
+func NewIndex(txns []*lunchmoney.Transaction, opts *Options) *Index {
+	ix := &Index{byGram: map[string][]int{}, byName: map[string]*Payee{}, keyGrams: map[string][]string{}}
+	if opts != nil {
+		ix.opts = *opts
+	}
+	if ix.opts.Aliases == nil {
+		ix.opts.Aliases = DefaultAliases
+	}
+	if ix.opts.GroupThreshold <= 0 {
+		ix.opts.GroupThreshold = 0.7
+	}
+
+	for _, t := range txns {
+		p := ix.byName[t.Payee]
+		if p == nil {
+			p = &Payee{Name: t.Payee, Key: ix.Key(t.Payee)}
+			ix.byName[t.Payee] = p
+			ix.payees = append(ix.payees, p)
+		}
+		p.Transactions++
+		p.Total = p.Total.Add(t.ToBase)
+	}
+	slices.SortFunc(ix.payees, func(a, b *Payee) int { return cmp.Compare(a.Name, b.Name) })
+
+	for i, p := range ix.payees {
+		g := trigrams(Normalize(p.Name))
+		ix.grams = append(ix.grams, g)
+		for _, gram := range g {
+			ix.byGram[gram] = append(ix.byGram[gram], i)
+		}
+		if _, ok := ix.keyGrams[p.Key]; !ok {
+			ix.keyGrams[p.Key] = trigrams(p.Key)
+		}
+	}
+
+	return ix
+}
+
+// Payees returns the indexed payees sorted by name.
+func (ix *Index) Payees() []*Payee {
+	return slices.Clone(ix.payees)
+}
+
+// Key returns the canonical form of a payee: lower case words with
+// processor prefixes, store numbers, domains and similar noise removed, and
+// aliases applied. A payee that is nothing but noise keeps its normalized
+// form.
+func (ix *Index) Key(name string) string {
+	var words []string
+	for _, w := range strings.Fields(Normalize(name)) {
+		if a, ok := ix.opts.Aliases[w]; ok {
+			w = a
+		}
+		if w == "" || noise[w] || strings.ContainsFunc(w, unicode.IsDigit) {
+			continue
+		}
+		words = append(words, w)
+	}
+
+	if len(words) == 0 {
+		return Normalize(name)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Search returns up to limit payees matching query, best first. Payees
+// containing the query score highest; the rest are ranked by trigram
+// similarity, and those scoring below 0.3 are left out.
+func (ix *Index) Search(query string, limit int) []Match {
+	q := Normalize(query)
+	if q == "" {
+		return nil
+	}
+
+	qg := trigrams(q)
+	seen := map[int]bool{}
+	var ret []Match
+	for _, gram := range qg {
+		for _, i := range ix.byGram[gram] {
+			if seen[i] {
+				continue
+			}
+			seen[i] = true
+
+			p := ix.payees[i]
+			score := dice(qg, ix.grams[i])
+			if n := Normalize(p.Name); strings.Contains(n, q) {
+				score = max(score, 0.5+0.5*float64(len(q))/float64(len(n)))
+			}
+			if score >= minSearchScore {
+				ret = append(ret, Match{Payee: p, Score: score})
+			}
+		}
+	}
+
+	sortMatches(ret)
+	if limit > 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
+
+	return ret
+}
+
+// Similar returns the other payees whose canonical keys are at least
+// minScore similar to that of name, best first.
+func (ix *Index) Similar(name string, minScore float64) []Match {
+	key := ix.Key(name)
+	kg := trigrams(key)
+
+	var ret []Match
+	for _, p := range ix.payees {
+		if p.Name == name {
+			continue
+		}
+
+		score := 1.0
+		if p.Key != key {
+			score = dice(kg, ix.keyGrams[p.Key])
+		}
+		if score >= minScore {
+			ret = append(ret, Match{Payee: p, Score: score})
+		}
+	}
+	sortMatches(ret)
+
+	return ret
+}
+
+// Groups returns payees that are probably the same merchant: those sharing a
+// canonical key, merged with groups whose keys are at least
+// Options.GroupThreshold similar. Groups are sorted by transaction count,
+// most first, and only groups of more than one payee are returned.
+func (ix *Index) Groups() []Group {
+	var keys []string
+	for k := range ix.keyGrams {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	parent := make([]int, len(keys))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// Only keys sharing a trigram can be similar, so the shared counts are
+	// gathered from an inverted index rather than comparing every pair.
+	byGram := map[string][]int{}
+	for i, k := range keys {
+		for _, g := range ix.keyGrams[k] {
+			byGram[g] = append(byGram[g], i)
+		}
+	}
+	shared := map[int]int{}
+	for i, k := range keys {
+		clear(shared)
+		for _, g := range ix.keyGrams[k] {
+			for _, j := range byGram[g] {
+				if j > i {
+					shared[j]++
+				}
+			}
+		}
+		for j, n := range shared {
+			size := len(ix.keyGrams[k]) + len(ix.keyGrams[keys[j]])
+			if 2*float64(n)/float64(size) >= ix.opts.GroupThreshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	index := map[string]int{}
+	for i, k := range keys {
+		index[k] = i
+	}
+	groups := map[int]*Group{}
+	for _, p := range ix.payees {
+		root := find(index[p.Key])
+		g := groups[root]
+		if g == nil {
+			g = &Group{Key: keys[root]}
+			groups[root] = g
+		}
+		g.Payees = append(g.Payees, p)
+		g.Transactions += p.Transactions
+		g.Total = g.Total.Add(p.Total)
+	}
+
+	var ret []Group
+	for _, g := range groups {
+		if len(g.Payees) > 1 {
+			ret = append(ret, *g)
+		}
+	}
+	slices.SortFunc(ret, func(a, b Group) int {
+		return cmp.Or(cmp.Compare(b.Transactions, a.Transactions), cmp.Compare(a.Key, b.Key))
+	})
+
+	return ret
+}
+
+func sortMatches(m []Match) {
+	slices.SortFunc(m, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.Transactions, a.Transactions), cmp.Compare(a.Name, b.Name))
+	})
+}
+
+// Similarity returns the Dice coefficient of the character trigrams of the
+// normalized payees, from 0 (nothing in common) to 1 (the same once
+// normalized).
+func Similarity(a, b string) float64 {
+	return dice(trigrams(Normalize(a)), trigrams(Normalize(b)))
+}
+
+// Normalize lower-cases s and collapses everything but letters and digits
+// into single spaces, so "AMAZON.COM*123" and "Amazon.com 123" compare
+// equal.
+func Normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// trigrams returns the distinct character trigrams of s, padded so short
+// strings and word boundaries produce trigrams too.
+func trigrams(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	r := []rune("  " + s + " ")
+	seen := map[string]bool{}
+	var ret []string
+	for i := 0; i+3 <= len(r); i++ {
+		g := string(r[i : i+3])
+		if !seen[g] {
+			seen[g] = true
+			ret = append(ret, g)
+		}
+	}
+
+	return ret
+}
+
+// dice returns the Dice coefficient of two trigram sets.
+func dice(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, g := range a {
+		set[g] = true
+	}
+	shared := 0
+	for _, g := range b {
+		if set[g] {
+			shared++
+		}
+	}
+
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
@@ -0,0 +1,65 @@
+package payees
+
+import (
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex() *Index {
+	var txns []*lunchmoney.Transaction
+	for _, p := range []string{
+		"AMZN Mktp US*2K3LL", "AMZN Mktp US*9Z1AB", "Amazon.com", "Amazon.com",
+		"SQ *BLUE BOTTLE COFFEE", "Blue Bottle Coffee #12",
+		"Whole Foods Market", "Shell Oil 5551234",
+	} {
+		txns = append(txns, &lunchmoney.Transaction{Payee: p, ToBase: lunchmoney.MustParseDecimal("10")})
+	}
+
+	return NewIndex(txns, nil)
+}
+
+func TestKey(t *testing.T) {
+	ix := testIndex()
+	assert.Equal(t, "amazon", ix.Key("AMZN Mktp US*2K3LL"))
+	assert.Equal(t, "amazon", ix.Key("Amazon.com"))
+	assert.Equal(t, "blue bottle coffee", ix.Key("SQ *BLUE BOTTLE COFFEE"))
+	assert.Equal(t, "shell oil", ix.Key("Shell Oil 5551234"))
+	assert.Equal(t, "12345", ix.Key("#12345"))
+}
+
+func TestSearch(t *testing.T) {
+	ix := testIndex()
+
+	got := ix.Search("blue botle", 0)
+	require.NotEmpty(t, got)
+	assert.Equal(t, "blue bottle coffee", got[0].Key)
+	assert.Len(t, ix.Search("coffee", 0), 2)
+	assert.Equal(t, "Amazon.com", ix.Search("amazon", 1)[0].Name)
+	assert.Empty(t, ix.Search("zzzz", 0))
+	assert.Empty(t, ix.Search("  ", 0))
+}
+
+func TestSimilarAndGroups(t *testing.T) {
+	ix := testIndex()
+
+	similar := ix.Similar("Amazon.com", 0.9)
+	require.Len(t, similar, 2)
+	assert.Equal(t, 1.0, similar[0].Score)
+
+	groups := ix.Groups()
+	require.Len(t, groups, 2)
+	assert.Equal(t, "amazon", groups[0].Key)
+	assert.Len(t, groups[0].Payees, 3)
+	assert.Equal(t, 4, groups[0].Transactions)
+	assert.Equal(t, "40", groups[0].Total.String())
+	assert.Equal(t, "blue bottle coffee", groups[1].Key)
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, Similarity("Amazon.com", "AMAZON COM"), 1e-9)
+	assert.InDelta(t, 0.0, Similarity("abc", "xyz"), 1e-9)
+	assert.Greater(t, Similarity("AMZN Mktp", "AMZN Marketplace"), 0.4)
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/Rhymond/go-money"
-	"github.com/go-playground/validator/v10"
 )
 
 // PlaidAccountsResponse is a list plaid accounts response.
@@ -17,50 +16,135 @@ type PlaidAccountsResponse struct {
 
 // PlaidAccount is a single LM Plaid account.
 type PlaidAccount struct {
-	ID                int64     `json:"id"`
-	DateLinked        string    `json:"date_linked"`
-	Name              string    `json:"name"`
-	DisplayName       string    `json:"display_name"`
-	Type              string    `json:"type"`
-	Subtype           string    `json:"subtype"`
-	Mask              string    `json:"mask"`
-	InstitutionName   string    `json:"institution_name"`
-	Status            string    `json:"status"`
-	LastImport        time.Time `json:"last_import"`
-	Balance           string    `json:"balance"`
-	ToBase            float64   `json:"to_base"` // the balance converted to the user's primary currency
-	Currency          string    `json:"currency"`
-	BalanceLastUpdate time.Time `json:"balance_last_update"`
-	Limit             int64     `json:"limit"`
+	ID                PlaidAccountID `json:"id"`
+	DateLinked        Timestamp      `json:"date_linked"`
+	Name              string         `json:"name"`
+	DisplayName       string         `json:"display_name"`
+	Type              string         `json:"type"`
+	Subtype           string         `json:"subtype"`
+	Mask              string         `json:"mask"`
+	InstitutionName   string         `json:"institution_name"`
+	Status            string         `json:"status"`
+	LastImport        Timestamp      `json:"last_import"`
+	Balance           Decimal        `json:"balance"`
+	ToBase            Decimal        `json:"to_base"` // the balance converted to the user's primary currency
+	Currency          string         `json:"currency"`
+	BalanceLastUpdate Timestamp      `json:"balance_last_update"`
+	LastFetch         Timestamp      `json:"last_fetch"`
+	Limit             *Decimal       `json:"limit"` // credit limit, nil if the account has none
+
+	// Extra holds the fields of the Plaid account the client does not know about
+	// yet, so they survive re-encoding.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a, keeping unknown fields in Extra.
+func (a *PlaidAccount) UnmarshalJSON(data []byte) error {
+	type plain PlaidAccount
+	extra, err := decodeExtra(data, (*plain)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a together with the fields in Extra.
+func (a PlaidAccount) MarshalJSON() ([]byte, error) {
+	type plain PlaidAccount
+	return encodeExtra(plain(a), a.Extra)
 }
 
 // ParsedAmount converts the Plaid account balance and currency into a money.Money object.
 // This provides a convenient way to work with account balances using the go-money library's
 // currency handling capabilities. Returns an error if the balance cannot be parsed.
 func (p *PlaidAccount) ParsedAmount() (*money.Money, error) {
-	return ParseCurrency(p.Balance, p.Currency)
+	return p.Balance.Money(p.Currency), nil
+}
+
+// ToBaseMoney is like Transaction.ToBaseMoney for the account's balance.
+func (p *PlaidAccount) ToBaseMoney(primaryCurrency string) *money.Money {
+	return p.ToBase.Money(primaryCurrency)
+}
+
+// ParsedLimit converts the account's credit limit into a money.Money object.
+// It returns nil if the account has no limit.
+func (p *PlaidAccount) ParsedLimit() (*money.Money, error) {
+	if p.Limit == nil {
+		return nil, nil
+	}
+
+	return p.Limit.Money(p.Currency), nil
+}
+
+// LastRefreshed returns when Lunch Money last fetched the account from Plaid,
+// falling back to the last balance update for responses without last_fetch.
+func (p *PlaidAccount) LastRefreshed() time.Time {
+	if !p.LastFetch.IsZero() {
+		return p.LastFetch.Time
+	}
+
+	return p.BalanceLastUpdate.Time
+}
+
+// IsStale reports whether the account has not been refreshed within
+// threshold. Accounts that have never been refreshed are stale.
+func (p *PlaidAccount) IsStale(threshold time.Duration) bool {
+	return p.isStaleAt(time.Now(), threshold)
+}
+
+func (p *PlaidAccount) isStaleAt(now time.Time, threshold time.Duration) bool {
+	last := p.LastRefreshed()
+	return last.IsZero() || now.Sub(last) > threshold
 }
 
 // GetPlaidAccounts retrieves all Plaid-connected accounts from the Lunch Money API.
 // It returns a slice of PlaidAccount objects containing information about each account,
 // including balance, institution information, and status. Returns an error if the request fails.
 func (c *Client) GetPlaidAccounts(ctx context.Context) ([]*PlaidAccount, error) {
-	validate := validator.New()
 	options := map[string]string{}
 
-	body, err := c.Get(ctx, "/v1/plaid_accounts", options)
-	if err != nil {
+	resp := &PlaidAccountsResponse{}
+	if err := c.getJSON(ctx, c.endpoint("plaid_accounts"), options, resp); err != nil {
 		return nil, fmt.Errorf("get plaid accounts: %w", err)
 	}
 
-	resp := &PlaidAccountsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	return validateRecords(ctx, c, resp.PlaidAccounts)
+}
+
+// RefreshPlaidAccounts holds the options of a Plaid refresh. Without a date
+// range Lunch Money picks one; without an account ID every account is
+// refreshed.
+type RefreshPlaidAccounts struct {
+	StartDate      *Date           `json:"start_date,omitempty" validate:"omitnil,datetime=2006-01-02"`
+	EndDate        *Date           `json:"end_date,omitempty" validate:"omitnil,datetime=2006-01-02"`
+	PlaidAccountID *PlaidAccountID `json:"plaid_account_id,omitempty"`
+}
+
+// validate checks the date range; the API ignores either date without the
+// other.
+func (r *RefreshPlaidAccounts) validate() error {
+	return validateDateRange(r.StartDate, r.EndDate, true)
+}
+
+// RefreshPlaidAccounts asks Lunch Money to fetch the latest transactions
+// from Plaid. The fetch happens in the background; it reports whether a
+// refresh was started, which it is not while one is already running or was
+// requested too recently. opts may be nil.
+func (c *Client) RefreshPlaidAccounts(ctx context.Context, opts *RefreshPlaidAccounts) (bool, error) {
+	if opts == nil {
+		opts = &RefreshPlaidAccounts{}
+	}
+	if err := c.validateRequest(ctx, opts); err != nil {
+		return false, err
+	}
+
+	body, err := c.Post(ctx, c.endpoint("plaid_accounts/fetch"), opts)
+	if err != nil {
+		return false, fmt.Errorf("refresh plaid accounts: %w", err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
-		return nil, err
+	var started bool
+	if err := c.decode(body, &started); err != nil {
+		return false, fmt.Errorf("decode response: %w", err)
 	}
 
-	return resp.PlaidAccounts, nil
+	return started, nil
 }
@@ -0,0 +1,59 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPlaidAccounts(t *testing.T) {
+	data, err := os.ReadFile("testdata/plaid.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	accounts, err := client.GetPlaidAccounts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	limit, err := accounts[0].ParsedLimit()
+	require.NoError(t, err)
+	assert.Nil(t, limit)
+
+	limit, err = accounts[1].ParsedLimit()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500000), limit.Amount())
+
+	updated := accounts[1].BalanceLastUpdate.Time
+	assert.False(t, accounts[1].isStaleAt(updated.Add(time.Hour), 24*time.Hour))
+	assert.True(t, accounts[1].isStaleAt(updated.Add(25*time.Hour), 24*time.Hour))
+	assert.True(t, (&PlaidAccount{}).IsStale(time.Hour))
+}
+
+func TestRefreshPlaidAccounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/plaid_accounts/fetch", r.URL.Path)
+		_, _ = w.Write([]byte(`true`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	started, err := client.RefreshPlaidAccounts(context.Background(), nil)
+	require.NoError(t, err)
+	assert.True(t, started)
+}
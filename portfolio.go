@@ -0,0 +1,102 @@
+package lunchmoney
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+)
+
+// allocationPlaces is the precision of portfolio allocation percentages.
+const allocationPlaces = 2
+
+// PortfolioHolding is one crypto account of a Portfolio.
+type PortfolioHolding struct {
+	Crypto *Crypto
+	// Allocation is the holding's share of the portfolio's value, in
+	// percent.
+	Allocation Decimal
+}
+
+// PortfolioPosition is the holdings of one currency.
+type PortfolioPosition struct {
+	// Currency is the lower-case currency code, such as "btc".
+	Currency string
+	// Balance is the sum of the holdings' balances, in Currency.
+	Balance Decimal
+	// ToBase is the position's value in the user's primary currency.
+	ToBase Decimal
+	// Allocation is the position's share of the portfolio's value, in
+	// percent.
+	Allocation Decimal
+	// Holdings are ordered by value, largest first.
+	Holdings []*PortfolioHolding
+}
+
+// Portfolio summarizes crypto balances by currency.
+type Portfolio struct {
+	// ToBase is the portfolio's value in the user's primary currency.
+	ToBase Decimal
+	// Positions are ordered by value, largest first.
+	Positions []*PortfolioPosition
+}
+
+// CryptoPortfolio fetches crypto balances and summarizes them.
+func (c *Client) CryptoPortfolio(ctx context.Context) (*Portfolio, error) {
+	crypto, err := c.GetCrypto(WithDefaultOperation(ctx, "crypto_portfolio"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPortfolio(crypto), nil
+}
+
+// NewPortfolio groups crypto balances that were already fetched by currency
+// and computes each position's and holding's allocation from the to_base
+// values. Closed accounts are left out. Allocations are rounded to two
+// decimal places and are zero when the portfolio has no value.
+func NewPortfolio(crypto []*Crypto) *Portfolio {
+	p := &Portfolio{}
+	byCurrency := map[string]*PortfolioPosition{}
+	for _, cr := range crypto {
+		if cr.Status == "closed" {
+			continue
+		}
+
+		currency := strings.ToLower(cr.Currency)
+		pos, ok := byCurrency[currency]
+		if !ok {
+			pos = &PortfolioPosition{Currency: currency}
+			byCurrency[currency] = pos
+			p.Positions = append(p.Positions, pos)
+		}
+		pos.Balance = pos.Balance.Add(cr.Balance)
+		pos.ToBase = pos.ToBase.Add(cr.ToBase)
+		pos.Holdings = append(pos.Holdings, &PortfolioHolding{Crypto: cr})
+		p.ToBase = p.ToBase.Add(cr.ToBase)
+	}
+
+	for _, pos := range p.Positions {
+		pos.Allocation = allocation(pos.ToBase, p.ToBase)
+		for _, h := range pos.Holdings {
+			h.Allocation = allocation(h.Crypto.ToBase, p.ToBase)
+		}
+		slices.SortStableFunc(pos.Holdings, func(a, b *PortfolioHolding) int {
+			return b.Crypto.ToBase.Cmp(a.Crypto.ToBase)
+		})
+	}
+	slices.SortStableFunc(p.Positions, func(a, b *PortfolioPosition) int {
+		return cmp.Or(b.ToBase.Cmp(a.ToBase), cmp.Compare(a.Currency, b.Currency))
+	})
+
+	return p
+}
+
+// allocation returns part as a percentage of total.
+func allocation(part, total Decimal) Decimal {
+	if total.IsZero() {
+		return Decimal{}
+	}
+
+	return part.Mul(NewDecimal(100, 0)).Div(total, allocationPlaces)
+}
@@ -0,0 +1,38 @@
+package lunchmoney
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPortfolio(t *testing.T) {
+	p := NewPortfolio([]*Crypto{
+		{ID: 1, Name: "Exchange BTC", Currency: "BTC", Balance: MustParseDecimal("0.5"), ToBase: MustParseDecimal("30000")},
+		{ID: 2, Name: "Cold BTC", Currency: "btc", Balance: MustParseDecimal("0.25"), ToBase: MustParseDecimal("15000")},
+		{ID: 3, Name: "ETH", Currency: "eth", Balance: MustParseDecimal("2"), ToBase: MustParseDecimal("5000")},
+		{ID: 4, Name: "Dust", Currency: "doge", Balance: MustParseDecimal("10"), ToBase: MustParseDecimal("1")},
+		{ID: 5, Name: "Old", Currency: "eth", Balance: MustParseDecimal("9"), ToBase: MustParseDecimal("9"), Status: "closed"},
+	})
+
+	assert.Equal(t, "50001", p.ToBase.String())
+	require.Len(t, p.Positions, 3)
+
+	btc := p.Positions[0]
+	assert.Equal(t, "btc", btc.Currency)
+	assert.Equal(t, "0.75", btc.Balance.String())
+	assert.Equal(t, "45000", btc.ToBase.String())
+	assert.Equal(t, "90.00", btc.Allocation.String())
+	require.Len(t, btc.Holdings, 2)
+	assert.Equal(t, CryptoID(1), btc.Holdings[0].Crypto.ID)
+	assert.Equal(t, "60.00", btc.Holdings[0].Allocation.String())
+
+	assert.Equal(t, "eth", p.Positions[1].Currency)
+	assert.Equal(t, "2", p.Positions[1].Balance.String())
+	assert.Equal(t, "0.00", p.Positions[2].Allocation.String())
+
+	empty := NewPortfolio(nil)
+	assert.True(t, empty.ToBase.IsZero())
+	assert.Empty(t, empty.Positions)
+}
@@ -0,0 +1,134 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// TransactionQuery builds TransactionFilters fluently:
+//
+//	filters, err := NewTransactionQuery().Between(start, end).Category(id).Limit(500).Filters()
+//
+// Mistakes such as a negative limit or an end date before the start date are
+// collected and reported by Filters, so a chain never needs intermediate
+// error checks.
+type TransactionQuery struct {
+	f    TransactionFilters
+	errs []error
+}
+
+// NewTransactionQuery starts an empty query, which matches what the API
+// returns without any filters.
+func NewTransactionQuery() *TransactionQuery {
+	return &TransactionQuery{}
+}
+
+// Between limits the query to transactions dated from start to end,
+// inclusive. The API only honours a date range when both ends are given.
+func (q *TransactionQuery) Between(start, end Date) *TransactionQuery {
+	if start.IsZero() || end.IsZero() {
+		q.errs = append(q.errs, errors.New("between: start and end dates are required"))
+		return q
+	}
+
+	if end.Before(start) {
+		q.errs = append(q.errs, fmt.Errorf("between: end date %s is before start date %s", end, start))
+		return q
+	}
+
+	q.f.StartDate = &start
+	q.f.EndDate = &end
+	return q
+}
+
+// Category limits the query to the category with the given ID.
+func (q *TransactionQuery) Category(id CategoryID) *TransactionQuery {
+	q.f.CategoryID = &id
+	return q
+}
+
+// Tag limits the query to transactions with the given tag.
+func (q *TransactionQuery) Tag(id TagID) *TransactionQuery {
+	q.f.TagID = &id
+	return q
+}
+
+// Asset limits the query to a manually-managed asset.
+func (q *TransactionQuery) Asset(id AssetID) *TransactionQuery {
+	q.f.AssetID = &id
+	return q
+}
+
+// PlaidAccount limits the query to a Plaid account.
+func (q *TransactionQuery) PlaidAccount(id PlaidAccountID) *TransactionQuery {
+	q.f.PlaidAccountID = &id
+	return q
+}
+
+// Recurring limits the query to transactions matched to a recurring item.
+func (q *TransactionQuery) Recurring(id RecurringID) *TransactionQuery {
+	q.f.RecurringID = &id
+	return q
+}
+
+// ExternalID limits the query to transactions with the given external ID.
+func (q *TransactionQuery) ExternalID(id string) *TransactionQuery {
+	q.f.ExternalID = &id
+	return q
+}
+
+// Limit caps the number of transactions returned.
+func (q *TransactionQuery) Limit(n int64) *TransactionQuery {
+	if n <= 0 {
+		q.errs = append(q.errs, fmt.Errorf("limit: must be positive, got %d", n))
+		return q
+	}
+
+	q.f.Limit = &n
+	return q
+}
+
+// Offset skips the first n matching transactions.
+func (q *TransactionQuery) Offset(n int64) *TransactionQuery {
+	if n < 0 {
+		q.errs = append(q.errs, fmt.Errorf("offset: must not be negative, got %d", n))
+		return q
+	}
+
+	q.f.Offset = &n
+	return q
+}
+
+// DebitAsNegative asks the API to return debits as negative amounts and
+// credits as positive ones.
+func (q *TransactionQuery) DebitAsNegative() *TransactionQuery {
+	b := true
+	q.f.DebitAsNegative = &b
+	return q
+}
+
+// Filters returns the validated filters, or every mistake made while
+// building the query.
+func (q *TransactionQuery) Filters() (*TransactionFilters, error) {
+	if err := errors.Join(q.errs...); err != nil {
+		return nil, fmt.Errorf("invalid transaction query: %w", err)
+	}
+
+	f := q.f
+	if err := validateRequest(context.Background(), &f); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// Params returns the validated query as GET parameters.
+func (q *TransactionQuery) Params() (map[string]string, error) {
+	f, err := q.Filters()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.ToMap()
+}
@@ -0,0 +1,41 @@
+package lunchmoney
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionQuery(t *testing.T) {
+	params, err := NewTransactionQuery().
+		Between(NewDate(2024, 1, 1), NewDate(2024, 3, 31)).
+		Category(4).
+		Limit(500).
+		DebitAsNegative().
+		Params()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"start_date":        "2024-01-01",
+		"end_date":          "2024-03-31",
+		"category_id":       "4",
+		"limit":             "500",
+		"debit_as_negative": "true",
+	}, params)
+
+	params, err = NewTransactionQuery().Params()
+	require.NoError(t, err)
+	assert.Empty(t, params)
+
+	_, err = NewTransactionQuery().
+		Between(NewDate(2024, 3, 1), NewDate(2024, 1, 1)).
+		Limit(0).
+		Filters()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "before start date")
+	assert.Contains(t, err.Error(), "limit")
+
+	_, err = NewTransactionQuery().ExternalID(strings.Repeat("x", 76)).Filters()
+	require.Error(t, err)
+}
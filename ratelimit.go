@@ -0,0 +1,205 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Priority is the scheduling class of a request waiting on a RateLimiter.
+// Lower values are served first.
+type Priority int
+
+const (
+	// PriorityInteractive is for user-facing requests. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBatch is for background work such as exports and syncs, which
+	// should yield to interactive requests sharing the same client.
+	PriorityBatch
+
+	numPriorities = int(PriorityBatch) + 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context that marks requests made with it as having
+// the given priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority stored in ctx, or
+// PriorityInteractive if none is set.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && int(p) < numPriorities {
+		return p
+	}
+
+	return PriorityInteractive
+}
+
+type waiter struct {
+	priority Priority
+}
+
+// RateLimiter is a token bucket shared by all requests of a client. Waiters
+// are queued per priority class, and a token is always handed to the oldest
+// waiter of the highest priority class, so a large batch job cannot starve
+// interactive requests.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	queues [numPriorities][]*waiter
+	notify chan struct{}
+}
+
+// NewRateLimiter creates a limiter that allows rps requests per second on
+// average with bursts of up to burst requests.
+func NewRateLimiter(rps float64, burst int) (*RateLimiter, error) {
+	if rps <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %v", rps)
+	}
+
+	if burst < 1 {
+		return nil, fmt.Errorf("burst must be at least 1, got %d", burst)
+	}
+
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		notify: make(chan struct{}),
+	}, nil
+}
+
+// WithRateLimit throttles the client's requests to rps per second on average
+// with bursts of up to burst requests, so bulk operations stay under the
+// API's limits instead of running into 429 responses. Clients derived with
+// With share the limiter.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) error {
+		l, err := NewRateLimiter(rps, burst)
+		if err != nil {
+			return err
+		}
+
+		c.limiter = l
+		return nil
+	}
+}
+
+// WithLimiter throttles the client's requests with l, which may be shared
+// with other clients so they stay under one quota together. Requests are
+// scheduled by the Priority attached to their context. A nil l removes the
+// limit.
+func WithLimiter(l *RateLimiter) Option {
+	return func(c *Client) error {
+		c.limiter = l
+		return nil
+	}
+}
+
+// Wait blocks until a token is available for a request of priority p or ctx
+// is done.
+func (l *RateLimiter) Wait(ctx context.Context, p Priority) error {
+	if p < 0 || int(p) >= numPriorities {
+		p = PriorityInteractive
+	}
+
+	w := &waiter{priority: p}
+	l.mu.Lock()
+	l.queues[p] = append(l.queues[p], w)
+	for {
+		l.refill(time.Now())
+		var delay time.Duration
+		if l.head() == w {
+			if l.tokens >= 1 {
+				l.tokens--
+				l.remove(w)
+				l.broadcast()
+				l.mu.Unlock()
+				return nil
+			}
+			delay = max(time.Duration((1-l.tokens)/l.rate*float64(time.Second)), time.Millisecond)
+		}
+
+		notify := l.notify
+		l.mu.Unlock()
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if delay > 0 {
+			timer = time.NewTimer(delay)
+			fire = timer.C
+		}
+
+		select {
+		case <-notify:
+		case <-fire:
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.remove(w)
+			l.broadcast()
+			l.mu.Unlock()
+			return ctx.Err()
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		l.mu.Lock()
+	}
+}
+
+// wait blocks on the client's limiter, if it has one, using the priority
+// carried by ctx.
+func (c *Client) wait(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	if err := c.limiter.Wait(ctx, PriorityFromContext(ctx)); err != nil {
+		return fmt.Errorf("rate limit: %w", err)
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+func (l *RateLimiter) head() *waiter {
+	for _, q := range l.queues {
+		if len(q) > 0 {
+			return q[0]
+		}
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) remove(w *waiter) {
+	q := l.queues[w.priority]
+	for i, v := range q {
+		if v == w {
+			l.queues[w.priority] = append(q[:i], q[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *RateLimiter) broadcast() {
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
@@ -0,0 +1,86 @@
+package lunchmoney
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterPriority(t *testing.T) {
+	l, err := NewRateLimiter(20, 1)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, l.Wait(ctx, PriorityBatch))
+
+	var (
+		mu    sync.Mutex
+		order []Priority
+		wg    sync.WaitGroup
+	)
+	run := func(p Priority) {
+		defer wg.Done()
+		assert.NoError(t, l.Wait(ctx, p))
+		mu.Lock()
+		order = append(order, p)
+		mu.Unlock()
+	}
+
+	wg.Add(3)
+	go run(PriorityBatch)
+	time.Sleep(5 * time.Millisecond)
+	go run(PriorityBatch)
+	time.Sleep(5 * time.Millisecond)
+	go run(PriorityInteractive)
+	wg.Wait()
+
+	assert.Equal(t, []Priority{PriorityInteractive, PriorityBatch, PriorityBatch}, order)
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l, err := NewRateLimiter(0.001, 1)
+	require.NoError(t, err)
+	require.NoError(t, l.Wait(context.Background(), PriorityInteractive))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx, PriorityInteractive), context.DeadlineExceeded)
+	assert.Nil(t, l.head())
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, PriorityInteractive, PriorityFromContext(ctx))
+	assert.Equal(t, PriorityBatch, PriorityFromContext(WithPriority(ctx, PriorityBatch)))
+}
+
+func TestWithRateLimit(t *testing.T) {
+	calls := 0
+	client, err := NewClient("test-token", WithRateLimit(20, 2), WithTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+	})))
+	require.NoError(t, err)
+	require.NotNil(t, client.limiter)
+
+	start := time.Now()
+	for range 3 {
+		_, err := client.GetTags(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, calls)
+	// The burst covers two requests; the third waits for a token.
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	_, err = NewClient("test-token", WithRateLimit(0, 1))
+	assert.Error(t, err)
+	_, err = NewClient("test-token", WithRateLimit(1, 0))
+	assert.Error(t, err)
+}
@@ -4,10 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/Rhymond/go-money"
-	"github.com/go-playground/validator/v10"
 )
 
 // RecurringExpensesResponse is the data struct we get back from a get request.
@@ -17,35 +15,35 @@ type RecurringExpensesResponse struct {
 
 // RecurringExpense is like a transaction, but one that's scheduled to happen.
 type RecurringExpense struct {
-	ID             int64     `json:"id"`
-	StartDate      string    `json:"start_date" validate:"datetime=2006-01-02"`
-	EndDate        string    `json:"end_date" validate:"datetime=2006-01-02"`
-	Cadence        string    `json:"cadence"`
-	Payee          string    `json:"payee"`
-	Amount         string    `json:"amount"`
-	Currency       string    `json:"currency"`
-	CreatedAt      time.Time `json:"created_at"`
-	Description    string    `json:"description"`
-	BillingDate    string    `json:"billing_date"`
-	Type           string    `json:"type"`
-	OriginalName   string    `json:"original_name"`
-	Source         string    `json:"source"`
-	PlaidAccountID int64     `json:"plaid_account_id"`
-	AssetID        int64     `json:"asset_id"`
-	TransactionID  int64     `json:"transaction_id"`
+	ID             RecurringID     `json:"id"`
+	StartDate      Date            `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate        Date            `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Cadence        string          `json:"cadence"`
+	Payee          string          `json:"payee"`
+	Amount         Decimal         `json:"amount"`
+	Currency       string          `json:"currency"`
+	CreatedAt      Timestamp       `json:"created_at"`
+	Description    string          `json:"description"`
+	BillingDate    string          `json:"billing_date"`
+	Type           string          `json:"type"`
+	OriginalName   string          `json:"original_name"`
+	Source         string          `json:"source"`
+	PlaidAccountID *PlaidAccountID `json:"plaid_account_id"`
+	AssetID        *AssetID        `json:"asset_id"`
+	TransactionID  *TransactionID  `json:"transaction_id"`
 }
 
 // ParsedAmount converts the recurring expense's amount and currency into a money.Money object.
 // This provides a convenient way to work with the expense amount using the go-money library's
 // currency handling capabilities. Returns an error if the amount cannot be parsed.
 func (r *RecurringExpense) ParsedAmount() (*money.Money, error) {
-	return ParseCurrency(r.Amount, r.Currency)
+	return r.Amount.Money(r.Currency), nil
 }
 
 // RecurringExpenseFilters are options to pass to the request.
 type RecurringExpenseFilters struct {
-	StartDate       string `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
-	DebitAsNegative bool   `json:"debit_as_negative"`
+	StartDate       Date `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	DebitAsNegative bool `json:"debit_as_negative"`
 }
 
 // ToMap converts the recurring expense filters to a string map to be sent with the request as
@@ -69,10 +67,9 @@ func (r *RecurringExpenseFilters) ToMap() (map[string]string, error) {
 // It returns a slice of RecurringExpense objects or an error if the request fails.
 // The filters parameter can be used to specify date ranges and other criteria.
 func (c *Client) GetRecurringExpenses(ctx context.Context, filters *RecurringExpenseFilters) ([]*RecurringExpense, error) {
-	validate := validator.New()
 	options := map[string]string{}
 	if filters != nil {
-		if err := validate.Struct(filters); err != nil {
+		if err := c.validateRequest(ctx, filters); err != nil {
 			return nil, err
 		}
 
@@ -83,19 +80,96 @@ func (c *Client) GetRecurringExpenses(ctx context.Context, filters *RecurringExp
 		options = maps
 	}
 
-	body, err := c.Get(ctx, "/v1/recurring_expenses", options)
-	if err != nil {
+	resp := &RecurringExpensesResponse{}
+	if err := c.getJSON(ctx, c.endpoint("recurring_expenses"), options, resp); err != nil {
 		return nil, fmt.Errorf("get recurring expenses: %w", err)
 	}
 
-	resp := &RecurringExpensesResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	return validateRecords(ctx, c, resp.RecurringExpenses)
+}
+
+// RecurringItem is a recurring item as returned by /v1/recurring_items, with
+// the occurrences expected in the requested month.
+type RecurringItem struct {
+	ID                RecurringID     `json:"id"`
+	StartDate         Date            `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate           Date            `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Payee             string          `json:"payee"`
+	Amount            Decimal         `json:"amount"`
+	Currency          string          `json:"currency"`
+	ToBase            Decimal         `json:"to_base"`
+	BillingDate       string          `json:"billing_date"`
+	OriginalName      string          `json:"original_name"`
+	Description       string          `json:"description"`
+	Notes             string          `json:"notes"`
+	Source            string          `json:"source"`
+	PlaidAccountID    *PlaidAccountID `json:"plaid_account_id"`
+	AssetID           *AssetID        `json:"asset_id"`
+	CategoryID        *CategoryID     `json:"category_id"`
+	CategoryGroupID   *CategoryID     `json:"category_group_id"`
+	IsIncome          bool            `json:"is_income"`
+	ExcludeFromTotals bool            `json:"exclude_from_totals"`
+	// Granularity and Quantity give the cadence, such as every 2 weeks.
+	Granularity string    `json:"granularity"`
+	Quantity    int       `json:"quantity"`
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+	// Occurrences maps each expected date in the range to the transactions
+	// matched to it.
+	Occurrences             map[string][]*Transaction `json:"occurrences"`
+	TransactionsWithinRange []*Transaction            `json:"transactions_within_range"`
+	MissingDatesWithinRange []Date                    `json:"missing_dates_within_range"`
+}
+
+// RecurringItemFilters scope the recurring items request. The API returns
+// the items expected in the months covered by the range, defaulting to the
+// current month.
+type RecurringItemFilters struct {
+	StartDate       *Date `json:"start_date" validate:"omitnil,datetime=2006-01-02"`
+	EndDate         *Date `json:"end_date" validate:"omitnil,datetime=2006-01-02"`
+	DebitAsNegative *bool `json:"debit_as_negative"`
+}
+
+// ToMap converts the filters to a string map to be sent with the request as
+// GET parameters. If the field is nil, it will not be included in the map.
+func (r *RecurringItemFilters) ToMap() map[string]string {
+	ret := map[string]string{}
+	if r.StartDate != nil {
+		ret["start_date"] = r.StartDate.String()
 	}
 
-	if err := validate.Struct(resp); err != nil {
-		return nil, err
+	if r.EndDate != nil {
+		ret["end_date"] = r.EndDate.String()
+	}
+
+	if r.DebitAsNegative != nil {
+		ret["debit_as_negative"] = fmt.Sprintf("%t", *r.DebitAsNegative)
+	}
+
+	return ret
+}
+
+// validate checks the date window: an end date needs a start date and must
+// not be before it.
+func (r *RecurringItemFilters) validate() error {
+	return validateDateRange(r.StartDate, r.EndDate, false)
+}
+
+// GetRecurringItems retrieves the recurring items expected in the months
+// covered by filters, which may be nil for the current month.
+func (c *Client) GetRecurringItems(ctx context.Context, filters *RecurringItemFilters) ([]*RecurringItem, error) {
+	options := map[string]string{}
+	if filters != nil {
+		if err := c.validateRequest(ctx, filters); err != nil {
+			return nil, err
+		}
+		options = filters.ToMap()
+	}
+
+	var resp []*RecurringItem
+	if err := c.getJSON(ctx, c.endpoint("recurring_items"), options, &resp); err != nil {
+		return nil, fmt.Errorf("get recurring items: %w", err)
 	}
 
-	return resp.RecurringExpenses, nil
+	return validateRecords(ctx, c, resp)
 }
@@ -0,0 +1,56 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecurringItems(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/recurring_items", r.URL.Path)
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`[{
+			"id": 12, "start_date": "2023-01-01", "end_date": null, "payee": "Gym", "amount": "-45.00", "currency": "usd",
+			"to_base": -45, "billing_date": "2023-01-05", "description": null, "source": "manual", "category_id": 3,
+			"granularity": "month", "quantity": 1, "created_at": "2023-01-01T10:00:00.000Z",
+			"occurrences": {"2024-03-05": [{"id": 99, "date": "2024-03-05", "amount": "-45.00"}]},
+			"transactions_within_range": [{"id": 99, "date": "2024-03-05", "amount": "-45.00"}],
+			"missing_dates_within_range": []
+		}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	items, err := client.GetRecurringItems(context.Background(), &RecurringItemFilters{
+		StartDate:       Ptr(MustParseDate("2024-03-01")),
+		EndDate:         Ptr(MustParseDate("2024-03-31")),
+		DebitAsNegative: Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"start_date": {"2024-03-01"}, "end_date": {"2024-03-31"}, "debit_as_negative": {"true"}}, query)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Gym", items[0].Payee)
+	assert.Equal(t, "-45", items[0].ToBase.String())
+	assert.True(t, items[0].EndDate.IsZero())
+	require.Len(t, items[0].Occurrences["2024-03-05"], 1)
+	assert.Equal(t, TransactionID(99), items[0].TransactionsWithinRange[0].ID)
+
+	query = nil
+	_, err = client.GetRecurringItems(context.Background(), &RecurringItemFilters{EndDate: Ptr(MustParseDate("2024-03-31"))})
+	assert.ErrorIs(t, err, ErrValidation)
+	_, err = client.GetRecurringItems(context.Background(), &RecurringItemFilters{
+		StartDate: Ptr(MustParseDate("2024-03-01")),
+		EndDate:   Ptr(MustParseDate("2024-02-01")),
+	})
+	assert.ErrorContains(t, err, "end_date: failed gtefield=start_date")
+	assert.Nil(t, query)
+}
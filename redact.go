@@ -0,0 +1,110 @@
+package lunchmoney
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// redacted replaces secrets in logs and formatted output.
+const redacted = "[REDACTED]"
+
+// SensitiveKeys are the log attribute keys MaskSensitive masks.
+var SensitiveKeys = []string{"payee", "notes"}
+
+// MaskSensitive is a slog.HandlerOptions.ReplaceAttr function masking the
+// payees and notes of transactions logged through their LogValue methods,
+// for logs that must not hold details of the user's spending:
+//
+//	slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{ReplaceAttr: lunchmoney.MaskSensitive})
+func MaskSensitive(_ []string, a slog.Attr) slog.Attr {
+	if slices.Contains(SensitiveKeys, a.Key) && a.Value.Kind() == slog.KindString && a.Value.String() != "" {
+		a.Value = slog.StringValue(redacted)
+	}
+
+	return a
+}
+
+// String describes the transport without its key, so printing the client's
+// HTTP client with %+v does not leak the token. The wrapped transport is
+// described by its type only, since printing its fields would read its
+// connection pool while other goroutines use it.
+func (adt *addAuthHeaderTransport) String() string {
+	return fmt.Sprintf("&{T:%T Key:%s}", adt.T, redacted)
+}
+
+// LogValue implements slog.LogValuer. The access token is never logged.
+func (c *Client) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.Bool("dry_run", c.dryRun)}
+	if c.base != nil {
+		attrs = append(attrs, slog.String("base", c.base.String()))
+	}
+	if a, ok := c.httpClient.Transport.(*addAuthHeaderTransport); ok && a.Key != "" {
+		attrs = append(attrs, slog.String("token", redacted))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer. Payee and notes can be masked with
+// MaskSensitive.
+func (t *Transaction) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int64("id", int64(t.ID)),
+		slog.String("date", t.Date.String()),
+		slog.String("amount", t.Amount.String()),
+		slog.String("currency", t.Currency),
+		slog.String("payee", t.Payee),
+		slog.String("notes", t.Notes),
+		slog.String("status", t.Status),
+	}
+	if t.CategoryID != nil {
+		attrs = append(attrs, slog.Int64("category_id", int64(*t.CategoryID)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer. Payee and notes can be masked with
+// MaskSensitive.
+func (t InsertTransaction) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("date", t.Date.String()),
+		slog.String("amount", t.Amount.String()),
+		slog.String("currency", t.Currency),
+		slog.String("payee", t.Payee),
+		slog.String("notes", t.Notes),
+		slog.String("external_id", t.ExternalID),
+	}
+	if t.CategoryID != nil {
+		attrs = append(attrs, slog.Int64("category_id", int64(*t.CategoryID)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, logging the fields the update sets.
+// Payee and notes can be masked with MaskSensitive.
+func (ut *UpdateTransaction) LogValue() slog.Value {
+	var attrs []slog.Attr
+	if ut.Date != nil {
+		attrs = append(attrs, slog.String("date", ut.Date.String()))
+	}
+	if ut.CategoryID != nil {
+		attrs = append(attrs, slog.Int64("category_id", int64(*ut.CategoryID)))
+	}
+	if ut.Payee != nil {
+		attrs = append(attrs, slog.String("payee", *ut.Payee))
+	}
+	if ut.Notes != nil {
+		attrs = append(attrs, slog.String("notes", *ut.Notes))
+	}
+	if ut.Status != nil {
+		attrs = append(attrs, slog.String("status", *ut.Status))
+	}
+	if ut.ExternalID != nil {
+		attrs = append(attrs, slog.String("external_id", *ut.ExternalID))
+	}
+
+	return slog.GroupValue(attrs...)
+}
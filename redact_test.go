@@ -0,0 +1,46 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRedactsToken(t *testing.T) {
+	client, err := NewClient("secret-token")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("client", "client", client)
+	assert.Contains(t, buf.String(), "client.token=[REDACTED]")
+	assert.Contains(t, buf.String(), "client.base=https://dev.lunchmoney.app/")
+
+	assert.NotContains(t, fmt.Sprintf("%+v", client.httpClient.Transport), "secret-token")
+	assert.NotContains(t, fmt.Sprintf("%+v %v", client, client.httpClient), "secret-token")
+	assert.NotContains(t, buf.String(), "secret-token")
+}
+
+func TestMaskSensitive(t *testing.T) {
+	txn := &Transaction{ID: 3, Date: MustParseDate("2024-02-01"), Amount: MustParseDecimal("9.99"), Payee: "Pharmacy", Notes: "prescription", CategoryID: Ptr(CategoryID(2))}
+	ut := &UpdateTransaction{Notes: Ptr("private"), Status: Ptr("cleared")}
+
+	var plain bytes.Buffer
+	slog.New(slog.NewTextHandler(&plain, nil)).Info("txn", "txn", txn, "update", ut)
+	assert.Contains(t, plain.String(), "txn.payee=Pharmacy")
+	assert.Contains(t, plain.String(), "txn.category_id=2")
+	assert.Contains(t, plain.String(), "update.notes=private")
+	assert.NotContains(t, plain.String(), "update.payee")
+
+	var masked bytes.Buffer
+	slog.New(slog.NewTextHandler(&masked, &slog.HandlerOptions{ReplaceAttr: MaskSensitive})).Info("txn", "txn", txn, "insert", InsertTransaction{Payee: "Clinic"})
+	assert.Contains(t, masked.String(), "txn.payee=[REDACTED]")
+	assert.Contains(t, masked.String(), "txn.notes=[REDACTED]")
+	assert.Contains(t, masked.String(), "insert.payee=[REDACTED]")
+	assert.Contains(t, masked.String(), "insert.notes=\"\"")
+	assert.Contains(t, masked.String(), "txn.amount=9.99")
+	assert.NotContains(t, masked.String(), "Pharmacy")
+}
@@ -0,0 +1,61 @@
+package refdata
+
+import "github.com/icco/lunchmoney"
+
+// Decorated is a transaction together with the names of the category, tags
+// and account it refers to. Names that are unknown to the cache are empty.
+type Decorated struct {
+	*lunchmoney.Transaction
+	CategoryName string
+	TagNames     []string
+	AccountName  string
+}
+
+// Decorate resolves the names referenced by each transaction against the
+// current snapshot. Tag names already present on the transaction are kept.
+func (c *Cache) Decorate(txns []*lunchmoney.Transaction) []*Decorated {
+	ret := make([]*Decorated, len(txns))
+	for i, t := range txns {
+		d := &Decorated{Transaction: t, AccountName: c.AccountName(t)}
+		if t.CategoryID != nil {
+			if cat, ok := c.Category(*t.CategoryID); ok {
+				d.CategoryName = cat.Name
+			}
+		}
+		for _, tag := range t.Tags {
+			name := tag.Name
+			if v, ok := c.Tag(tag.ID); ok {
+				name = v.Name
+			}
+			d.TagNames = append(d.TagNames, name)
+		}
+		ret[i] = d
+	}
+
+	return ret
+}
+
+// AccountName returns the display name of the asset or Plaid account a
+// transaction belongs to, or "" if it is not known.
+func (c *Cache) AccountName(t *lunchmoney.Transaction) string {
+	if t.AssetID != nil {
+		if a, ok := c.Asset(*t.AssetID); ok {
+			return displayName(a.DisplayName, a.Name)
+		}
+	}
+	if t.PlaidAccountID != nil {
+		if a, ok := c.PlaidAccount(*t.PlaidAccountID); ok {
+			return displayName(a.DisplayName, a.Name)
+		}
+	}
+
+	return ""
+}
+
+func displayName(display, name string) string {
+	if display != "" {
+		return display
+	}
+
+	return name
+}
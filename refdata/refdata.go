@@ -0,0 +1,201 @@
+// Package refdata keeps Lunch Money reference data (categories, tags, assets
+// and Plaid accounts) in memory for fast lookups, for example in web servers
+// that resolve names on every request.
+package refdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Source is the subset of *lunchmoney.Client used to load reference data.
+type Source interface {
+	GetCategories(ctx context.Context) ([]*lunchmoney.Category, error)
+	GetTags(ctx context.Context) ([]*lunchmoney.Tag, error)
+	GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error)
+	GetPlaidAccounts(ctx context.Context) ([]*lunchmoney.PlaidAccount, error)
+}
+
+// snapshot is an immutable, fully loaded set of reference data.
+type snapshot struct {
+	categories    map[lunchmoney.CategoryID]*lunchmoney.Category
+	tags          map[lunchmoney.TagID]*lunchmoney.Tag
+	assets        map[lunchmoney.AssetID]*lunchmoney.Asset
+	plaidAccounts map[lunchmoney.PlaidAccountID]*lunchmoney.PlaidAccount
+	categoryNames map[string]*lunchmoney.Category
+	tagNames      map[string]*lunchmoney.Tag
+	loadedAt      time.Time
+}
+
+// Cache holds the most recently loaded reference data. Lookups never block:
+// a refresh builds a complete new snapshot and swaps it in atomically, so
+// readers see either the old or the new data, never a mix.
+type Cache struct {
+	src  Source
+	data atomic.Pointer[snapshot]
+}
+
+// New creates an empty cache backed by src. Call Refresh or Run to load it.
+func New(src Source) *Cache {
+	return &Cache{src: src}
+}
+
+// Refresh loads all reference data from the source concurrently and replaces
+// the cached snapshot. On error the previous snapshot is kept.
+func (c *Cache) Refresh(ctx context.Context) error {
+	var (
+		wg         sync.WaitGroup
+		categories []*lunchmoney.Category
+		tags       []*lunchmoney.Tag
+		assets     []*lunchmoney.Asset
+		plaid      []*lunchmoney.PlaidAccount
+		errs       [4]error
+	)
+
+	wg.Add(4)
+	go func() { defer wg.Done(); categories, errs[0] = c.src.GetCategories(ctx) }()
+	go func() { defer wg.Done(); tags, errs[1] = c.src.GetTags(ctx) }()
+	go func() { defer wg.Done(); assets, errs[2] = c.src.GetAssets(ctx) }()
+	go func() { defer wg.Done(); plaid, errs[3] = c.src.GetPlaidAccounts(ctx) }()
+	wg.Wait()
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return fmt.Errorf("refresh reference data: %w", err)
+	}
+
+	s := &snapshot{
+		categories:    make(map[lunchmoney.CategoryID]*lunchmoney.Category, len(categories)),
+		tags:          make(map[lunchmoney.TagID]*lunchmoney.Tag, len(tags)),
+		assets:        make(map[lunchmoney.AssetID]*lunchmoney.Asset, len(assets)),
+		plaidAccounts: make(map[lunchmoney.PlaidAccountID]*lunchmoney.PlaidAccount, len(plaid)),
+		categoryNames: make(map[string]*lunchmoney.Category, len(categories)),
+		tagNames:      make(map[string]*lunchmoney.Tag, len(tags)),
+		loadedAt:      time.Now(),
+	}
+	for _, v := range categories {
+		s.categories[v.ID] = v
+		if _, ok := s.categoryNames[strings.ToLower(v.Name)]; !ok {
+			s.categoryNames[strings.ToLower(v.Name)] = v
+		}
+	}
+	for _, v := range tags {
+		s.tags[v.ID] = v
+		if _, ok := s.tagNames[strings.ToLower(v.Name)]; !ok {
+			s.tagNames[strings.ToLower(v.Name)] = v
+		}
+	}
+	for _, v := range assets {
+		s.assets[v.ID] = v
+	}
+	for _, v := range plaid {
+		s.plaidAccounts[v.ID] = v
+	}
+
+	c.data.Store(s)
+	return nil
+}
+
+// Run refreshes the cache every interval until ctx is done. Refresh errors
+// are passed to onError, if set, and the cache keeps serving the last good
+// snapshot. Run does not perform an initial refresh; call Refresh first if
+// the cache must be populated before serving.
+func (c *Cache) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.Refresh(ctx); err != nil && onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// LoadedAt returns when the current snapshot was loaded, or the zero time if
+// the cache has never been refreshed.
+func (c *Cache) LoadedAt() time.Time {
+	if s := c.data.Load(); s != nil {
+		return s.loadedAt
+	}
+
+	return time.Time{}
+}
+
+// Category returns the category with the given ID.
+func (c *Cache) Category(id lunchmoney.CategoryID) (*lunchmoney.Category, bool) {
+	s := c.data.Load()
+	if s == nil {
+		return nil, false
+	}
+
+	v, ok := s.categories[id]
+	return v, ok
+}
+
+// CategoryByName returns the category with the given name, compared
+// case-insensitively. If several categories share a name, the first one
+// returned by the API wins.
+func (c *Cache) CategoryByName(name string) (*lunchmoney.Category, bool) {
+	s := c.data.Load()
+	if s == nil {
+		return nil, false
+	}
+
+	v, ok := s.categoryNames[strings.ToLower(name)]
+	return v, ok
+}
+
+// Tag returns the tag with the given ID.
+func (c *Cache) Tag(id lunchmoney.TagID) (*lunchmoney.Tag, bool) {
+	s := c.data.Load()
+	if s == nil {
+		return nil, false
+	}
+
+	v, ok := s.tags[id]
+	return v, ok
+}
+
+// TagByName returns the tag with the given name, compared case-insensitively.
+func (c *Cache) TagByName(name string) (*lunchmoney.Tag, bool) {
+	s := c.data.Load()
+	if s == nil {
+		return nil, false
+	}
+
+	v, ok := s.tagNames[strings.ToLower(name)]
+	return v, ok
+}
+
+// Asset returns the asset with the given ID.
+func (c *Cache) Asset(id lunchmoney.AssetID) (*lunchmoney.Asset, bool) {
+	s := c.data.Load()
+	if s == nil {
+		return nil, false
+	}
+
+	v, ok := s.assets[id]
+	return v, ok
+}
+
+// PlaidAccount returns the Plaid account with the given ID.
+func (c *Cache) PlaidAccount(id lunchmoney.PlaidAccountID) (*lunchmoney.PlaidAccount, bool) {
+	s := c.data.Load()
+	if s == nil {
+		return nil, false
+	}
+
+	v, ok := s.plaidAccounts[id]
+	return v, ok
+}
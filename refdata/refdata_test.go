@@ -0,0 +1,103 @@
+package refdata
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	categories []*lunchmoney.Category
+	tags       []*lunchmoney.Tag
+	assets     []*lunchmoney.Asset
+	plaid      []*lunchmoney.PlaidAccount
+	err        error
+}
+
+func (f *fakeSource) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return f.categories, f.err
+}
+
+func (f *fakeSource) GetTags(context.Context) ([]*lunchmoney.Tag, error) {
+	return f.tags, nil
+}
+
+func (f *fakeSource) GetAssets(context.Context) ([]*lunchmoney.Asset, error) {
+	return f.assets, nil
+}
+
+func (f *fakeSource) GetPlaidAccounts(context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	return f.plaid, nil
+}
+
+func TestCacheRefresh(t *testing.T) {
+	src := &fakeSource{
+		categories: []*lunchmoney.Category{{ID: 1, Name: "Groceries"}},
+		tags:       []*lunchmoney.Tag{{ID: 2, Name: "travel"}},
+		assets:     []*lunchmoney.Asset{{ID: 3, Name: "Cash"}},
+		plaid:      []*lunchmoney.PlaidAccount{{ID: 4, Name: "Checking"}},
+	}
+	c := New(src)
+
+	_, ok := c.Category(1)
+	assert.False(t, ok)
+	assert.True(t, c.LoadedAt().IsZero())
+
+	require.NoError(t, c.Refresh(context.Background()))
+	cat, ok := c.Category(1)
+	require.True(t, ok)
+	assert.Equal(t, "Groceries", cat.Name)
+	tag, ok := c.Tag(2)
+	require.True(t, ok)
+	assert.Equal(t, "travel", tag.Name)
+	_, ok = c.Asset(3)
+	assert.True(t, ok)
+	_, ok = c.PlaidAccount(4)
+	assert.True(t, ok)
+
+	// A failed refresh keeps serving the previous snapshot.
+	src.err = errors.New("boom")
+	src.categories = nil
+	require.Error(t, c.Refresh(context.Background()))
+	_, ok = c.Category(1)
+	assert.True(t, ok)
+}
+
+func TestCacheNamesAndDecorate(t *testing.T) {
+	src := &fakeSource{
+		categories: []*lunchmoney.Category{{ID: 1, Name: "Groceries"}},
+		tags:       []*lunchmoney.Tag{{ID: 2, Name: "travel"}},
+		assets:     []*lunchmoney.Asset{{ID: 3, Name: "Cash"}},
+		plaid:      []*lunchmoney.PlaidAccount{{ID: 4, Name: "Checking", DisplayName: "Main checking"}},
+	}
+	c := New(src)
+	require.NoError(t, c.Refresh(context.Background()))
+
+	cat, ok := c.CategoryByName("groceries")
+	require.True(t, ok)
+	assert.Equal(t, lunchmoney.CategoryID(1), cat.ID)
+	tag, ok := c.TagByName("Travel")
+	require.True(t, ok)
+	assert.Equal(t, lunchmoney.TagID(2), tag.ID)
+	_, ok = c.TagByName("missing")
+	assert.False(t, ok)
+
+	catID := lunchmoney.CategoryID(1)
+	assetID := lunchmoney.AssetID(3)
+	plaidID := lunchmoney.PlaidAccountID(4)
+	unknown := lunchmoney.CategoryID(99)
+	got := c.Decorate([]*lunchmoney.Transaction{
+		{ID: 10, CategoryID: &catID, AssetID: &assetID, Tags: []*lunchmoney.Tag{{ID: 2}}},
+		{ID: 11, CategoryID: &unknown, PlaidAccountID: &plaidID},
+	})
+	require.Len(t, got, 2)
+	assert.Equal(t, "Groceries", got[0].CategoryName)
+	assert.Equal(t, []string{"travel"}, got[0].TagNames)
+	assert.Equal(t, "Cash", got[0].AccountName)
+	assert.Equal(t, "", got[1].CategoryName)
+	assert.Equal(t, "Main checking", got[1].AccountName)
+}
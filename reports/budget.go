@@ -0,0 +1,161 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/icco/lunchmoney"
+)
+
+// BudgetFetcher fetches budgets and transactions. *lunchmoney.Client
+// implements it.
+type BudgetFetcher interface {
+	Fetcher
+	GetBudgets(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+}
+
+// BudgetLine compares a category's budget with its actual spending over a
+// period. Amounts are in the user's primary currency. For income categories
+// Spent is the income received, so it is positive like the budget.
+type BudgetLine struct {
+	CategoryID lunchmoney.CategoryID
+	Name       string
+	IsIncome   bool
+	// ExcludeFromBudget lines are not counted in the report totals.
+	ExcludeFromBudget bool
+	Budgeted          lunchmoney.Decimal
+	Spent             lunchmoney.Decimal
+	Transactions      int
+	// Children are the categories of a group. A group's figures are the sum
+	// of its children; the API's own group totals are ignored.
+	Children []*BudgetLine
+}
+
+// Remaining returns how much of the budget is left. It is negative when the
+// line is over budget.
+func (l *BudgetLine) Remaining() lunchmoney.Decimal {
+	return l.Budgeted.Sub(l.Spent)
+}
+
+// Percent returns spending as a percentage of the budget, or 0 if nothing was
+// budgeted.
+func (l *BudgetLine) Percent() float64 {
+	if l.Budgeted.IsZero() {
+		return 0
+	}
+
+	p, _ := new(big.Rat).Quo(l.Spent.Rat(), l.Budgeted.Rat()).Float64()
+	return p * 100
+}
+
+// BudgetReport is the budget versus actual spending of every category over a
+// period.
+type BudgetReport struct {
+	Start, End lunchmoney.Date
+	// Lines are the category groups and ungrouped categories, in the
+	// user's category order.
+	Lines []*BudgetLine
+	// Expenses and Income total the top-level lines, leaving out those
+	// excluded from the budget.
+	Expenses BudgetLine
+	Income   BudgetLine
+}
+
+// BudgetVsActual fetches the budgets and transactions between start and end,
+// inclusive, and compares them.
+func BudgetVsActual(ctx context.Context, f BudgetFetcher, start, end lunchmoney.Date) (*BudgetReport, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "report")
+
+	budgets, err := f.GetBudgets(ctx, &lunchmoney.BudgetFilters{StartDate: start, EndDate: end})
+	if err != nil {
+		return nil, fmt.Errorf("get budgets: %w", err)
+	}
+
+	txns, err := Transactions(f, start, end)(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	return NewBudgetReport(budgets, txns, start, end), nil
+}
+
+// NewBudgetReport compares budgets with txns for the months between start
+// and end, inclusive. Spending is taken from the to_base amounts of txns, so
+// it reflects the transactions passed in rather than the API's own totals.
+func NewBudgetReport(budgets []*lunchmoney.Budget, txns []*lunchmoney.Transaction, start, end lunchmoney.Date) *BudgetReport {
+	r := &BudgetReport{Start: start, End: end}
+
+	lines := map[lunchmoney.CategoryID]*BudgetLine{}
+	for _, b := range budgets {
+		l := &BudgetLine{
+			CategoryID:        b.CategoryID,
+			Name:              b.CategoryName,
+			IsIncome:          b.IsIncome,
+			ExcludeFromBudget: b.ExcludeFromBudget,
+		}
+		for _, m := range b.Months() {
+			if m.Before(lunchmoney.NewDate(start.Year, start.Month, 1)) || m.After(end) {
+				continue
+			}
+			if bd, ok := b.Month(m); ok {
+				l.Budgeted = l.Budgeted.Add(bd.BudgetToBase)
+			}
+		}
+		lines[b.CategoryID] = l
+	}
+
+	for _, t := range txns {
+		if t.CategoryID == nil || t.Date.Before(start) || t.Date.After(end) {
+			continue
+		}
+		l, ok := lines[*t.CategoryID]
+		if !ok {
+			continue
+		}
+
+		amount := t.ToBase
+		if l.IsIncome {
+			amount = amount.Neg()
+		}
+		l.Spent = l.Spent.Add(amount)
+		l.Transactions++
+	}
+
+	for _, b := range budgets {
+		l := lines[b.CategoryID]
+		if b.GroupID != nil && *b.GroupID != b.CategoryID {
+			if g, ok := lines[*b.GroupID]; ok {
+				g.Children = append(g.Children, l)
+				continue
+			}
+		}
+		r.Lines = append(r.Lines, l)
+	}
+
+	for _, l := range r.Lines {
+		if len(l.Children) > 0 {
+			l.Budgeted, l.Spent, l.Transactions = lunchmoney.Decimal{}, lunchmoney.Decimal{}, 0
+		}
+		for _, c := range l.Children {
+			l.Budgeted = l.Budgeted.Add(c.Budgeted)
+			l.Spent = l.Spent.Add(c.Spent)
+			l.Transactions += c.Transactions
+		}
+
+		if l.ExcludeFromBudget {
+			continue
+		}
+		total := &r.Expenses
+		if l.IsIncome {
+			total = &r.Income
+		}
+		total.Budgeted = total.Budgeted.Add(l.Budgeted)
+		total.Spent = total.Spent.Add(l.Spent)
+		total.Transactions += l.Transactions
+	}
+	r.Expenses.Name = "Expenses"
+	r.Income.Name, r.Income.IsIncome = "Income", true
+
+	return r
+}
@@ -0,0 +1,85 @@
+package reports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type budgetFetcher struct {
+	txns    []*lunchmoney.Transaction
+	budgets []*lunchmoney.Budget
+}
+
+func (f budgetFetcher) GetAllTransactions(context.Context, *lunchmoney.TransactionFilters, *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	return f.txns, nil
+}
+
+func (f budgetFetcher) GetBudgets(context.Context, *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	return f.budgets, nil
+}
+
+func budgetMonths(amounts map[string]string) map[string]*lunchmoney.BudgetData {
+	ret := map[string]*lunchmoney.BudgetData{}
+	for m, a := range amounts {
+		ret[m] = &lunchmoney.BudgetData{BudgetMonth: lunchmoney.MustParseDate(m), BudgetToBase: lunchmoney.MustParseDecimal(a)}
+	}
+
+	return ret
+}
+
+func TestBudgetVsActual(t *testing.T) {
+	food := lunchmoney.CategoryID(1)
+	groceries := lunchmoney.CategoryID(2)
+	dining := lunchmoney.CategoryID(3)
+	salary := lunchmoney.CategoryID(4)
+	budgets := []*lunchmoney.Budget{
+		{CategoryID: food, CategoryName: "Food", IsGroup: true},
+		{CategoryID: groceries, CategoryName: "Groceries", GroupID: &food, Data: budgetMonths(map[string]string{"2024-01-01": "400", "2024-02-01": "400", "2024-03-01": "999"})},
+		{CategoryID: dining, CategoryName: "Dining", GroupID: &food, Data: budgetMonths(map[string]string{"2024-01-01": "100", "2024-02-01": "100"})},
+		{CategoryID: salary, CategoryName: "Salary", IsIncome: true, Data: budgetMonths(map[string]string{"2024-01-01": "5000", "2024-02-01": "5000"})},
+	}
+
+	based := func(date, amount string, category lunchmoney.CategoryID) *lunchmoney.Transaction {
+		t := txn(date, amount, category)
+		t.ToBase = t.Amount
+		return t
+	}
+	f := budgetFetcher{
+		txns: []*lunchmoney.Transaction{
+			based("2024-01-05", "350", groceries),
+			based("2024-02-05", "500", groceries),
+			based("2024-01-20", "250", dining),
+			based("2024-01-31", "-5000", salary),
+			based("2024-03-02", "80", groceries),
+		},
+		budgets: budgets,
+	}
+
+	start, end := lunchmoney.NewDate(2024, 1, 1), lunchmoney.NewDate(2024, 2, 29)
+	r, err := BudgetVsActual(context.Background(), f, start, end)
+	require.NoError(t, err)
+	require.Len(t, r.Lines, 2)
+
+	group := r.Lines[0]
+	assert.Equal(t, "Food", group.Name)
+	require.Len(t, group.Children, 2)
+	assert.Equal(t, "1000", group.Budgeted.String())
+	assert.Equal(t, "1100", group.Spent.String())
+	assert.Equal(t, "-100", group.Remaining().String())
+	assert.InDelta(t, 110, group.Percent(), 0.001)
+
+	groceriesLine := group.Children[0]
+	assert.Equal(t, "800", groceriesLine.Budgeted.String())
+	assert.Equal(t, "850", groceriesLine.Spent.String())
+	assert.Equal(t, 2, groceriesLine.Transactions)
+
+	income := r.Lines[1]
+	assert.Equal(t, "5000", income.Spent.String())
+	assert.Equal(t, "10000", r.Income.Budgeted.String())
+	assert.Equal(t, "1100", r.Expenses.Spent.String())
+	assert.Equal(t, 0.0, (&BudgetLine{}).Percent())
+}
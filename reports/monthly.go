@@ -0,0 +1,198 @@
+package reports
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/icco/lunchmoney"
+)
+
+// SummaryFetcher fetches transactions and categories. *lunchmoney.Client
+// implements it.
+type SummaryFetcher interface {
+	Fetcher
+	GetCategories(ctx context.Context) ([]*lunchmoney.Category, error)
+}
+
+// Total is the spending of one category or payee.
+type Total struct {
+	Name string
+	// CategoryID is set for category totals, except for uncategorized
+	// spending.
+	CategoryID   *lunchmoney.CategoryID
+	Amount       lunchmoney.Decimal
+	Transactions int
+}
+
+// SummaryOptions configure a monthly summary.
+type SummaryOptions struct {
+	// Top is the number of categories, payees and transactions listed.
+	// Defaults to 5.
+	Top int
+}
+
+// MonthlySummary recaps a month of transactions. Amounts are in the user's
+// primary currency, and Income and Expenses are both positive.
+type MonthlySummary struct {
+	// Month is the first day of the month.
+	Month    lunchmoney.Date
+	Income   lunchmoney.Decimal
+	Expenses lunchmoney.Decimal
+	// Net is Income minus Expenses, the amount saved.
+	Net lunchmoney.Decimal
+	// TopCategories and TopPayees are the largest expense totals, largest
+	// first.
+	TopCategories []Total
+	TopPayees     []Total
+	// Largest are the biggest expense transactions, largest first.
+	Largest      []*lunchmoney.Transaction
+	Transactions int
+}
+
+// SavingsRate returns Net as a percentage of Income, or 0 without income.
+func (s *MonthlySummary) SavingsRate() float64 {
+	if s.Income.Sign() <= 0 {
+		return 0
+	}
+
+	r, _ := new(big.Rat).Quo(s.Net.Rat(), s.Income.Rat()).Float64()
+	return r * 100
+}
+
+// Monthly fetches the transactions and categories for the month containing
+// month and summarizes them.
+func Monthly(ctx context.Context, f SummaryFetcher, month lunchmoney.Date, opts *SummaryOptions) (*MonthlySummary, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "report")
+
+	start := lunchmoney.NewDate(month.Year, month.Month, 1)
+	categories, err := f.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get categories: %w", err)
+	}
+
+	txns, err := Transactions(f, start, start.AddMonths(1).AddDays(-1))(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	return NewMonthlySummary(month, txns, categories, opts), nil
+}
+
+// NewMonthlySummary summarizes the txns dated in the month containing month.
+// Transactions in income categories count as income and those in other
+// categories as expenses, so refunds reduce spending; uncategorized
+// transactions are classified by the sign of their amount. Categories
+// excluded from totals and transactions inside a transaction group, which
+// are counted through the group, are left out.
+func NewMonthlySummary(month lunchmoney.Date, txns []*lunchmoney.Transaction, categories []*lunchmoney.Category, opts *SummaryOptions) *MonthlySummary {
+	top := 5
+	if opts != nil && opts.Top > 0 {
+		top = opts.Top
+	}
+
+	byID := map[lunchmoney.CategoryID]*lunchmoney.Category{}
+	for _, c := range categories {
+		byID[c.ID] = c
+		for _, child := range c.Children {
+			byID[child.ID] = child
+		}
+	}
+
+	s := &MonthlySummary{Month: lunchmoney.NewDate(month.Year, month.Month, 1)}
+	cats := map[lunchmoney.CategoryID]*Total{}
+	uncategorized := &Total{Name: "Uncategorized"}
+	payees := map[string]*Total{}
+	var expenses []*lunchmoney.Transaction
+	for _, t := range txns {
+		if t.Date.Year != month.Year || t.Date.Month != month.Month || t.InGroup() {
+			continue
+		}
+
+		var c *lunchmoney.Category
+		if t.CategoryID != nil {
+			c = byID[*t.CategoryID]
+		}
+		if c != nil && c.ExcludeFromTotals {
+			continue
+		}
+		s.Transactions++
+
+		income := t.ToBase.Sign() < 0
+		if c != nil {
+			income = c.IsIncome
+		}
+		if income {
+			s.Income = s.Income.Sub(t.ToBase)
+			continue
+		}
+
+		s.Expenses = s.Expenses.Add(t.ToBase)
+		expenses = append(expenses, t)
+
+		total := uncategorized
+		if t.CategoryID != nil {
+			total = cats[*t.CategoryID]
+			if total == nil {
+				total = &Total{Name: fmt.Sprintf("Category %d", *t.CategoryID), CategoryID: t.CategoryID}
+				if c != nil {
+					total.Name = c.Name
+				}
+				cats[*t.CategoryID] = total
+			}
+		}
+		total.Amount = total.Amount.Add(t.ToBase)
+		total.Transactions++
+
+		p := payees[t.Payee]
+		if p == nil {
+			p = &Total{Name: t.Payee}
+			payees[t.Payee] = p
+		}
+		p.Amount = p.Amount.Add(t.ToBase)
+		p.Transactions++
+	}
+	s.Net = s.Income.Sub(s.Expenses)
+
+	all := make([]*Total, 0, len(cats)+1)
+	for _, t := range cats {
+		all = append(all, t)
+	}
+	if uncategorized.Transactions > 0 {
+		all = append(all, uncategorized)
+	}
+	s.TopCategories = topTotals(all, top)
+
+	all = all[:0]
+	for _, p := range payees {
+		all = append(all, p)
+	}
+	s.TopPayees = topTotals(all, top)
+
+	slices.SortStableFunc(expenses, func(a, b *lunchmoney.Transaction) int {
+		return cmp.Or(b.ToBase.Cmp(a.ToBase), a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+	s.Largest = expenses[:min(top, len(expenses))]
+
+	return s
+}
+
+// topTotals returns the n largest positive totals, largest first and by name
+// on ties.
+func topTotals(totals []*Total, n int) []Total {
+	slices.SortFunc(totals, func(a, b *Total) int {
+		return cmp.Or(b.Amount.Cmp(a.Amount), cmp.Compare(a.Name, b.Name))
+	})
+
+	var ret []Total
+	for _, t := range totals {
+		if len(ret) == n || t.Amount.Sign() <= 0 {
+			break
+		}
+		ret = append(ret, *t)
+	}
+
+	return ret
+}
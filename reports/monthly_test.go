@@ -0,0 +1,86 @@
+package reports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type summaryFetcher struct {
+	txns       []*lunchmoney.Transaction
+	categories []*lunchmoney.Category
+	filters    *lunchmoney.TransactionFilters
+}
+
+func (f *summaryFetcher) GetAllTransactions(_ context.Context, filters *lunchmoney.TransactionFilters, _ *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	f.filters = filters
+	return f.txns, nil
+}
+
+func (f *summaryFetcher) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return f.categories, nil
+}
+
+func TestMonthly(t *testing.T) {
+	based := func(id int64, date, payee, amount string, category *lunchmoney.CategoryID) *lunchmoney.Transaction {
+		a := lunchmoney.MustParseDecimal(amount)
+		return &lunchmoney.Transaction{ID: lunchmoney.TransactionID(id), Date: lunchmoney.MustParseDate(date), Payee: payee, Amount: a, ToBase: a, CategoryID: category}
+	}
+	salary, food, rent, transfer := lunchmoney.Ptr(lunchmoney.CategoryID(1)), lunchmoney.Ptr(lunchmoney.CategoryID(2)), lunchmoney.Ptr(lunchmoney.CategoryID(3)), lunchmoney.Ptr(lunchmoney.CategoryID(4))
+
+	f := &summaryFetcher{
+		categories: []*lunchmoney.Category{
+			{ID: 1, Name: "Salary", IsIncome: true},
+			{ID: 9, Name: "Living", IsGroup: true, Children: []*lunchmoney.Category{{ID: 2, Name: "Food"}, {ID: 3, Name: "Rent"}}},
+			{ID: 4, Name: "Transfer", ExcludeFromTotals: true},
+		},
+		txns: []*lunchmoney.Transaction{
+			based(1, "2024-03-01", "Acme", "-4000", salary),
+			based(2, "2024-03-02", "Landlord", "1500", rent),
+			based(3, "2024-03-05", "Market", "120.50", food),
+			based(4, "2024-03-09", "Market", "-20.50", food),
+			based(5, "2024-03-10", "Cafe", "30", food),
+			based(6, "2024-03-11", "Kiosk", "50", nil),
+			based(7, "2024-03-12", "Savings", "1000", transfer),
+			based(8, "2024-03-13", "Refund Co", "-100", nil),
+			based(9, "2024-04-01", "Landlord", "1500", rent),
+		},
+	}
+
+	s, err := Monthly(context.Background(), f, lunchmoney.MustParseDate("2024-03-17"), &SummaryOptions{Top: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01", f.filters.StartDate.String())
+	assert.Equal(t, "2024-03-31", f.filters.EndDate.String())
+
+	assert.Equal(t, "2024-03-01", s.Month.String())
+	assert.True(t, lunchmoney.MustParseDecimal("4100").Equal(s.Income), s.Income.String())
+	assert.True(t, lunchmoney.MustParseDecimal("1680").Equal(s.Expenses), s.Expenses.String())
+	assert.True(t, lunchmoney.MustParseDecimal("2420").Equal(s.Net), s.Net.String())
+	assert.InDelta(t, 59.02, s.SavingsRate(), 0.01)
+	assert.Equal(t, 7, s.Transactions)
+
+	require.Len(t, s.TopCategories, 2)
+	assert.Equal(t, "Rent", s.TopCategories[0].Name)
+	assert.Equal(t, "Food", s.TopCategories[1].Name)
+	assert.Equal(t, "130.00", s.TopCategories[1].Amount.String())
+	assert.Equal(t, 3, s.TopCategories[1].Transactions)
+
+	require.Len(t, s.TopPayees, 2)
+	assert.Equal(t, "Landlord", s.TopPayees[0].Name)
+	assert.Equal(t, "Market", s.TopPayees[1].Name)
+
+	require.Len(t, s.Largest, 2)
+	assert.Equal(t, lunchmoney.TransactionID(2), s.Largest[0].ID)
+	assert.Equal(t, lunchmoney.TransactionID(3), s.Largest[1].ID)
+}
+
+func TestMonthlySummaryEmpty(t *testing.T) {
+	s := NewMonthlySummary(lunchmoney.MustParseDate("2024-02-10"), nil, nil, nil)
+	assert.Equal(t, "2024-02-01", s.Month.String())
+	assert.Equal(t, 0.0, s.SavingsRate())
+	assert.Empty(t, s.TopCategories)
+	assert.Empty(t, s.Largest)
+}
@@ -0,0 +1,214 @@
+// Package reports builds small transaction reports out of composable steps: a
+// source fetches transactions, predicates filter them, a group-by assigns
+// each to a bucket, an aggregator reduces each bucket to a row, and a sink
+// writes the result. For example, last quarter's dining spend by week as CSV:
+//
+//	_, err := reports.New(reports.Transactions(client, start, end)).
+//		Where(reports.InCategory(diningID)).
+//		GroupBy(reports.ByWeek).
+//		Run(ctx, reports.CSV(os.Stdout))
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Fetcher fetches transactions. *lunchmoney.Client implements it.
+type Fetcher interface {
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+}
+
+// Source produces the transactions a report is built from.
+type Source func(ctx context.Context) ([]*lunchmoney.Transaction, error)
+
+// Transactions is a Source returning every transaction dated between start
+// and end, inclusive.
+func Transactions(f Fetcher, start, end lunchmoney.Date) Source {
+	return func(ctx context.Context) ([]*lunchmoney.Transaction, error) {
+		return f.GetAllTransactions(ctx, &lunchmoney.TransactionFilters{StartDate: &start, EndDate: &end}, nil)
+	}
+}
+
+// Slice is a Source returning txns, useful for transactions that were
+// already fetched.
+func Slice(txns []*lunchmoney.Transaction) Source {
+	return func(context.Context) ([]*lunchmoney.Transaction, error) {
+		return txns, nil
+	}
+}
+
+// Predicate selects transactions to include in a report. Any of the
+// lunchmoney package's predicates can be used.
+type Predicate = lunchmoney.Predicate
+
+// InCategory selects transactions in the category with the given ID.
+func InCategory(id lunchmoney.CategoryID) Predicate {
+	return lunchmoney.InCategory(id)
+}
+
+// Expenses selects transactions that are debits. Lunch Money reports debits
+// as positive amounts unless debit_as_negative is requested.
+func Expenses(t *lunchmoney.Transaction) bool {
+	return t.Amount.Sign() > 0
+}
+
+// GroupBy returns the bucket a transaction belongs to. Buckets are sorted by
+// key in the report, so keys should sort in a meaningful order.
+type GroupBy func(t *lunchmoney.Transaction) string
+
+// ByDay groups transactions by date.
+func ByDay(t *lunchmoney.Transaction) string {
+	return t.Date.String()
+}
+
+// ByWeek groups transactions by the Monday starting their week.
+func ByWeek(t *lunchmoney.Transaction) string {
+	offset := (int(t.Date.Time().Weekday()) + 6) % 7
+	return t.Date.AddDays(-offset).String()
+}
+
+// ByMonth groups transactions by calendar month, as YYYY-MM.
+func ByMonth(t *lunchmoney.Transaction) string {
+	return fmt.Sprintf("%04d-%02d", t.Date.Year, t.Date.Month)
+}
+
+// ByCategory groups transactions by category ID. Uncategorized transactions
+// share the key "uncategorized".
+func ByCategory(t *lunchmoney.Transaction) string {
+	if t.CategoryID == nil {
+		return "uncategorized"
+	}
+
+	return strconv.FormatInt(int64(*t.CategoryID), 10)
+}
+
+// ByPayee groups transactions by payee.
+func ByPayee(t *lunchmoney.Transaction) string {
+	return t.Payee
+}
+
+// Row is one bucket of a report.
+type Row struct {
+	Key   string
+	Count int
+	Total lunchmoney.Decimal
+}
+
+// Aggregator reduces the transactions of a bucket to a row.
+type Aggregator func(key string, txns []*lunchmoney.Transaction) Row
+
+// Sum is the default Aggregator. It counts the transactions and adds up
+// their amounts. Amounts in different currencies are added as-is, so group
+// or filter by currency first when that matters.
+func Sum(key string, txns []*lunchmoney.Transaction) Row {
+	r := Row{Key: key, Count: len(txns)}
+	for _, t := range txns {
+		r.Total = r.Total.Add(t.Amount)
+	}
+
+	return r
+}
+
+// Sink receives the finished report.
+type Sink func(ctx context.Context, rows []Row) error
+
+// CSV is a Sink writing the report as CSV with a key,count,total header.
+func CSV(w io.Writer) Sink {
+	return func(_ context.Context, rows []Row) error {
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"key", "count", "total"}); err != nil {
+			return err
+		}
+
+		for _, r := range rows {
+			if err := cw.Write([]string{r.Key, strconv.Itoa(r.Count), r.Total.String()}); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		return cw.Error()
+	}
+}
+
+// Pipeline is a report definition. The zero value is not usable; create one
+// with New.
+type Pipeline struct {
+	source    Source
+	preds     []Predicate
+	groupBy   GroupBy
+	aggregate Aggregator
+}
+
+// New starts a pipeline reading from src. Without further steps it sums all
+// transactions into a single row.
+func New(src Source) *Pipeline {
+	return &Pipeline{
+		source:    src,
+		groupBy:   func(*lunchmoney.Transaction) string { return "total" },
+		aggregate: Sum,
+	}
+}
+
+// Where adds predicates that every transaction must match.
+func (p *Pipeline) Where(preds ...Predicate) *Pipeline {
+	p.preds = append(p.preds, preds...)
+	return p
+}
+
+// GroupBy sets how transactions are bucketed.
+func (p *Pipeline) GroupBy(g GroupBy) *Pipeline {
+	p.groupBy = g
+	return p
+}
+
+// Aggregate sets how each bucket is reduced to a row.
+func (p *Pipeline) Aggregate(a Aggregator) *Pipeline {
+	p.aggregate = a
+	return p
+}
+
+// Run fetches the transactions, builds the report and hands it to sink, if
+// not nil. It returns the rows sorted by key.
+func (p *Pipeline) Run(ctx context.Context, sink Sink) ([]Row, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "report")
+
+	txns, err := p.source(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("report source: %w", err)
+	}
+
+	buckets := map[string][]*lunchmoney.Transaction{}
+outer:
+	for _, t := range txns {
+		for _, pred := range p.preds {
+			if !pred(t) {
+				continue outer
+			}
+		}
+
+		key := p.groupBy(t)
+		buckets[key] = append(buckets[key], t)
+	}
+
+	rows := make([]Row, 0, len(buckets))
+	for key, ts := range buckets {
+		rows = append(rows, p.aggregate(key, ts))
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+
+	if sink != nil {
+		if err := sink(ctx, rows); err != nil {
+			return nil, fmt.Errorf("report sink: %w", err)
+		}
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,46 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func txn(date, amount string, category lunchmoney.CategoryID) *lunchmoney.Transaction {
+	return &lunchmoney.Transaction{
+		Date:       lunchmoney.MustParseDate(date),
+		Amount:     lunchmoney.MustParseDecimal(amount),
+		CategoryID: lunchmoney.Ptr(category),
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	src := Slice([]*lunchmoney.Transaction{
+		txn("2024-01-01", "12.50", 1), // Monday
+		txn("2024-01-07", "7.50", 1),  // Sunday, same week
+		txn("2024-01-08", "20.00", 1),
+		txn("2024-01-03", "99.00", 2),
+		txn("2024-01-04", "-5.00", 1),
+	})
+
+	var buf bytes.Buffer
+	rows, err := New(src).
+		Where(InCategory(1), Expenses).
+		GroupBy(ByWeek).
+		Run(context.Background(), CSV(&buf))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, "2024-01-01", rows[0].Key)
+	assert.Equal(t, 2, rows[0].Count)
+	assert.Equal(t, "20.00", rows[0].Total.String())
+	assert.Equal(t, "key,count,total\n2024-01-01,2,20.00\n2024-01-08,1,20.00\n", buf.String())
+
+	rows, err = New(src).Run(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Row{{Key: "total", Count: 5, Total: lunchmoney.MustParseDecimal("134.00")}}, rows)
+}
@@ -0,0 +1,124 @@
+package reports
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Rhymond/go-money"
+	"github.com/icco/lunchmoney"
+)
+
+// Attribution decides how the amount of a transaction with several tags is
+// counted towards each of them.
+type Attribution int
+
+const (
+	// AttributeFull counts the whole amount towards every tag, so tag totals
+	// can add up to more than was spent.
+	AttributeFull Attribution = iota
+	// AttributeSplit divides the amount evenly between the tags, in the
+	// currency's minor units, so tag totals add up to what was spent.
+	AttributeSplit
+	// AttributeFirst counts the whole amount towards the first tag only.
+	AttributeFirst
+)
+
+// TagOptions configure a tag spending report.
+type TagOptions struct {
+	Attribution Attribution
+	// Tags limits the report to these tags. Other tags are ignored when
+	// attributing amounts, so a transaction tagged with one of them and
+	// one other counts fully towards the listed tag.
+	Tags []lunchmoney.TagID
+	// Currency is the user's primary currency, used to round split amounts.
+	// Defaults to USD.
+	Currency string
+}
+
+// TagTotal is the spending of one tag. Amounts are in the user's primary
+// currency with debits positive, so refunds and income reduce the total.
+type TagTotal struct {
+	TagID        lunchmoney.TagID
+	Name         string
+	Amount       lunchmoney.Decimal
+	Transactions int
+}
+
+// TagSpending fetches the transactions between start and end, inclusive,
+// and totals them by tag.
+func TagSpending(ctx context.Context, f Fetcher, start, end lunchmoney.Date, opts *TagOptions) ([]TagTotal, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "report")
+
+	txns, err := Transactions(f, start, end)(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	return TagTotals(txns, opts)
+}
+
+// TagTotals totals txns by tag, largest first. Untagged transactions are
+// left out.
+func TagTotals(txns []*lunchmoney.Transaction, opts *TagOptions) ([]TagTotal, error) {
+	if opts == nil {
+		opts = &TagOptions{}
+	}
+	currency := cmp.Or(opts.Currency, money.USD)
+
+	totals := map[lunchmoney.TagID]*TagTotal{}
+	for _, t := range txns {
+		var tags []*lunchmoney.Tag
+		for _, tag := range t.Tags {
+			if tag != nil && (len(opts.Tags) == 0 || slices.Contains(opts.Tags, tag.ID)) {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			continue
+		}
+
+		amounts := make([]lunchmoney.Decimal, len(tags))
+		switch opts.Attribution {
+		case AttributeFull:
+			for i := range amounts {
+				amounts[i] = t.ToBase
+			}
+		case AttributeSplit:
+			places := int32(2)
+			if c := money.GetCurrency(currency); c != nil {
+				places = int32(c.Fraction)
+			}
+			parts, err := lunchmoney.SplitEvenly(t.ToBase.Round(places), len(tags), currency)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", t.ID, err)
+			}
+			amounts = parts
+		case AttributeFirst:
+			tags, amounts = tags[:1], []lunchmoney.Decimal{t.ToBase}
+		default:
+			return nil, fmt.Errorf("unknown attribution %d", opts.Attribution)
+		}
+
+		for i, tag := range tags {
+			tt := totals[tag.ID]
+			if tt == nil {
+				tt = &TagTotal{TagID: tag.ID, Name: tag.Name}
+				totals[tag.ID] = tt
+			}
+			tt.Amount = tt.Amount.Add(amounts[i])
+			tt.Transactions++
+		}
+	}
+
+	ret := make([]TagTotal, 0, len(totals))
+	for _, tt := range totals {
+		ret = append(ret, *tt)
+	}
+	slices.SortFunc(ret, func(a, b TagTotal) int {
+		return cmp.Or(b.Amount.Cmp(a.Amount), cmp.Compare(a.Name, b.Name), cmp.Compare(a.TagID, b.TagID))
+	})
+
+	return ret, nil
+}
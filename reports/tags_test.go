@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagSpending(t *testing.T) {
+	trip := &lunchmoney.Tag{ID: 1, Name: "japan-trip"}
+	work := &lunchmoney.Tag{ID: 2, Name: "work"}
+	tagged := func(id int64, amount string, tags ...*lunchmoney.Tag) *lunchmoney.Transaction {
+		a := lunchmoney.MustParseDecimal(amount)
+		return &lunchmoney.Transaction{ID: lunchmoney.TransactionID(id), Amount: a, ToBase: a, Tags: tags}
+	}
+	f := &summaryFetcher{txns: []*lunchmoney.Transaction{
+		tagged(1, "100.01", trip, work),
+		tagged(2, "40", trip),
+		tagged(3, "-10", trip),
+		tagged(4, "25", work),
+		tagged(5, "500"),
+	}}
+
+	tests := []struct {
+		name string
+		opts *TagOptions
+		want map[string]string
+	}{
+		{"full", nil, map[string]string{"japan-trip": "130.01", "work": "125.01"}},
+		{"split", &TagOptions{Attribution: AttributeSplit}, map[string]string{"japan-trip": "80.01", "work": "75.00"}},
+		{"first", &TagOptions{Attribution: AttributeFirst}, map[string]string{"japan-trip": "130.01", "work": "25"}},
+		{"only trip", &TagOptions{Attribution: AttributeSplit, Tags: []lunchmoney.TagID{1}}, map[string]string{"japan-trip": "130.01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals, err := TagSpending(context.Background(), f, lunchmoney.MustParseDate("2024-01-01"), lunchmoney.MustParseDate("2024-01-31"), tt.opts)
+			require.NoError(t, err)
+			require.Len(t, totals, len(tt.want))
+			for _, total := range totals {
+				assert.True(t, lunchmoney.MustParseDecimal(tt.want[total.Name]).Equal(total.Amount), "%s: %s", total.Name, total.Amount)
+			}
+			assert.Equal(t, "japan-trip", totals[0].Name)
+		})
+	}
+
+	_, err := TagTotals(f.txns, &TagOptions{Attribution: Attribution(9)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/icco/lunchmoney"
+)
+
+// TrendOptions configure a category trend.
+type TrendOptions struct {
+	// End is a date in the last month of the trend. Defaults to today.
+	End lunchmoney.Date
+	// Window is the number of months in the moving average. Defaults to 3.
+	Window int
+}
+
+// TrendMonth is one month of a category trend. Amounts are in the user's
+// primary currency.
+type TrendMonth struct {
+	// Month is the first day of the month.
+	Month        lunchmoney.Date
+	Total        lunchmoney.Decimal
+	Transactions int
+	// Average is the mean Total of this month and the months before it in
+	// the moving average window, rounded to two decimal places. Months
+	// before the start of the trend are not counted.
+	Average lunchmoney.Decimal
+	// Change is Total minus the previous month's Total. It is zero for the
+	// first month.
+	Change lunchmoney.Decimal
+}
+
+// ChangePercent returns Change as a percentage of the previous month's
+// Total, or 0 if that was zero.
+func (m TrendMonth) ChangePercent() float64 {
+	prev := m.Total.Sub(m.Change)
+	if prev.IsZero() {
+		return 0
+	}
+
+	p, _ := new(big.Rat).Quo(m.Change.Rat(), prev.Abs().Rat()).Float64()
+	return p * 100
+}
+
+// Trend is the month by month spending of a category.
+type Trend struct {
+	CategoryID lunchmoney.CategoryID
+	// Months are in order, oldest first.
+	Months []TrendMonth
+	// Average is the mean monthly Total over the whole trend, rounded to two
+	// decimal places.
+	Average lunchmoney.Decimal
+}
+
+// CategoryTrend fetches the last months months of transactions in a category,
+// ending with the month of opts.End, and computes the trend.
+func CategoryTrend(ctx context.Context, f Fetcher, id lunchmoney.CategoryID, months int, opts *TrendOptions) (*Trend, error) {
+	if months < 1 {
+		return nil, fmt.Errorf("trend over %d months: need at least one", months)
+	}
+	ctx = lunchmoney.WithDefaultOperation(ctx, "report")
+
+	start, end := trendRange(months, opts)
+	txns, err := f.GetAllTransactions(ctx, &lunchmoney.TransactionFilters{CategoryID: &id, StartDate: &start, EndDate: &end}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	// The API already selected the category, including the children of a
+	// category group, whose transactions never carry the group's ID.
+	return newTrend(txns, id, months, opts, func(*lunchmoney.Transaction) bool { return true }), nil
+}
+
+// NewCategoryTrend computes the trend of the category from txns, which may
+// include other categories and dates; those are ignored. Transactions of a
+// category group are in its child categories, so for a group use
+// CategoryTrend, which counts every transaction the API returns for it.
+func NewCategoryTrend(txns []*lunchmoney.Transaction, id lunchmoney.CategoryID, months int, opts *TrendOptions) *Trend {
+	return newTrend(txns, id, months, opts, InCategory(id))
+}
+
+func newTrend(txns []*lunchmoney.Transaction, id lunchmoney.CategoryID, months int, opts *TrendOptions, in Predicate) *Trend {
+	window := 3
+	if opts != nil && opts.Window > 0 {
+		window = opts.Window
+	}
+
+	start, _ := trendRange(months, opts)
+	tr := &Trend{CategoryID: id, Months: make([]TrendMonth, max(months, 0))}
+	for i := range tr.Months {
+		tr.Months[i].Month = start.AddMonths(i)
+	}
+
+	for _, t := range txns {
+		if !in(t) {
+			continue
+		}
+		i := (t.Date.Year-start.Year)*12 + int(t.Date.Month-start.Month)
+		if i < 0 || i >= len(tr.Months) {
+			continue
+		}
+		tr.Months[i].Total = tr.Months[i].Total.Add(t.ToBase)
+		tr.Months[i].Transactions++
+	}
+
+	var total lunchmoney.Decimal
+	for i := range tr.Months {
+		m := &tr.Months[i]
+		total = total.Add(m.Total)
+		if i > 0 {
+			m.Change = m.Total.Sub(tr.Months[i-1].Total)
+		}
+
+		var sum lunchmoney.Decimal
+		from := max(0, i-window+1)
+		for _, w := range tr.Months[from : i+1] {
+			sum = sum.Add(w.Total)
+		}
+		m.Average = sum.Div(lunchmoney.NewDecimal(int64(i+1-from), 0), 2)
+	}
+	if len(tr.Months) > 0 {
+		tr.Average = total.Div(lunchmoney.NewDecimal(int64(len(tr.Months)), 0), 2)
+	}
+
+	return tr
+}
+
+// trendRange returns the first day of the first month and the last day of
+// the last month of a trend.
+func trendRange(months int, opts *TrendOptions) (lunchmoney.Date, lunchmoney.Date) {
+	end := lunchmoney.Today()
+	if opts != nil && !opts.End.IsZero() {
+		end = opts.End
+	}
+
+	last := lunchmoney.NewDate(end.Year, end.Month, 1)
+	return last.AddMonths(1 - months), last.AddMonths(1).AddDays(-1)
+}
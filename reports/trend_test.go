@@ -0,0 +1,63 @@
+package reports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryTrend(t *testing.T) {
+	based := func(date, amount string, category lunchmoney.CategoryID) *lunchmoney.Transaction {
+		t := txn(date, amount, category)
+		t.ToBase = t.Amount
+		return t
+	}
+	// Category 5 is a group: the API returns the transactions of its
+	// child category 6 too.
+	f := &summaryFetcher{txns: []*lunchmoney.Transaction{
+		based("2023-12-31", "999", 5),
+		based("2024-01-03", "100", 5),
+		based("2024-01-20", "50", 6),
+		based("2024-03-04", "300", 5),
+		based("2024-04-30", "100", 5),
+	}}
+
+	tr, err := CategoryTrend(context.Background(), f, 5, 4, &TrendOptions{End: lunchmoney.MustParseDate("2024-04-15"), Window: 2})
+	require.NoError(t, err)
+	assert.Equal(t, lunchmoney.CategoryID(5), *f.filters.CategoryID)
+	assert.Equal(t, "2024-01-01", f.filters.StartDate.String())
+	assert.Equal(t, "2024-04-30", f.filters.EndDate.String())
+
+	require.Len(t, tr.Months, 4)
+	want := []struct {
+		month, total, average, change string
+		count                         int
+	}{
+		{"2024-01-01", "150", "150.00", "0", 2},
+		{"2024-02-01", "0", "75.00", "-150", 0},
+		{"2024-03-01", "300", "150.00", "300", 1},
+		{"2024-04-01", "100", "200.00", "-200", 1},
+	}
+	for i, w := range want {
+		m := tr.Months[i]
+		assert.Equal(t, w.month, m.Month.String())
+		assert.True(t, lunchmoney.MustParseDecimal(w.total).Equal(m.Total), "%s total %s", w.month, m.Total)
+		assert.Equal(t, w.average, m.Average.String(), w.month)
+		assert.True(t, lunchmoney.MustParseDecimal(w.change).Equal(m.Change), "%s change %s", w.month, m.Change)
+		assert.Equal(t, w.count, m.Transactions)
+	}
+	assert.Equal(t, 0.0, tr.Months[2].ChangePercent())
+	assert.InDelta(t, -66.67, tr.Months[3].ChangePercent(), 0.01)
+	assert.Equal(t, "137.50", tr.Average.String())
+
+	_, err = CategoryTrend(context.Background(), f, 5, 0, nil)
+	assert.Error(t, err)
+
+	// NewCategoryTrend selects the category itself.
+	tr = NewCategoryTrend(f.txns, 5, 4, &TrendOptions{End: lunchmoney.MustParseDate("2024-04-15")})
+	assert.Equal(t, 1, tr.Months[0].Transactions)
+	assert.True(t, lunchmoney.MustParseDecimal("100").Equal(tr.Months[0].Total))
+}
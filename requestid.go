@@ -0,0 +1,69 @@
+package lunchmoney
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the ID the client gives each
+// request.
+const RequestIDHeader = "X-Request-ID"
+
+// CorrelationHeaders are the response headers the API or the proxies in
+// front of it use to identify a request. Those present are kept in
+// APIError.Correlation and logged, so a call can be referenced when talking to
+// support.
+var CorrelationHeaders = []string{"X-Request-ID", "X-Correlation-ID", "CF-Ray"}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose requests carry id in their
+// X-Request-ID header instead of a generated one, so a call can be traced
+// across services. Every retry of a request carries the same ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if none is
+// set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// newRequestID returns a random 128 bit ID.
+func newRequestID() string {
+	var b [16]byte
+	// crypto/rand.Read never fails.
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// setRequestID gives req the request ID of ctx, or a new one, and returns it.
+func setRequestID(ctx context.Context, req *http.Request) string {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		id = newRequestID()
+	}
+	req.Header.Set(RequestIDHeader, id)
+
+	return id
+}
+
+// correlation returns the correlation headers present in h, or nil if there
+// are none.
+func correlation(h http.Header) map[string]string {
+	var ret map[string]string
+	for _, k := range CorrelationHeaders {
+		if v := h.Get(k); v != "" {
+			if ret == nil {
+				ret = map[string]string{}
+			}
+			ret[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+
+	return ret
+}
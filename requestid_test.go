@@ -0,0 +1,63 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		ids []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get("X-Request-ID"))
+		mu.Unlock()
+		w.Header().Set("Cf-Ray", "8a1b2c3d4e5f-AMS")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithLogger(logger), WithRetries(1, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = client.GetTags(WithRequestID(context.Background(), "sync-42"))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "sync-42", apiErr.RequestID)
+	assert.Equal(t, map[string]string{"Cf-Ray": "8a1b2c3d4e5f-AMS"}, apiErr.Correlation)
+	assert.Equal(t, []string{"sync-42", "sync-42"}, ids)
+	assert.Contains(t, logs.String(), "request_id=sync-42")
+	assert.Contains(t, logs.String(), "Cf-Ray=8a1b2c3d4e5f-AMS")
+
+	// Without one in the context, an ID is generated and kept across retries.
+	ids = nil
+	_, err = client.GetTags(context.Background())
+	require.ErrorAs(t, err, &apiErr)
+	require.Len(t, ids, 2)
+	assert.Len(t, ids[0], 32)
+	assert.Equal(t, ids[0], ids[1])
+	assert.Equal(t, ids[0], apiErr.RequestID)
+
+	// Each write gets its own ID.
+	ids = nil
+	_, err = client.Post(context.Background(), "/v1/tags", nil)
+	require.ErrorAs(t, err, &apiErr)
+	_, err = client.Post(context.Background(), "/v1/tags", nil)
+	require.Error(t, err)
+	require.Len(t, ids, 2)
+	assert.Equal(t, ids[0], apiErr.RequestID)
+	assert.NotEqual(t, ids[0], ids[1])
+}
@@ -0,0 +1,234 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRetryBudgetExhausted is returned, wrapping the last error, when a request
+// could have been retried but its RetryBudget was used up.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// WithRetries retries GET requests that failed with 429 Too Many Requests, a
+// 5xx status or a transport error up to n times, waiting backoff times the
+// attempt number before each retry, or longer if the response's Retry-After
+// header asks for it, up to the limit set with WithMaxRetryAfter. Writes are
+// never retried, since the API has no way to make them idempotent. A
+// RetryBudget attached to the context bounds the retries of a whole call
+// chain. WithBackoff replaces the backoff policy.
+func WithRetries(n int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("retries must not be negative, got %d", n)
+		}
+		if backoff < 0 {
+			return fmt.Errorf("backoff must not be negative, got %s", backoff)
+		}
+
+		c.maxRetries = n
+		c.retryBackoff = backoff
+		return nil
+	}
+}
+
+// Backoff decides how long to wait before retrying a request.
+type Backoff interface {
+	// Delay returns the wait before retry number attempt, starting at 1, of
+	// a request that failed with err. prev is the wait before the previous
+	// retry, or zero before the first.
+	Delay(attempt int, prev time.Duration, err error) time.Duration
+}
+
+// BackoffFunc adapts a function to the Backoff interface, for example a
+// deterministic policy in tests.
+type BackoffFunc func(attempt int, prev time.Duration, err error) time.Duration
+
+// Delay calls f.
+func (f BackoffFunc) Delay(attempt int, prev time.Duration, err error) time.Duration {
+	return f(attempt, prev, err)
+}
+
+// LinearBackoff waits d times the attempt number.
+func LinearBackoff(d time.Duration) Backoff {
+	return BackoffFunc(func(attempt int, _ time.Duration, _ error) time.Duration {
+		return d * time.Duration(attempt)
+	})
+}
+
+// DecorrelatedJitter waits a random time between base and three times the
+// previous wait, capped at maxDelay. The randomness spreads out clients that
+// failed at the same time, such as several jobs hitting the rate limit.
+func DecorrelatedJitter(base, maxDelay time.Duration) Backoff {
+	return BackoffFunc(func(_ int, prev time.Duration, _ error) time.Duration {
+		hi := max(3*prev, base)
+		return min(maxDelay, base+rand.N(hi-base+1))
+	})
+}
+
+// DefaultMaxRetryAfter is the longest wait a Retry-After header can ask for
+// unless WithMaxRetryAfter changes it.
+const DefaultMaxRetryAfter = time.Minute
+
+// WithMaxRetryAfter caps the wait a Retry-After header can ask for before a
+// retry at d, so a misbehaving server or proxy cannot stall a request for
+// hours. The backoff policy still applies if it asks for longer.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *Client) error {
+		if d <= 0 {
+			return fmt.Errorf("max retry after must be positive, got %s", d)
+		}
+
+		c.maxRetryAfter = d
+		return nil
+	}
+}
+
+// WithBackoff sets the policy deciding how long to wait before the retries
+// enabled with WithRetries, instead of its linear backoff.
+func WithBackoff(b Backoff) Option {
+	return func(c *Client) error {
+		if b == nil {
+			return errors.New("backoff is nil")
+		}
+
+		c.backoff = b
+		return nil
+	}
+}
+
+// RetryBudget bounds the retries of every request made with a context, on
+// top of the retries each request may make on its own, so a misconfigured
+// job fails fast rather than retrying for hours.
+type RetryBudget struct {
+	// MaxElapsed is how long after the budget was attached retries may
+	// start. A retry whose backoff would end later is not made. Zero means
+	// no limit.
+	MaxElapsed time.Duration
+	// MaxRetries is the number of retries shared by all requests. Zero means
+	// no limit.
+	MaxRetries int
+}
+
+type retryBudgetKey struct{}
+
+// retryBudget is the state of a RetryBudget shared by a call chain.
+type retryBudget struct {
+	RetryBudget
+	deadline time.Time
+
+	mu      sync.Mutex
+	retries int
+}
+
+// WithRetryBudget returns a context whose requests share budget b. The
+// elapsed time is counted from this call.
+func WithRetryBudget(ctx context.Context, b RetryBudget) context.Context {
+	rb := &retryBudget{RetryBudget: b}
+	if b.MaxElapsed > 0 {
+		rb.deadline = time.Now().Add(b.MaxElapsed)
+	}
+
+	return context.WithValue(ctx, retryBudgetKey{}, rb)
+}
+
+// takeRetry spends one retry from the budget of ctx, if it has one, unless
+// that would exceed it. wait is the backoff before the retry.
+func takeRetry(ctx context.Context, wait time.Duration) bool {
+	b, ok := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	if !ok {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.MaxRetries > 0 && b.retries >= b.MaxRetries {
+		return false
+	}
+	if !b.deadline.IsZero() && time.Now().Add(wait).After(b.deadline) {
+		return false
+	}
+
+	b.retries++
+	return true
+}
+
+// retryWait waits for wait, or longer if the API asked for it with
+// Retry-After, up to the client's limit, before retrying a request that
+// failed with err. It returns err wrapped in ErrRetryBudgetExhausted if the
+// budget of ctx does not allow the retry, or the context's error if it is
+// done first.
+func (c *Client) retryWait(ctx context.Context, err error, wait time.Duration) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		wait = max(wait, min(apiErr.RetryAfter, c.retryAfterLimit()))
+	}
+
+	if !takeRetry(ctx, wait) {
+		return fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, err)
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter returns the wait asked for by a Retry-After header value,
+// given either in seconds or as an HTTP date, at time now. It returns zero if
+// v is empty or malformed.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+
+	return 0
+}
+
+// backoffPolicy returns the client's backoff policy.
+func (c *Client) backoffPolicy() Backoff {
+	if c.backoff != nil {
+		return c.backoff
+	}
+
+	return LinearBackoff(c.retryBackoff)
+}
+
+// retryAfterLimit returns the longest wait the client honours from a
+// Retry-After header.
+func (c *Client) retryAfterLimit() time.Duration {
+	if c.maxRetryAfter > 0 {
+		return c.maxRetryAfter
+	}
+
+	return DefaultMaxRetryAfter
+}
+
+// retryable reports whether a failed GET request is worth retrying.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
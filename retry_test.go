@@ -0,0 +1,190 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetries(t *testing.T) {
+	var calls, failures atomic.Int32
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error": "try again"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithRetries(3, time.Millisecond))
+	require.NoError(t, err)
+
+	reset := func(n int32) {
+		calls.Store(0)
+		failures.Store(n)
+	}
+
+	reset(2)
+	_, err = client.GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	reset(10)
+	_, err = client.GetTags(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, int32(4), calls.Load())
+
+	// Writes are not retried.
+	reset(1)
+	_, err = client.Post(context.Background(), "/v1/tags", nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	status = http.StatusNotFound
+	reset(1)
+	_, err = client.GetTags(context.Background())
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = NewClient("test-token", WithRetries(-1, 0))
+	assert.Error(t, err)
+}
+
+func TestRetryBudget(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithRetries(5, time.Millisecond))
+	require.NoError(t, err)
+
+	// The budget is shared by both requests.
+	ctx := WithRetryBudget(context.Background(), RetryBudget{MaxRetries: 3})
+	_, err = client.GetTags(ctx)
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(4), calls.Load())
+	_, err = client.GetTags(ctx)
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.Equal(t, int32(5), calls.Load())
+
+	// A retry that would end after the deadline is not made.
+	slow, err := client.With(WithRetries(5, time.Hour))
+	require.NoError(t, err)
+	calls.Store(0)
+	start := time.Now()
+	_, err = slow.GetTags(WithRetryBudget(context.Background(), RetryBudget{MaxElapsed: time.Minute}))
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	type call struct {
+		attempt int
+		prev    time.Duration
+	}
+	var calls []call
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithBackoff(BackoffFunc(func(attempt int, prev time.Duration, err error) time.Duration {
+		assert.ErrorContains(t, err, "502")
+		calls = append(calls, call{attempt, prev})
+		return time.Duration(attempt) * time.Microsecond
+	})), WithRetries(2, time.Hour))
+	require.NoError(t, err)
+
+	_, err = client.GetTags(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, []call{{1, 0}, {2, time.Microsecond}}, calls)
+
+	_, err = NewClient("test-token", WithBackoff(nil))
+	assert.Error(t, err)
+}
+
+func TestBackoffPolicies(t *testing.T) {
+	linear := LinearBackoff(time.Second)
+	assert.Equal(t, time.Second, linear.Delay(1, 0, nil))
+	assert.Equal(t, 3*time.Second, linear.Delay(3, 2*time.Second, nil))
+
+	jitter := DecorrelatedJitter(time.Second, 10*time.Second)
+	var prev time.Duration
+	for attempt := 1; attempt <= 20; attempt++ {
+		d := jitter.Delay(attempt, prev, nil)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, max(3*prev, time.Second))
+		assert.LessOrEqual(t, d, 10*time.Second)
+		prev = d
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithRetries(3, time.Microsecond))
+	require.NoError(t, err)
+
+	// The budget has room for the backoff but not for the 30 seconds the
+	// API asked for.
+	ctx := WithRetryBudget(context.Background(), RetryBudget{MaxElapsed: time.Second})
+	_, err = client.GetTags(ctx)
+	require.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 30*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// A day-long Retry-After is cut down to the client's limit.
+	calls.Store(0)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer slow.Close()
+
+	client, err = NewClient("test-token", WithBaseURL(slow.URL), WithRetries(1, time.Microsecond), WithMaxRetryAfter(10*time.Millisecond))
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = client.GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	_, err = NewClient("test-token", WithMaxRetryAfter(0))
+	require.Error(t, err)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+	assert.Equal(t, time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+}
@@ -0,0 +1,264 @@
+// Package review collects changes that need a human decision before they are
+// written to Lunch Money, such as conflicting rules, low-confidence
+// classifications and reconciliation mismatches.
+package review
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/store"
+)
+
+// Kind describes why an item needs attention.
+type Kind string
+
+// Item kinds.
+const (
+	KindRuleConflict           Kind = "rule_conflict"
+	KindLowConfidence          Kind = "low_confidence"
+	KindReconciliationMismatch Kind = "reconciliation_mismatch"
+)
+
+// Status is the state of an item in the queue.
+type Status string
+
+// Item statuses.
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+var (
+	// ErrNotFound is returned for unknown item IDs.
+	ErrNotFound = errors.New("review item not found")
+	// ErrResolved is returned when approving or rejecting an item that is
+	// no longer pending.
+	ErrResolved = errors.New("review item already resolved")
+)
+
+// Item is a single change awaiting review.
+type Item struct {
+	ID            string                   `json:"id"`
+	Kind          Kind                     `json:"kind"`
+	TransactionID lunchmoney.TransactionID `json:"transaction_id"`
+	Reason        string                   `json:"reason"`
+	// Proposed is the update applied to the transaction on approval. It may
+	// be nil for items that only need acknowledging.
+	Proposed   *lunchmoney.UpdateTransaction `json:"proposed,omitempty"`
+	Status     Status                        `json:"status"`
+	Note       string                        `json:"note,omitempty"`
+	CreatedAt  time.Time                     `json:"created_at"`
+	ResolvedAt time.Time                     `json:"resolved_at,omitempty"`
+}
+
+// Store persists review items. KVStore keeps them in a store.Store;
+// MemoryStore loses them when the process exits.
+type Store interface {
+	Save(ctx context.Context, item *Item) error
+	Load(ctx context.Context, id string) (*Item, error)
+	List(ctx context.Context) ([]*Item, error)
+}
+
+// Updater applies approved changes. *lunchmoney.Client implements it.
+type Updater interface {
+	UpdateTransaction(ctx context.Context, id lunchmoney.TransactionID, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error)
+}
+
+// Queue is a review queue backed by a Store.
+type Queue struct {
+	store   Store
+	updater Updater
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewQueue creates a queue that persists items in store and writes approved
+// changes through updater.
+func NewQueue(store Store, updater Updater) *Queue {
+	return &Queue{store: store, updater: updater, now: time.Now}
+}
+
+// Add puts an item in the queue as pending and returns its ID.
+func (q *Queue) Add(ctx context.Context, item *Item) (string, error) {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("generate item id: %w", err)
+	}
+
+	item.ID = hex.EncodeToString(id[:])
+	item.Status = StatusPending
+	item.CreatedAt = q.now()
+	if err := q.store.Save(ctx, item); err != nil {
+		return "", fmt.Errorf("save review item: %w", err)
+	}
+
+	return item.ID, nil
+}
+
+// Pending returns the pending items, oldest first.
+func (q *Queue) Pending(ctx context.Context) ([]*Item, error) {
+	items, err := q.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list review items: %w", err)
+	}
+
+	var ret []*Item
+	for _, it := range items {
+		if it.Status == StatusPending {
+			ret = append(ret, it)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].CreatedAt.Before(ret[j].CreatedAt) })
+
+	return ret, nil
+}
+
+// Approve applies the item's proposed update, if any, and marks it approved.
+// If the API write fails the item stays pending.
+func (q *Queue) Approve(ctx context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, err := q.pending(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if item.Proposed != nil {
+		if _, err := q.updater.UpdateTransaction(ctx, item.TransactionID, item.Proposed); err != nil {
+			return fmt.Errorf("apply review item %s: %w", id, err)
+		}
+	}
+
+	return q.resolve(ctx, item, StatusApproved, "")
+}
+
+// Reject marks the item rejected without writing anything to the API.
+func (q *Queue) Reject(ctx context.Context, id, note string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, err := q.pending(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return q.resolve(ctx, item, StatusRejected, note)
+}
+
+func (q *Queue) pending(ctx context.Context, id string) (*Item, error) {
+	item, err := q.store.Load(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("load review item %s: %w", id, err)
+	}
+
+	if item.Status != StatusPending {
+		return nil, fmt.Errorf("review item %s is %s: %w", id, item.Status, ErrResolved)
+	}
+
+	return item, nil
+}
+
+func (q *Queue) resolve(ctx context.Context, item *Item, s Status, note string) error {
+	item.Status = s
+	item.Note = note
+	item.ResolvedAt = q.now()
+	if err := q.store.Save(ctx, item); err != nil {
+		return fmt.Errorf("save review item %s: %w", item.ID, err)
+	}
+
+	return nil
+}
+
+// MemoryStore is an in-memory Store, useful for tests and short-lived tools.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	items map[string]Item
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{items: map[string]Item{}}
+}
+
+// Save implements Store.
+func (m *MemoryStore) Save(_ context.Context, item *Item) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items[item.ID] = *item
+	return nil
+}
+
+// Load implements Store.
+func (m *MemoryStore) Load(_ context.Context, id string) (*Item, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	it, ok := m.items[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &it, nil
+}
+
+// List implements Store.
+func (m *MemoryStore) List(_ context.Context) ([]*Item, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make([]*Item, 0, len(m.items))
+	for _, it := range m.items {
+		ret = append(ret, &it)
+	}
+
+	return ret, nil
+}
+
+// Items is the entity KVStore keeps review items under.
+const Items store.Entity = "review_items"
+
+// KVStore is a Store that keeps items in a store.Store, such as a store.Dir,
+// so the queue survives restarts.
+type KVStore struct {
+	kv store.Store
+}
+
+// NewKVStore creates a Store keeping items in kv under the Items entity.
+func NewKVStore(kv store.Store) *KVStore {
+	return &KVStore{kv: kv}
+}
+
+// Save implements Store.
+func (k *KVStore) Save(ctx context.Context, item *Item) error {
+	return store.PutJSON(ctx, k.kv, Items, item.ID, item)
+}
+
+// Load implements Store.
+func (k *KVStore) Load(ctx context.Context, id string) (*Item, error) {
+	it := &Item{}
+	err := store.GetJSON(ctx, k.kv, Items, id, it)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return it, nil
+}
+
+// List implements Store.
+func (k *KVStore) List(ctx context.Context) ([]*Item, error) {
+	return store.ListJSON[Item](ctx, k.kv, Items)
+}
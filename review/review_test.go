@@ -0,0 +1,105 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpdater struct {
+	calls map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction
+	err   error
+}
+
+func (f *fakeUpdater) UpdateTransaction(_ context.Context, id lunchmoney.TransactionID, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.calls[id] = ut
+	return &lunchmoney.UpdateTransactionResp{Updated: true}, nil
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	up := &fakeUpdater{calls: map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction{}}
+	q := NewQueue(NewMemoryStore(), up)
+
+	approveID, err := q.Add(ctx, &Item{
+		Kind:          KindLowConfidence,
+		TransactionID: 10,
+		Reason:        "payee matched 2 rules",
+		Proposed:      &lunchmoney.UpdateTransaction{CategoryID: lunchmoney.Ptr(lunchmoney.CategoryID(3))},
+	})
+	require.NoError(t, err)
+	rejectID, err := q.Add(ctx, &Item{Kind: KindRuleConflict, TransactionID: 11})
+	require.NoError(t, err)
+
+	pending, err := q.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	up.err = errors.New("api down")
+	require.Error(t, q.Approve(ctx, approveID))
+	pending, err = q.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	up.err = nil
+	require.NoError(t, q.Approve(ctx, approveID))
+	assert.Equal(t, lunchmoney.CategoryID(3), *up.calls[10].CategoryID)
+	assert.ErrorIs(t, q.Approve(ctx, approveID), ErrResolved)
+
+	require.NoError(t, q.Reject(ctx, rejectID, "wrong merchant"))
+	_, ok := up.calls[11]
+	assert.False(t, ok)
+
+	pending, err = q.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	assert.ErrorIs(t, q.Reject(ctx, "missing", ""), ErrNotFound)
+}
+
+func TestKVStoreSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	up := &fakeUpdater{calls: map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction{}}
+
+	kv, err := store.OpenDir(dir)
+	require.NoError(t, err)
+	id, err := NewQueue(NewKVStore(kv), up).Add(ctx, &Item{Kind: KindReconciliationMismatch, TransactionID: 12, Reason: "balance off by 3.00"})
+	require.NoError(t, err)
+
+	kv, err = store.OpenDir(dir)
+	require.NoError(t, err)
+	q := NewQueue(NewKVStore(kv), up)
+	pending, err := q.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, id, pending[0].ID)
+	assert.Equal(t, "balance off by 3.00", pending[0].Reason)
+
+	require.NoError(t, q.Reject(ctx, id, "fixed by hand"))
+	assert.ErrorIs(t, q.Reject(ctx, "missing", ""), ErrNotFound)
+}
+
+func TestKVStoreKeepsClears(t *testing.T) {
+	ctx := context.Background()
+	up := &fakeUpdater{calls: map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction{}}
+
+	kv, err := store.OpenDir(t.TempDir())
+	require.NoError(t, err)
+	q := NewQueue(NewKVStore(kv), up)
+	id, err := q.Add(ctx, &Item{Kind: KindLowConfidence, TransactionID: 13, Proposed: &lunchmoney.UpdateTransaction{ClearCategory: true}})
+	require.NoError(t, err)
+
+	require.NoError(t, q.Approve(ctx, id))
+	require.NotNil(t, up.calls[13])
+	assert.True(t, up.calls[13].ClearCategory)
+	assert.Nil(t, up.calls[13].CategoryID)
+}
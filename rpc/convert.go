@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"github.com/icco/lunchmoney"
+	lunchmoneyv1 "github.com/icco/lunchmoney/proto/lunchmoney/v1"
+)
+
+// optional converts a nullable reference to a proto3 optional field.
+func optional[ID ~int64](id *ID) *int64 {
+	if id == nil {
+		return nil
+	}
+
+	v := int64(*id)
+	return &v
+}
+
+// convertID is the inverse of optional.
+func convertID[ID ~int64](v *int64) *ID {
+	if v == nil {
+		return nil
+	}
+
+	id := ID(*v)
+	return &id
+}
+
+func newTransaction(t *lunchmoney.Transaction) *lunchmoneyv1.Transaction {
+	ret := &lunchmoneyv1.Transaction{
+		Id:             int64(t.ID),
+		Date:           t.Date.String(),
+		Payee:          t.Payee,
+		Amount:         t.Amount.String(),
+		Currency:       t.Currency,
+		ToBase:         t.ToBase.String(),
+		Notes:          t.Notes,
+		CategoryId:     optional(t.CategoryID),
+		AssetId:        optional(t.AssetID),
+		PlaidAccountId: optional(t.PlaidAccountID),
+		RecurringId:    optional(t.RecurringID),
+		Status:         t.Status,
+		IsGroup:        t.IsGroup,
+		GroupId:        optional(t.GroupID),
+		ParentId:       optional(t.ParentID),
+		ExternalId:     t.ExternalID,
+	}
+	for _, tag := range t.Tags {
+		ret.Tags = append(ret.Tags, &lunchmoneyv1.Tag{Id: int64(tag.ID), Name: tag.Name})
+	}
+
+	return ret
+}
+
+func newCategory(c *lunchmoney.Category) *lunchmoneyv1.Category {
+	return &lunchmoneyv1.Category{
+		Id:                int64(c.ID),
+		Name:              c.Name,
+		Description:       c.Description,
+		IsIncome:          c.IsIncome,
+		ExcludeFromBudget: c.ExcludeFromBudget,
+		ExcludeFromTotals: c.ExcludeFromTotals,
+		IsGroup:           c.IsGroup,
+		GroupId:           optional(c.GroupID),
+		Archived:          c.Archived,
+		Order:             int32(c.Order),
+	}
+}
+
+func newBudget(b *lunchmoney.Budget) *lunchmoneyv1.Budget {
+	ret := &lunchmoneyv1.Budget{
+		CategoryId:        int64(b.CategoryID),
+		CategoryName:      b.CategoryName,
+		CategoryGroupName: b.CategoryGroupName,
+		GroupId:           optional(b.GroupID),
+		IsGroup:           b.IsGroup,
+		IsIncome:          b.IsIncome,
+		ExcludeFromBudget: b.ExcludeFromBudget,
+		ExcludeFromTotals: b.ExcludeFromTotals,
+	}
+	for _, m := range b.Months() {
+		bd, ok := b.Month(m)
+		if !ok {
+			continue
+		}
+		ret.Months = append(ret.Months, &lunchmoneyv1.BudgetMonth{
+			Month:           m.String(),
+			Budgeted:        bd.BudgetToBase.String(),
+			Spent:           bd.SpendingToBase.String(),
+			Remaining:       bd.Remaining().String(),
+			NumTransactions: int32(bd.NumTransactions),
+		})
+	}
+
+	return ret
+}
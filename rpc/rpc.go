@@ -0,0 +1,165 @@
+// Package rpc implements LunchMoneyService, the gRPC service defined in
+// proto/lunchmoney/v1/lunchmoney.proto, so services that are not written in
+// Go can read transactions, categories and budgets through a typed
+// interface.
+//
+// Register a Server on a grpc.Server with
+// lunchmoneyv1.RegisterLunchMoneyServiceServer. Client errors are returned
+// with the matching gRPC status code, such as NOT_FOUND for
+// lunchmoney.ErrNotFound. Every call is made through the server's client, so
+// the server must only be reachable by trusted callers.
+package rpc
+
+//go:generate sh -c "cd ../proto && buf generate"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/icco/lunchmoney"
+	lunchmoneyv1 "github.com/icco/lunchmoney/proto/lunchmoney/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backend is the subset of *lunchmoney.Client the server needs.
+type Backend interface {
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+	GetTransaction(ctx context.Context, id lunchmoney.TransactionID, opts *lunchmoney.GetTransactionOptions) (*lunchmoney.Transaction, error)
+	GetCategories(ctx context.Context) ([]*lunchmoney.Category, error)
+	GetBudgets(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+}
+
+// Server implements lunchmoneyv1.LunchMoneyServiceServer.
+type Server struct {
+	lunchmoneyv1.UnimplementedLunchMoneyServiceServer
+
+	backend Backend
+}
+
+// NewServer creates a server reading through b.
+func NewServer(b Backend) *Server {
+	return &Server{backend: b}
+}
+
+// ListTransactions implements LunchMoneyService.ListTransactions.
+func (s *Server) ListTransactions(ctx context.Context, req *lunchmoneyv1.ListTransactionsRequest) (*lunchmoneyv1.ListTransactionsResponse, error) {
+	filters := &lunchmoney.TransactionFilters{
+		CategoryID:     convertID[lunchmoney.CategoryID](req.CategoryId),
+		AssetID:        convertID[lunchmoney.AssetID](req.AssetId),
+		PlaidAccountID: convertID[lunchmoney.PlaidAccountID](req.PlaidAccountId),
+		TagID:          convertID[lunchmoney.TagID](req.TagId),
+	}
+	if req.GetStartDate() != "" || req.GetEndDate() != "" {
+		start, end, err := dates(req.GetStartDate(), req.GetEndDate())
+		if err != nil {
+			return nil, err
+		}
+		filters.StartDate, filters.EndDate = &start, &end
+	}
+
+	txns, err := s.backend.GetAllTransactions(lunchmoney.WithOperation(ctx, "rpc.ListTransactions"), filters, nil)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	// The API cannot filter by status, so it is done here.
+	resp := &lunchmoneyv1.ListTransactionsResponse{}
+	for _, t := range txns {
+		if req.GetStatus() == "" || t.Status == req.GetStatus() {
+			resp.Transactions = append(resp.Transactions, newTransaction(t))
+		}
+	}
+
+	return resp, nil
+}
+
+// GetTransaction implements LunchMoneyService.GetTransaction.
+func (s *Server) GetTransaction(ctx context.Context, req *lunchmoneyv1.GetTransactionRequest) (*lunchmoneyv1.Transaction, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	t, err := s.backend.GetTransaction(lunchmoney.WithOperation(ctx, "rpc.GetTransaction"), lunchmoney.TransactionID(req.GetId()), nil)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	return newTransaction(t), nil
+}
+
+// ListCategories implements LunchMoneyService.ListCategories.
+func (s *Server) ListCategories(ctx context.Context, _ *lunchmoneyv1.ListCategoriesRequest) (*lunchmoneyv1.ListCategoriesResponse, error) {
+	cats, err := s.backend.GetCategories(lunchmoney.WithOperation(ctx, "rpc.ListCategories"))
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	resp := &lunchmoneyv1.ListCategoriesResponse{}
+	for _, c := range cats {
+		resp.Categories = append(resp.Categories, newCategory(c))
+	}
+
+	return resp, nil
+}
+
+// GetBudgets implements LunchMoneyService.GetBudgets.
+func (s *Server) GetBudgets(ctx context.Context, req *lunchmoneyv1.GetBudgetsRequest) (*lunchmoneyv1.GetBudgetsResponse, error) {
+	start, end, err := dates(req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.backend.GetBudgets(lunchmoney.WithOperation(ctx, "rpc.GetBudgets"), &lunchmoney.BudgetFilters{StartDate: start, EndDate: end})
+	if err != nil {
+		return nil, statusOf(err)
+	}
+
+	resp := &lunchmoneyv1.GetBudgetsResponse{}
+	for _, b := range budgets {
+		resp.Budgets = append(resp.Budgets, newBudget(b))
+	}
+
+	return resp, nil
+}
+
+func dates(start, end string) (lunchmoney.Date, lunchmoney.Date, error) {
+	if start == "" || end == "" {
+		return lunchmoney.Date{}, lunchmoney.Date{}, status.Error(codes.InvalidArgument, "start_date and end_date must be given together")
+	}
+
+	from, err := lunchmoney.ParseDate(start)
+	if err != nil {
+		return lunchmoney.Date{}, lunchmoney.Date{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	to, err := lunchmoney.ParseDate(end)
+	if err != nil {
+		return lunchmoney.Date{}, lunchmoney.Date{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return from, to, nil
+}
+
+// statusOf maps an error from the client to a gRPC status.
+func statusOf(err error) error {
+	code := codes.Unknown
+	var apiErr *lunchmoney.APIError
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, lunchmoney.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, lunchmoney.ErrUnauthorized):
+		code = codes.Unauthenticated
+	case errors.Is(err, lunchmoney.ErrRateLimited):
+		code = codes.ResourceExhausted
+	case lunchmoney.IsValidation(err):
+		code = codes.InvalidArgument
+	case errors.As(err, &apiErr) && apiErr.StatusCode >= 500:
+		code = codes.Unavailable
+	}
+
+	return status.Error(code, err.Error())
+}
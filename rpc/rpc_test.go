@@ -0,0 +1,166 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	lunchmoneyv1 "github.com/icco/lunchmoney/proto/lunchmoney/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeBackend struct {
+	filters *lunchmoney.TransactionFilters
+	budgets *lunchmoney.BudgetFilters
+	ops     []string
+}
+
+func (f *fakeBackend) GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, _ *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error) {
+	f.filters = filters
+	f.ops = append(f.ops, lunchmoney.OperationFromContext(ctx))
+	return []*lunchmoney.Transaction{
+		{ID: 1, Date: lunchmoney.NewDate(2024, 1, 5), Payee: "Cafe", Amount: lunchmoney.NewDecimal(450, 2), Currency: "usd", Status: "cleared", CategoryID: lunchmoney.Ptr(lunchmoney.CategoryID(7)), Tags: []*lunchmoney.Tag{{ID: 3, Name: "coffee"}}},
+		{ID: 2, Date: lunchmoney.NewDate(2024, 1, 6), Payee: "Rent", Amount: lunchmoney.NewDecimal(100000, 2), Currency: "usd", Status: "uncleared"},
+	}, nil
+}
+
+func (f *fakeBackend) GetTransaction(_ context.Context, id lunchmoney.TransactionID, _ *lunchmoney.GetTransactionOptions) (*lunchmoney.Transaction, error) {
+	if id != 1 {
+		return nil, fmt.Errorf("get transaction: %w", lunchmoney.ErrNotFound)
+	}
+	return &lunchmoney.Transaction{ID: 1, Payee: "Cafe", Amount: lunchmoney.NewDecimal(450, 2)}, nil
+}
+
+func (f *fakeBackend) GetCategories(context.Context) ([]*lunchmoney.Category, error) {
+	return []*lunchmoney.Category{{ID: 7, Name: "Coffee", GroupID: lunchmoney.Ptr(lunchmoney.CategoryID(0))}}, nil
+}
+
+func (f *fakeBackend) GetBudgets(_ context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	f.budgets = filters
+	return []*lunchmoney.Budget{{
+		CategoryID:   7,
+		CategoryName: "Coffee",
+		Data: map[string]*lunchmoney.BudgetData{
+			"2024-01-01": {BudgetMonth: lunchmoney.NewDate(2024, 1, 1), BudgetToBase: lunchmoney.NewDecimal(5000, 2), SpendingToBase: lunchmoney.NewDecimal(450, 2), NumTransactions: 1},
+		},
+	}}, nil
+}
+
+// dial serves b over an in-memory listener and returns a client for it.
+func dial(t *testing.T, b Backend) lunchmoneyv1.LunchMoneyServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	lunchmoneyv1.RegisterLunchMoneyServiceServer(srv, NewServer(b))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return lunchmoneyv1.NewLunchMoneyServiceClient(conn)
+}
+
+func TestListTransactions(t *testing.T) {
+	f := &fakeBackend{}
+	c := dial(t, f)
+
+	resp, err := c.ListTransactions(context.Background(), &lunchmoneyv1.ListTransactionsRequest{
+		StartDate:  "2024-01-01",
+		EndDate:    "2024-01-31",
+		CategoryId: proto.Int64(7),
+		TagId:      proto.Int64(3),
+		Status:     "cleared",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Transactions, 1)
+	txn := resp.Transactions[0]
+	assert.Equal(t, int64(1), txn.GetId())
+	assert.Equal(t, "2024-01-05", txn.GetDate())
+	assert.Equal(t, "4.50", txn.GetAmount())
+	assert.Equal(t, int64(7), txn.GetCategoryId())
+	assert.Nil(t, txn.AssetId)
+	require.Len(t, txn.Tags, 1)
+	assert.Equal(t, "coffee", txn.Tags[0].GetName())
+
+	assert.Equal(t, "2024-01-01", f.filters.StartDate.String())
+	assert.Equal(t, "2024-01-31", f.filters.EndDate.String())
+	assert.Equal(t, lunchmoney.CategoryID(7), *f.filters.CategoryID)
+	assert.Equal(t, lunchmoney.TagID(3), *f.filters.TagID)
+	assert.Nil(t, f.filters.AssetID)
+	assert.Equal(t, []string{"rpc.ListTransactions"}, f.ops)
+
+	_, err = c.ListTransactions(context.Background(), &lunchmoneyv1.ListTransactionsRequest{StartDate: "2024-01-01"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetTransaction(t *testing.T) {
+	c := dial(t, &fakeBackend{})
+
+	txn, err := c.GetTransaction(context.Background(), &lunchmoneyv1.GetTransactionRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Cafe", txn.GetPayee())
+
+	_, err = c.GetTransaction(context.Background(), &lunchmoneyv1.GetTransactionRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = c.GetTransaction(context.Background(), &lunchmoneyv1.GetTransactionRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListCategories(t *testing.T) {
+	c := dial(t, &fakeBackend{})
+
+	resp, err := c.ListCategories(context.Background(), &lunchmoneyv1.ListCategoriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Categories, 1)
+	assert.Equal(t, "Coffee", resp.Categories[0].GetName())
+	// A group ID of 0 is kept apart from no group.
+	require.NotNil(t, resp.Categories[0].GroupId)
+	assert.Equal(t, int64(0), resp.Categories[0].GetGroupId())
+}
+
+func TestGetBudgets(t *testing.T) {
+	f := &fakeBackend{}
+	c := dial(t, f)
+
+	resp, err := c.GetBudgets(context.Background(), &lunchmoneyv1.GetBudgetsRequest{StartDate: "2024-01-01", EndDate: "2024-01-31"})
+	require.NoError(t, err)
+	require.Len(t, resp.Budgets, 1)
+	require.Len(t, resp.Budgets[0].Months, 1)
+	m := resp.Budgets[0].Months[0]
+	assert.Equal(t, "2024-01-01", m.GetMonth())
+	assert.Equal(t, "50.00", m.GetBudgeted())
+	assert.Equal(t, "4.50", m.GetSpent())
+	assert.Equal(t, "45.50", m.GetRemaining())
+	assert.Equal(t, int32(1), m.GetNumTransactions())
+	assert.Equal(t, "2024-01-31", f.budgets.EndDate.String())
+}
+
+func TestStatusOf(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("x: %w", context.Canceled), codes.Canceled},
+		{fmt.Errorf("x: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{fmt.Errorf("x: %w", lunchmoney.ErrUnauthorized), codes.Unauthenticated},
+		{fmt.Errorf("x: %w", lunchmoney.ErrRateLimited), codes.ResourceExhausted},
+		{&lunchmoney.APIError{StatusCode: http.StatusBadGateway}, codes.Unavailable},
+		{fmt.Errorf("boom"), codes.Unknown},
+	} {
+		assert.Equal(t, tc.code, status.Code(statusOf(tc.err)), tc.err.Error())
+	}
+}
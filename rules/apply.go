@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/review"
+)
+
+// Applier runs a RuleSet over transactions and writes the resulting updates.
+type Applier struct {
+	Rules   *RuleSet
+	Updater review.Updater
+	// Workers is the number of updates sent concurrently. Defaults to 4.
+	Workers int
+	// Review, if set, receives transactions whose rules conflict as
+	// rule_conflict items, with the first rule's update proposed. Without
+	// it conflicting transactions are only reported.
+	Review *review.Queue
+	// DryRun plans updates without writing them.
+	DryRun bool
+}
+
+// Planned is an update the applier made, or would make in a dry run.
+type Planned struct {
+	Transaction *lunchmoney.Transaction
+	Update      *lunchmoney.UpdateTransaction
+	Rules       []string
+}
+
+// ApplyResult describes what Apply did.
+type ApplyResult struct {
+	Updated   []Planned
+	Conflicts []Planned
+	// Failed holds the updates the API rejected, keyed by transaction.
+	Failed map[lunchmoney.TransactionID]error
+}
+
+// Apply plans an update for every transaction and sends those that change
+// something. Conflicting transactions are not updated. The returned error
+// joins all update and review queue failures; the result is complete either
+// way.
+func (a *Applier) Apply(ctx context.Context, txns []*lunchmoney.Transaction) (*ApplyResult, error) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "rules")
+
+	res := &ApplyResult{Failed: map[lunchmoney.TransactionID]error{}}
+	var (
+		todo []Planned
+		errs []error
+	)
+	for _, t := range txns {
+		ut, names, err := a.Rules.Plan(t)
+		p := Planned{Transaction: t, Update: ut, Rules: names}
+		switch {
+		case errors.Is(err, ErrConflict):
+			res.Conflicts = append(res.Conflicts, p)
+			if a.Review != nil && !a.DryRun {
+				item := &review.Item{Kind: review.KindRuleConflict, TransactionID: t.ID, Reason: err.Error(), Proposed: ut}
+				if _, err := a.Review.Add(ctx, item); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		case ut != nil:
+			todo = append(todo, p)
+		}
+	}
+
+	if a.DryRun {
+		res.Updated = todo
+		return res, errors.Join(errs...)
+	}
+
+	workers := a.Workers
+	if workers < 1 {
+		workers = 4
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan Planned)
+	)
+	for range min(workers, len(todo)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				_, err := a.Updater.UpdateTransaction(ctx, p.Transaction.ID, p.Update)
+
+				mu.Lock()
+				if err != nil {
+					res.Failed[p.Transaction.ID] = err
+					errs = append(errs, fmt.Errorf("transaction %d (%s): %w", p.Transaction.ID, strings.Join(p.Rules, ", "), err))
+				} else {
+					res.Updated = append(res.Updated, p)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, p := range todo {
+		jobs <- p
+	}
+	close(jobs)
+	wg.Wait()
+
+	return res, errors.Join(errs...)
+}
@@ -0,0 +1,213 @@
+// Package rules categorizes and tags transactions on the client side with
+// declarative rules, for logic Lunch Money's own rules cannot express.
+//
+// Rules are usually loaded from JSON:
+//
+//	[
+//	  {"name": "coffee", "if": {"payee": "(?i)coffee|espresso"}, "then": {"set_category": 12, "add_tags": [3]}},
+//	  {"name": "rent", "if": {"payee": "^ACME PROPERTIES", "min_amount": "1000"}, "then": {"set_category": 4, "mark_reviewed": true}}
+//	]
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+
+	"github.com/icco/lunchmoney"
+)
+
+// ErrConflict is returned by Plan when matching rules disagree, for example
+// by setting different categories.
+var ErrConflict = errors.New("conflicting rules")
+
+// Condition selects transactions. All set fields must match; an empty
+// condition matches every transaction.
+type Condition struct {
+	// Payee is a regular expression matched against the payee.
+	Payee string `json:"payee,omitempty"`
+	// MinAmount and MaxAmount bound the amount, inclusive. Debits are
+	// positive, as returned by the API.
+	MinAmount      *lunchmoney.Decimal        `json:"min_amount,omitempty"`
+	MaxAmount      *lunchmoney.Decimal        `json:"max_amount,omitempty"`
+	AssetID        *lunchmoney.AssetID        `json:"asset_id,omitempty"`
+	PlaidAccountID *lunchmoney.PlaidAccountID `json:"plaid_account_id,omitempty"`
+	// Uncategorized only matches transactions without a category.
+	Uncategorized bool `json:"uncategorized,omitempty"`
+}
+
+// Action is what a rule does to the transactions it matches.
+type Action struct {
+	SetCategory *lunchmoney.CategoryID `json:"set_category,omitempty"`
+	AddTags     []lunchmoney.TagID     `json:"add_tags,omitempty"`
+	SetNotes    *string                `json:"set_notes,omitempty"`
+	// MarkReviewed sets the status to cleared.
+	MarkReviewed bool `json:"mark_reviewed,omitempty"`
+}
+
+// Rule maps a condition to an action.
+type Rule struct {
+	Name string    `json:"name"`
+	If   Condition `json:"if"`
+	Then Action    `json:"then"`
+}
+
+// RuleSet is a compiled, ordered list of rules.
+type RuleSet struct {
+	rules []*compiled
+}
+
+type compiled struct {
+	Rule
+	payee *regexp.Regexp
+}
+
+// Compile checks rules and compiles their patterns.
+func Compile(rules []Rule) (*RuleSet, error) {
+	rs := &RuleSet{}
+	for i, r := range rules {
+		c := &compiled{Rule: r}
+		if r.Name == "" {
+			c.Name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		if r.If.Payee != "" {
+			re, err := regexp.Compile(r.If.Payee)
+			if err != nil {
+				return nil, fmt.Errorf("%s: payee: %w", c.Name, err)
+			}
+			c.payee = re
+		}
+		if r.If.MinAmount != nil && r.If.MaxAmount != nil && r.If.MinAmount.Cmp(*r.If.MaxAmount) > 0 {
+			return nil, fmt.Errorf("%s: min_amount is greater than max_amount", c.Name)
+		}
+		if r.Then.SetCategory == nil && len(r.Then.AddTags) == 0 && r.Then.SetNotes == nil && !r.Then.MarkReviewed {
+			return nil, fmt.Errorf("%s: no action", c.Name)
+		}
+
+		rs.rules = append(rs.rules, c)
+	}
+
+	return rs, nil
+}
+
+// Load reads a JSON array of rules and compiles it.
+func Load(r io.Reader) (*RuleSet, error) {
+	var rules []Rule
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+
+	return Compile(rules)
+}
+
+func (c *compiled) matches(t *lunchmoney.Transaction) bool {
+	cond := c.If
+	switch {
+	case c.payee != nil && !c.payee.MatchString(t.Payee):
+		return false
+	case cond.MinAmount != nil && t.Amount.Cmp(*cond.MinAmount) < 0:
+		return false
+	case cond.MaxAmount != nil && t.Amount.Cmp(*cond.MaxAmount) > 0:
+		return false
+	case cond.AssetID != nil && (t.AssetID == nil || *t.AssetID != *cond.AssetID):
+		return false
+	case cond.PlaidAccountID != nil && (t.PlaidAccountID == nil || *t.PlaidAccountID != *cond.PlaidAccountID):
+		return false
+	case cond.Uncategorized && t.CategoryID != nil:
+		return false
+	default:
+		return true
+	}
+}
+
+// Match returns the names of the rules matching t, in order.
+func (rs *RuleSet) Match(t *lunchmoney.Transaction) []string {
+	var ret []string
+	for _, r := range rs.rules {
+		if r.matches(t) {
+			ret = append(ret, r.Name)
+		}
+	}
+
+	return ret
+}
+
+// Plan returns the update the matching rules make to t and the names of
+// those rules. Changes t already has are left out, so the update is nil when
+// there is nothing to do. If the rules disagree on the category or notes,
+// the update from the first rule is returned with an error wrapping
+// ErrConflict.
+func (rs *RuleSet) Plan(t *lunchmoney.Transaction) (*lunchmoney.UpdateTransaction, []string, error) {
+	var (
+		ut      lunchmoney.UpdateTransaction
+		matched []string
+		errs    []error
+		tags    []lunchmoney.TagID
+		changed bool
+	)
+	for _, tag := range t.Tags {
+		tags = append(tags, tag.ID)
+	}
+	addedTags := false
+
+	for _, r := range rs.rules {
+		if !r.matches(t) {
+			continue
+		}
+		matched = append(matched, r.Name)
+		a := r.Then
+
+		if a.SetCategory != nil {
+			switch {
+			case ut.CategoryID != nil && *ut.CategoryID != *a.SetCategory:
+				errs = append(errs, fmt.Errorf("%s sets category %d, not %d: %w", r.Name, *a.SetCategory, *ut.CategoryID, ErrConflict))
+			case ut.CategoryID == nil:
+				ut.CategoryID = a.SetCategory
+			}
+		}
+		if a.SetNotes != nil {
+			switch {
+			case ut.Notes != nil && *ut.Notes != *a.SetNotes:
+				errs = append(errs, fmt.Errorf("%s sets notes %q, not %q: %w", r.Name, *a.SetNotes, *ut.Notes, ErrConflict))
+			case ut.Notes == nil:
+				ut.Notes = a.SetNotes
+			}
+		}
+		for _, id := range a.AddTags {
+			if !slices.Contains(tags, id) {
+				tags = append(tags, id)
+				addedTags = true
+			}
+		}
+		if a.MarkReviewed {
+			ut.Status = lunchmoney.Ptr("cleared")
+		}
+	}
+
+	if ut.CategoryID != nil && t.CategoryID != nil && *ut.CategoryID == *t.CategoryID {
+		ut.CategoryID = nil
+	}
+	if ut.Notes != nil && *ut.Notes == t.Notes {
+		ut.Notes = nil
+	}
+	if ut.Status != nil && *ut.Status == t.Status {
+		ut.Status = nil
+	}
+	if addedTags {
+		ut.TagsIDs = tags
+	}
+	changed = ut.CategoryID != nil || ut.Notes != nil || ut.Status != nil || ut.TagsIDs != nil
+
+	var ret *lunchmoney.UpdateTransaction
+	if changed {
+		ret = &ut
+	}
+
+	return ret, matched, errors.Join(errs...)
+}
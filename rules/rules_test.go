@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `[
+	{"name": "coffee", "if": {"payee": "(?i)coffee"}, "then": {"set_category": 12, "add_tags": [3]}},
+	{"name": "small", "if": {"max_amount": "5"}, "then": {"add_tags": [4], "mark_reviewed": true}},
+	{"name": "snacks", "if": {"payee": "(?i)coffee & snacks"}, "then": {"set_category": 13}}
+]`
+
+func TestPlan(t *testing.T) {
+	rs, err := Load(strings.NewReader(testRules))
+	require.NoError(t, err)
+
+	ut, names, err := rs.Plan(&lunchmoney.Transaction{Payee: "Blue Coffee", Amount: lunchmoney.MustParseDecimal("4.50"), Tags: []*lunchmoney.Tag{{ID: 3}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"coffee", "small"}, names)
+	require.NotNil(t, ut)
+	assert.Equal(t, lunchmoney.CategoryID(12), *ut.CategoryID)
+	assert.Equal(t, []lunchmoney.TagID{3, 4}, ut.TagsIDs)
+	assert.Equal(t, "cleared", *ut.Status)
+
+	// Nothing to do when the transaction already looks like the rules want.
+	cat := lunchmoney.CategoryID(12)
+	ut, _, err = rs.Plan(&lunchmoney.Transaction{Payee: "Coffee", Amount: lunchmoney.MustParseDecimal("10"), CategoryID: &cat, Tags: []*lunchmoney.Tag{{ID: 3}}})
+	require.NoError(t, err)
+	assert.Nil(t, ut)
+
+	_, _, err = rs.Plan(&lunchmoney.Transaction{Payee: "Coffee & Snacks", Amount: lunchmoney.MustParseDecimal("10")})
+	assert.ErrorIs(t, err, ErrConflict)
+}
+
+func TestCompileErrors(t *testing.T) {
+	_, err := Compile([]Rule{{If: Condition{Payee: "("}, Then: Action{MarkReviewed: true}}})
+	assert.ErrorContains(t, err, "rule 1: payee")
+
+	_, err = Compile([]Rule{{Name: "noop"}})
+	assert.ErrorContains(t, err, "noop: no action")
+
+	_, err = Load(strings.NewReader(`[{"name": "x", "then": {"set_colour": 1}}]`))
+	assert.Error(t, err)
+}
+
+type fakeUpdater struct {
+	mu    sync.Mutex
+	calls map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction
+	fail  lunchmoney.TransactionID
+}
+
+func (f *fakeUpdater) UpdateTransaction(_ context.Context, id lunchmoney.TransactionID, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	if id == f.fail {
+		return nil, errors.New("boom")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[id] = ut
+	return &lunchmoney.UpdateTransactionResp{Updated: true}, nil
+}
+
+func TestApply(t *testing.T) {
+	rs, err := Load(strings.NewReader(testRules))
+	require.NoError(t, err)
+
+	up := &fakeUpdater{calls: map[lunchmoney.TransactionID]*lunchmoney.UpdateTransaction{}, fail: 3}
+	queue := review.NewQueue(review.NewMemoryStore(), up)
+	a := &Applier{Rules: rs, Updater: up, Review: queue}
+
+	res, err := a.Apply(context.Background(), []*lunchmoney.Transaction{
+		{ID: 1, Payee: "Coffee", Amount: lunchmoney.MustParseDecimal("10")},
+		{ID: 2, Payee: "Coffee & Snacks", Amount: lunchmoney.MustParseDecimal("10")},
+		{ID: 3, Payee: "Tiny", Amount: lunchmoney.MustParseDecimal("1")},
+		{ID: 4, Payee: "Rent", Amount: lunchmoney.MustParseDecimal("900")},
+	})
+	assert.ErrorContains(t, err, "transaction 3 (small): boom")
+
+	require.Len(t, res.Updated, 1)
+	assert.Equal(t, lunchmoney.TransactionID(1), res.Updated[0].Transaction.ID)
+	assert.Contains(t, up.calls, lunchmoney.TransactionID(1))
+	require.Len(t, res.Conflicts, 1)
+	assert.Contains(t, res.Failed, lunchmoney.TransactionID(3))
+
+	pending, err := queue.Pending(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, review.KindRuleConflict, pending[0].Kind)
+	assert.Equal(t, lunchmoney.TransactionID(2), pending[0].TransactionID)
+}
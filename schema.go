@@ -0,0 +1,221 @@
+package lunchmoney
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed schemas/v1.json
+var schemaJSON []byte
+
+// schema is the subset of JSON Schema the embedded schemas use: type,
+// properties, required, items, enum, format "date" and local $refs. A $ref
+// may sit next to properties and required, which add to the referenced
+// schema.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       schemaTypes        `json:"type"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *schema            `json:"items"`
+	Enum       []string           `json:"enum"`
+	Format     string             `json:"format"`
+}
+
+// schemaTypes is a JSON Schema type, which is a string or a list of them.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(b, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*t = schemaTypes{s}
+		return nil
+	}
+
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+type schemaDocument struct {
+	Endpoints map[string]*schema `json:"endpoints"`
+	Defs      map[string]*schema `json:"$defs"`
+}
+
+var loadSchemas = sync.OnceValue(func() *schemaDocument {
+	doc := &schemaDocument{}
+	if err := json.Unmarshal(schemaJSON, doc); err != nil {
+		panic(fmt.Sprintf("lunchmoney: invalid embedded schema: %v", err))
+	}
+	return doc
+})
+
+// SchemaMismatch is a place where a response does not match its schema.
+type SchemaMismatch struct {
+	// Path is the JSON pointer of the offending value, such as
+	// "/transactions/3/category_id".
+	Path    string
+	Message string
+}
+
+func (m SchemaMismatch) String() string {
+	return m.Path + ": " + m.Message
+}
+
+// SchemaError is returned under WithSchemaValidation when a response does
+// not match the schema of its endpoint.
+type SchemaError struct {
+	// Endpoint is the schema's path pattern, such as "/v1/transactions/{id}",
+	// preceded by the method for requests other than GET, such as
+	// "PUT /v1/transactions/{id}".
+	Endpoint   string
+	Mismatches []SchemaMismatch
+}
+
+func (e *SchemaError) Error() string {
+	msgs := make([]string, len(e.Mismatches))
+	for i, m := range e.Mismatches {
+		msgs[i] = m.String()
+	}
+
+	return fmt.Sprintf("response of %s does not match schema: %s", e.Endpoint, strings.Join(msgs, "; "))
+}
+
+// WithSchemaValidation checks responses against the JSON Schemas embedded
+// in the package before decoding them, failing with a SchemaError that lists
+// every mismatch by its JSON pointer. It is meant for debugging responses
+// with unexpected nulls or types. Every endpoint the client calls has a
+// schema, for writes as well as reads; other paths requested through Get,
+// Put or Post are not checked.
+func WithSchemaValidation(enabled bool) Option {
+	return func(c *Client) error {
+		c.schemaValidation = enabled
+		return nil
+	}
+}
+
+// endpointPattern replaces the numeric segments of path with {id}.
+func endpointPattern(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if _, err := strconv.ParseInt(p, 10, 64); err == nil {
+			parts[i] = "{id}"
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// checkSchema validates the response body of the request to path against
+// the schema of its endpoint, if there is one.
+func checkSchema(method, path string, body []byte) error {
+	doc := loadSchemas()
+	endpoint := endpointPattern(path)
+	if method != http.MethodGet {
+		endpoint = method + " " + endpoint
+	}
+	s, ok := doc.Endpoints[endpoint]
+	if !ok {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	var mismatches []SchemaMismatch
+	doc.check(s, v, "", &mismatches)
+	if len(mismatches) > 0 {
+		return &SchemaError{Endpoint: endpoint, Mismatches: mismatches}
+	}
+
+	return nil
+}
+
+func (d *schemaDocument) check(s *schema, v any, path string, out *[]SchemaMismatch) {
+	if s.Ref != "" {
+		ref, ok := d.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			panic("lunchmoney: unknown schema reference " + s.Ref)
+		}
+		d.check(ref, v, path, out)
+		if len(s.Properties) == 0 && len(s.Required) == 0 {
+			return
+		}
+	}
+
+	fail := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		*out = append(*out, SchemaMismatch{Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	got := jsonType(v)
+	if len(s.Type) > 0 && !slices.Contains(s.Type, got) && !(got == "integer" && slices.Contains(s.Type, "number")) {
+		fail("expected %s, got %s", strings.Join(s.Type, " or "), got)
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			if ps, ok := s.Properties[name]; ok {
+				d.check(ps, v[name], path+"/"+name, out)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				d.check(s.Items, item, path+"/"+strconv.Itoa(i), out)
+			}
+		}
+	case string:
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+			fail("%q is not one of %s", v, strings.Join(s.Enum, ", "))
+		}
+		if s.Format == "date" {
+			if _, err := ParseDate(v); err != nil {
+				fail("%q is not a date", v)
+			}
+		}
+	}
+}
+
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
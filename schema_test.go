@@ -0,0 +1,86 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchema(t *testing.T) {
+	require.NoError(t, checkSchema(http.MethodGet, "/v1/transactions", []byte(`{"transactions": [
+		{"id": 1, "date": "2024-01-02", "amount": "4.50", "to_base": 4.5, "category_id": null, "status": "cleared", "tags": null}
+	]}`)))
+	require.NoError(t, checkSchema(http.MethodGet, "/v1/unknown", []byte(`"anything"`)))
+
+	err := checkSchema(http.MethodGet, "/v1/transactions", []byte(`{"transactions": [
+		{"id": 1, "date": "2024-01-02", "amount": "4.50"},
+		{"id": "2", "date": "02/01/2024", "amount": null, "category_id": 3.5, "status": "void", "tags": [{"id": 1}]}
+	]}`))
+	var serr *SchemaError
+	require.ErrorAs(t, err, &serr)
+	assert.Equal(t, "/v1/transactions", serr.Endpoint)
+	assert.Equal(t, []SchemaMismatch{
+		{Path: "/transactions/1/amount", Message: "expected string, got null"},
+		{Path: "/transactions/1/category_id", Message: "expected integer or null, got number"},
+		{Path: "/transactions/1/date", Message: `"02/01/2024" is not a date`},
+		{Path: "/transactions/1/id", Message: "expected integer, got string"},
+		{Path: "/transactions/1/status", Message: `"void" is not one of cleared, uncleared, recurring, recurring_suggested, pending`},
+		{Path: "/transactions/1/tags/0", Message: `missing required property "name"`},
+	}, serr.Mismatches)
+
+	err = checkSchema(http.MethodGet, "/v1/transactions/42", []byte(`[]`))
+	assert.EqualError(t, err, "response of /v1/transactions/{id} does not match schema: /: expected object, got array")
+
+	// Groups are transactions with children.
+	err = checkSchema(http.MethodGet, "/v1/transactions/group", []byte(`{"id": "1", "date": "2024-01-02", "amount": "4.50"}`))
+	assert.EqualError(t, err, `response of /v1/transactions/group does not match schema: /id: expected integer, got string; /: missing required property "children"`)
+
+	// Writes are keyed by method.
+	err = checkSchema(http.MethodPut, "/v1/transactions/42", []byte(`{"updated": "yes"}`))
+	assert.EqualError(t, err, "response of PUT /v1/transactions/{id} does not match schema: /updated: expected boolean, got string")
+	require.NoError(t, checkSchema(http.MethodPost, "/v1/plaid_accounts/fetch", []byte(`true`)))
+}
+
+func TestSchemaRefs(t *testing.T) {
+	doc := loadSchemas()
+	var walk func(name string, s *schema)
+	walk = func(name string, s *schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			_, ok := doc.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+			assert.True(t, ok, "%s: unknown reference %s", name, s.Ref)
+		}
+		for _, p := range s.Properties {
+			walk(name, p)
+		}
+		walk(name, s.Items)
+	}
+	for name, s := range doc.Endpoints {
+		walk(name, s)
+	}
+	for name, s := range doc.Defs {
+		walk(name, s)
+	}
+}
+
+func TestWithSchemaValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 1, "name": null}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL), WithSchemaValidation(true))
+	require.NoError(t, err)
+
+	_, err = client.GetTags(context.Background())
+	var serr *SchemaError
+	require.ErrorAs(t, err, &serr)
+	assert.Equal(t, []SchemaMismatch{{Path: "/0/name", Message: "expected string, got null"}}, serr.Mismatches)
+}
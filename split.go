@@ -0,0 +1,225 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// ErrInvalidSplit is returned when an amount cannot be split as requested,
+// or when split parts do not add up to the transaction amount.
+var ErrInvalidSplit = errors.New("invalid split")
+
+// SplitPart is one child of a split transaction. Amount is in the parent
+// transaction's currency.
+type SplitPart struct {
+	Payee      string      `json:"payee,omitempty"`
+	Date       Date        `json:"date" validate:"required"`
+	CategoryID *CategoryID `json:"category_id,omitempty"`
+	Notes      string      `json:"notes,omitempty"`
+	Amount     Decimal     `json:"amount"`
+}
+
+// SplitEvenly divides total into n parts in the currency's minor units. Parts
+// differ by at most one minor unit, with the larger ones first, so 10.00
+// split three ways is 3.34, 3.33 and 3.33.
+func SplitEvenly(total Decimal, n int, currency string) ([]Decimal, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("split into %d parts: %w", n, ErrInvalidSplit)
+	}
+
+	weights := make([]*big.Rat, n)
+	for i := range weights {
+		weights[i] = big.NewRat(1, 1)
+	}
+
+	return splitWeighted(total, weights, currency)
+}
+
+// SplitByPercent divides total into parts of the given percentages, which
+// must be positive and add up to exactly 100. Rounding leftovers go to the
+// parts that lost the most to rounding.
+func SplitByPercent(total Decimal, percents []Decimal, currency string) ([]Decimal, error) {
+	if len(percents) == 0 {
+		return nil, fmt.Errorf("no percentages: %w", ErrInvalidSplit)
+	}
+
+	var sum Decimal
+	weights := make([]*big.Rat, len(percents))
+	for i, p := range percents {
+		if p.Sign() <= 0 {
+			return nil, fmt.Errorf("percentage %s is not positive: %w", p, ErrInvalidSplit)
+		}
+		sum = sum.Add(p)
+		weights[i] = p.Rat()
+	}
+	if !sum.Equal(NewDecimal(100, 0)) {
+		return nil, fmt.Errorf("percentages add up to %s, not 100: %w", sum, ErrInvalidSplit)
+	}
+
+	return splitWeighted(total, weights, currency)
+}
+
+// SplitFixed returns the fixed amounts followed by whatever is left of total.
+// The fixed amounts must have the same sign as total and leave a non-zero
+// remainder.
+func SplitFixed(total Decimal, fixed []Decimal, currency string) ([]Decimal, error) {
+	places := currencyFraction(currency)
+	if !total.Round(places).Equal(total) {
+		return nil, fmt.Errorf("%s has more than %d decimal places: %w", total, places, ErrInvalidSplit)
+	}
+
+	ret := make([]Decimal, 0, len(fixed)+1)
+	rest := total
+	for _, f := range fixed {
+		if !f.Round(places).Equal(f) {
+			return nil, fmt.Errorf("%s has more than %d decimal places: %w", f, places, ErrInvalidSplit)
+		}
+		if f.Sign() != total.Sign() {
+			return nil, fmt.Errorf("%s does not have the sign of %s: %w", f, total, ErrInvalidSplit)
+		}
+		rest = rest.Sub(f)
+		ret = append(ret, f)
+	}
+	if rest.Sign() != total.Sign() {
+		return nil, fmt.Errorf("fixed amounts leave %s of %s: %w", rest, total, ErrInvalidSplit)
+	}
+
+	return append(ret, rest), nil
+}
+
+// splitWeighted divides total in proportion to weights using the largest
+// remainder method, so the parts always add up to total exactly.
+func splitWeighted(total Decimal, weights []*big.Rat, currency string) ([]Decimal, error) {
+	places := currencyFraction(currency)
+	if !total.Round(places).Equal(total) {
+		return nil, fmt.Errorf("%s has more than %d decimal places: %w", total, places, ErrInvalidSplit)
+	}
+
+	units := total.Abs().Round(places).rescale(places)
+
+	sum := new(big.Rat)
+	for _, w := range weights {
+		sum.Add(sum, w)
+	}
+
+	type share struct {
+		i    int
+		rest *big.Rat
+	}
+	parts := make([]*big.Int, len(weights))
+	shares := make([]share, len(weights))
+	left := new(big.Int).Set(units)
+	for i, w := range weights {
+		exact := new(big.Rat).Mul(new(big.Rat).SetInt(units), new(big.Rat).Quo(w, sum))
+		parts[i] = new(big.Int).Quo(exact.Num(), exact.Denom())
+		shares[i] = share{i: i, rest: new(big.Rat).Sub(exact, new(big.Rat).SetInt(parts[i]))}
+		left.Sub(left, parts[i])
+	}
+
+	slices.SortStableFunc(shares, func(a, b share) int {
+		return b.rest.Cmp(a.rest)
+	})
+	for j := 0; left.Sign() > 0; j++ {
+		parts[shares[j].i].Add(parts[shares[j].i], big.NewInt(1))
+		left.Sub(left, big.NewInt(1))
+	}
+
+	ret := make([]Decimal, len(parts))
+	for i, p := range parts {
+		if total.Sign() < 0 {
+			p.Neg(p)
+		}
+		ret[i] = Decimal{coef: p, scale: places}
+	}
+
+	return ret, nil
+}
+
+// SplitInto returns split parts of the given amounts that inherit the
+// transaction's payee, date, category and notes, ready to be adjusted and
+// passed to Client.SplitTransaction.
+func (t *Transaction) SplitInto(amounts []Decimal) []*SplitPart {
+	ret := make([]*SplitPart, len(amounts))
+	for i, a := range amounts {
+		ret[i] = &SplitPart{
+			Payee:      t.Payee,
+			Date:       t.Date,
+			CategoryID: t.CategoryID,
+			Notes:      t.Notes,
+			Amount:     a,
+		}
+	}
+
+	return ret
+}
+
+// SplitChild is a child transaction created by SplitTransaction, with the
+// part it was created from.
+type SplitChild struct {
+	ID TransactionID
+	SplitPart
+}
+
+// SplitResult is the outcome of SplitTransaction.
+type SplitResult struct {
+	// Parent is the split transaction. The API keeps it, hidden from
+	// transaction lists, as the parent of the children.
+	Parent  TransactionID
+	Updated bool
+	// Children are the new transactions, in the order of the parts.
+	Children []*SplitChild
+}
+
+// IDs returns the IDs of the child transactions.
+func (r *SplitResult) IDs() []TransactionID {
+	ret := make([]TransactionID, len(r.Children))
+	for i, c := range r.Children {
+		ret[i] = c.ID
+	}
+
+	return ret
+}
+
+// SplitTransaction splits t into parts, which must number at least two and
+// add up to t's amount. It returns the new child transactions, so they can
+// be tagged or updated straight away.
+func (c *Client) SplitTransaction(ctx context.Context, t *Transaction, parts []*SplitPart) (*SplitResult, error) {
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("split transaction %d into %d parts: %w", t.ID, len(parts), ErrInvalidSplit)
+	}
+
+	var sum Decimal
+	for _, p := range parts {
+		if err := c.validateRequest(ctx, p); err != nil {
+			return nil, err
+		}
+		sum = sum.Add(p.Amount)
+	}
+	if !sum.Equal(t.Amount) {
+		return nil, fmt.Errorf("split transaction %d: parts add up to %s, not %s: %w", t.ID, sum, t.Amount, ErrInvalidSplit)
+	}
+
+	req := &UpdateRequest{Transaction: &UpdateTransaction{}, Split: parts}
+	body, err := c.Put(ctx, c.endpoint("transactions/%d", t.ID), req)
+	if err != nil {
+		return nil, fmt.Errorf("split transaction %d: %w", t.ID, err)
+	}
+
+	resp := &UpdateTransactionResp{}
+	if err := c.decode(body, resp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(resp.Split) != len(parts) {
+		return nil, fmt.Errorf("split transaction %d: got %d IDs for %d parts", t.ID, len(resp.Split), len(parts))
+	}
+
+	ret := &SplitResult{Parent: t.ID, Updated: resp.Updated, Children: make([]*SplitChild, len(parts))}
+	for i, id := range resp.Split {
+		ret.Children[i] = &SplitChild{ID: TransactionID(id), SplitPart: *parts[i]}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,110 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decimals(ss ...string) []Decimal {
+	ret := make([]Decimal, len(ss))
+	for i, s := range ss {
+		ret[i] = MustParseDecimal(s)
+	}
+	return ret
+}
+
+func assertParts(t *testing.T, want []string, got []Decimal) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i], got[i].String(), "part %d", i)
+	}
+}
+
+func TestSplitEvenly(t *testing.T) {
+	parts, err := SplitEvenly(MustParseDecimal("10"), 3, "USD")
+	require.NoError(t, err)
+	assertParts(t, []string{"3.34", "3.33", "3.33"}, parts)
+
+	parts, err = SplitEvenly(MustParseDecimal("-0.05"), 2, "USD")
+	require.NoError(t, err)
+	assertParts(t, []string{"-0.03", "-0.02"}, parts)
+
+	parts, err = SplitEvenly(MustParseDecimal("1000"), 3, "JPY")
+	require.NoError(t, err)
+	assertParts(t, []string{"334", "333", "333"}, parts)
+
+	_, err = SplitEvenly(MustParseDecimal("10.005"), 2, "USD")
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+	_, err = SplitEvenly(MustParseDecimal("10"), 0, "USD")
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
+
+func TestSplitByPercent(t *testing.T) {
+	parts, err := SplitByPercent(MustParseDecimal("100.01"), decimals("60", "25", "15"), "USD")
+	require.NoError(t, err)
+	assertParts(t, []string{"60.01", "25.00", "15.00"}, parts)
+
+	parts, err = SplitByPercent(MustParseDecimal("0.10"), decimals("33.3", "33.3", "33.4"), "USD")
+	require.NoError(t, err)
+	assertParts(t, []string{"0.03", "0.03", "0.04"}, parts)
+
+	_, err = SplitByPercent(MustParseDecimal("10"), decimals("50", "40"), "USD")
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+	_, err = SplitByPercent(MustParseDecimal("10"), decimals("110", "-10"), "USD")
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
+
+func TestSplitFixed(t *testing.T) {
+	parts, err := SplitFixed(MustParseDecimal("85.40"), decimals("20", "12.5"), "USD")
+	require.NoError(t, err)
+	assertParts(t, []string{"20", "12.5", "52.90"}, parts)
+
+	_, err = SplitFixed(MustParseDecimal("30"), decimals("20", "10"), "USD")
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+	_, err = SplitFixed(MustParseDecimal("30"), decimals("-5"), "USD")
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
+
+func TestSplitTransaction(t *testing.T) {
+	var got UpdateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v1/transactions/7", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, err := w.Write([]byte(`{"updated": true, "split": [8, 9]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	txn := &Transaction{ID: 7, Payee: "Dinner", Date: MustParseDate("2024-03-02"), Amount: MustParseDecimal("50.01"), Currency: "usd"}
+	amounts, err := SplitEvenly(txn.Amount, 2, txn.Currency)
+	require.NoError(t, err)
+	parts := txn.SplitInto(amounts)
+	parts[1].CategoryID = Ptr(CategoryID(3))
+
+	res, err := client.SplitTransaction(context.Background(), txn, parts)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionID(7), res.Parent)
+	assert.True(t, res.Updated)
+	assert.Equal(t, []TransactionID{8, 9}, res.IDs())
+	assert.Equal(t, "25.00", res.Children[1].Amount.String())
+	assert.Equal(t, CategoryID(3), *res.Children[1].CategoryID)
+	require.Len(t, got.Split, 2)
+	assert.Equal(t, "Dinner", got.Split[0].Payee)
+	assert.Equal(t, "25.01", got.Split[0].Amount.String())
+	assert.Equal(t, CategoryID(3), *got.Split[1].CategoryID)
+
+	parts[0].Amount = MustParseDecimal("1")
+	_, err = client.SplitTransaction(context.Background(), txn, parts)
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
@@ -0,0 +1,88 @@
+package lunchmoney
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+type operationKey struct{}
+
+// WithOperation returns a context that attributes API calls made with it to
+// the named high-level operation, such as "mirror.sync" or "report.monthly",
+// in the client's Stats and request logs.
+func WithOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// OperationFromContext returns the operation stored in ctx, or "" if none is
+// set.
+func OperationFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+	return name
+}
+
+// WithDefaultOperation is like WithOperation, but keeps the operation already
+// stored in ctx, if any. Functions that make API calls on behalf of a
+// high-level operation use it to name themselves while still letting their
+// caller attribute the calls to a broader one.
+func WithDefaultOperation(ctx context.Context, name string) context.Context {
+	if OperationFromContext(ctx) != "" {
+		return ctx
+	}
+
+	return WithOperation(ctx, name)
+}
+
+// OperationStats is the API usage of one operation.
+type OperationStats struct {
+	// Calls is the number of HTTP requests sent, including failed ones.
+	Calls int64
+	// Errors is the number of calls that failed or returned a non-2xx status.
+	Errors int64
+	// Duration is the total time spent waiting on responses.
+	Duration time.Duration
+}
+
+type stats struct {
+	mu  sync.Mutex
+	ops map[string]OperationStats
+}
+
+func (s *stats) record(op string, status int, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.ops[op]
+	st.Calls++
+	st.Duration += d
+	if status < 200 || status > 299 {
+		st.Errors++
+	}
+	s.ops[op] = st
+}
+
+// Stats returns the API usage recorded by the client so far, keyed by the
+// operation set with WithOperation. Calls made without an operation are
+// counted under "". Clients derived with With share their parent's stats.
+func (c *Client) Stats() map[string]OperationStats {
+	if c.stats == nil {
+		return map[string]OperationStats{}
+	}
+
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	return maps.Clone(c.stats.ops)
+}
+
+// ResetStats clears the recorded usage.
+func (c *Client) ResetStats() {
+	if c.stats == nil {
+		return
+	}
+
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	c.stats.ops = map[string]OperationStats{}
+}
@@ -0,0 +1,54 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/tags" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error": "boom"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id": 1}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ctx := WithOperation(context.Background(), "report")
+	_, err = client.GetUser(ctx)
+	require.NoError(t, err)
+	_, err = client.GetTags(ctx)
+	require.Error(t, err)
+
+	derived, err := client.With(WithDryRun(false))
+	require.NoError(t, err)
+	_, err = derived.GetUser(context.Background())
+	require.NoError(t, err)
+
+	stats := client.Stats()
+	assert.Equal(t, int64(2), stats["report"].Calls)
+	assert.Equal(t, int64(1), stats["report"].Errors)
+	assert.Positive(t, stats["report"].Duration)
+	assert.Equal(t, int64(1), stats[""].Calls)
+
+	client.ResetStats()
+	assert.Empty(t, client.Stats())
+}
+
+func TestWithDefaultOperation(t *testing.T) {
+	ctx := WithDefaultOperation(context.Background(), "report")
+	assert.Equal(t, "report", OperationFromContext(ctx))
+
+	ctx = WithDefaultOperation(WithOperation(context.Background(), "mcp.report"), "report")
+	assert.Equal(t, "mcp.report", OperationFromContext(ctx))
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/events"
+)
+
+// Balances holds balance snapshots recorded by RecordBalances.
+const Balances Entity = "balances"
+
+// BalanceSnapshot is the balance of an account at a point in time. Account
+// types are those of the events package: events.AccountAsset,
+// events.AccountPlaidAccount and events.AccountCrypto.
+type BalanceSnapshot struct {
+	AccountType string             `json:"account_type"`
+	AccountID   int64              `json:"account_id"`
+	Name        string             `json:"name"`
+	Balance     lunchmoney.Decimal `json:"balance"`
+	Currency    string             `json:"currency"`
+	// ToBase is the balance in the user's primary currency.
+	ToBase lunchmoney.Decimal `json:"to_base"`
+	At     time.Time          `json:"at"`
+}
+
+// balanceKey orders snapshots by account and then by time.
+func balanceKey(accountType string, id int64, at time.Time) string {
+	return balancePrefix(accountType, id) + at.UTC().Format("20060102T150405.000000000Z")
+}
+
+func balancePrefix(accountType string, id int64) string {
+	return accountType + "-" + Key(id) + "-"
+}
+
+// RecordBalances stores a snapshot of every account's balance taken at at.
+func RecordBalances(ctx context.Context, s Store, at time.Time, assets []*lunchmoney.Asset, plaid []*lunchmoney.PlaidAccount, crypto []*lunchmoney.Crypto) error {
+	return putSnapshots(ctx, s, snapshots(at, assets, plaid, crypto))
+}
+
+func snapshots(at time.Time, assets []*lunchmoney.Asset, plaid []*lunchmoney.PlaidAccount, crypto []*lunchmoney.Crypto) []*BalanceSnapshot {
+	var snaps []*BalanceSnapshot
+	for _, a := range assets {
+		snaps = append(snaps, &BalanceSnapshot{AccountType: events.AccountAsset, AccountID: int64(a.ID), Name: a.Name, Balance: a.Balance, Currency: a.Currency, ToBase: a.ToBase, At: at})
+	}
+	for _, p := range plaid {
+		snaps = append(snaps, &BalanceSnapshot{AccountType: events.AccountPlaidAccount, AccountID: int64(p.ID), Name: p.Name, Balance: p.Balance, Currency: p.Currency, ToBase: p.ToBase, At: at})
+	}
+	for _, c := range crypto {
+		snaps = append(snaps, &BalanceSnapshot{AccountType: events.AccountCrypto, AccountID: int64(c.ID), Name: c.Name, Balance: c.Balance, Currency: c.Currency, ToBase: c.ToBase, At: at})
+	}
+
+	return snaps
+}
+
+func putSnapshots(ctx context.Context, s Store, snaps []*BalanceSnapshot) error {
+	for _, snap := range snaps {
+		if err := PutJSON(ctx, s, Balances, balanceKey(snap.AccountType, snap.AccountID, snap.At), snap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BalanceHistory returns the snapshots of one account taken between start
+// and end, inclusive, in time order. A zero start or end leaves that side of
+// the range open. Only the account's snapshots in the range are read.
+func BalanceHistory(ctx context.Context, s Store, accountType string, id int64, start, end time.Time) ([]*BalanceSnapshot, error) {
+	prefix := balancePrefix(accountType, id)
+	from, to := prefix, prefixEnd(prefix)
+	if !start.IsZero() {
+		from = balanceKey(accountType, id, start)
+	}
+	if !end.IsZero() {
+		// Snapshots taken at end itself sort just below this key.
+		to = balanceKey(accountType, id, end) + "\x00"
+	}
+
+	items, err := s.ListRange(ctx, Balances, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*BalanceSnapshot, 0, len(items))
+	for _, it := range items {
+		snap := &BalanceSnapshot{}
+		if err := json.Unmarshal(it.Value, snap); err != nil {
+			return nil, fmt.Errorf("decode %s %s: %w", Balances, it.Key, err)
+		}
+		ret = append(ret, snap)
+	}
+
+	return ret, nil
+}
+
+// latestBalance returns the most recent snapshot of an account, or nil if
+// there is none.
+func latestBalance(ctx context.Context, s Store, accountType string, id int64) (*BalanceSnapshot, error) {
+	history, err := BalanceHistory(ctx, s, accountType, id, time.Time{}, time.Time{})
+	if err != nil || len(history) == 0 {
+		return nil, err
+	}
+
+	return history[len(history)-1], nil
+}
+
+// BalanceFetcher is the subset of *lunchmoney.Client a BalanceRecorder
+// wraps.
+type BalanceFetcher interface {
+	GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error)
+	GetPlaidAccounts(ctx context.Context) ([]*lunchmoney.PlaidAccount, error)
+	GetCrypto(ctx context.Context) ([]*lunchmoney.Crypto, error)
+}
+
+// BalanceRecorder wraps a BalanceFetcher and records a snapshot of the
+// balances returned by every successful fetch, building the balance history
+// the API does not keep. A snapshot is only recorded when an account's
+// balance differs from its last recorded one. If recording fails the fetched
+// accounts are still returned, together with the error. It is safe for
+// concurrent use.
+type BalanceRecorder struct {
+	fetcher BalanceFetcher
+	store   Store
+	now     func() time.Time
+
+	mu sync.Mutex
+	// last holds the last snapshot recorded for each account, by
+	// balancePrefix, once it has been looked up.
+	last map[string]*BalanceSnapshot
+}
+
+// NewBalanceRecorder records the balances fetched through f in s.
+func NewBalanceRecorder(f BalanceFetcher, s Store) *BalanceRecorder {
+	return &BalanceRecorder{fetcher: f, store: s, now: time.Now, last: map[string]*BalanceSnapshot{}}
+}
+
+// GetAssets fetches the assets and records their balances.
+func (r *BalanceRecorder) GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error) {
+	assets, err := r.fetcher.GetAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, r.record(ctx, snapshots(r.now(), assets, nil, nil))
+}
+
+// GetPlaidAccounts fetches the Plaid accounts and records their balances.
+func (r *BalanceRecorder) GetPlaidAccounts(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	accounts, err := r.fetcher.GetPlaidAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, r.record(ctx, snapshots(r.now(), nil, accounts, nil))
+}
+
+// GetCrypto fetches the crypto balances and records them.
+func (r *BalanceRecorder) GetCrypto(ctx context.Context) ([]*lunchmoney.Crypto, error) {
+	crypto, err := r.fetcher.GetCrypto(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto, r.record(ctx, snapshots(r.now(), nil, nil, crypto))
+}
+
+// record stores the snapshots whose balance changed since the account's last
+// recorded snapshot.
+func (r *BalanceRecorder) record(ctx context.Context, snaps []*BalanceSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, snap := range snaps {
+		key := balancePrefix(snap.AccountType, snap.AccountID)
+		last, ok := r.last[key]
+		if !ok {
+			var err error
+			if last, err = latestBalance(ctx, r.store, snap.AccountType, snap.AccountID); err != nil {
+				return fmt.Errorf("record balances: %w", err)
+			}
+		}
+		if last != nil && last.unchanged(snap) {
+			r.last[key] = last
+			continue
+		}
+
+		if err := putSnapshots(ctx, r.store, []*BalanceSnapshot{snap}); err != nil {
+			return fmt.Errorf("record balances: %w", err)
+		}
+		r.last[key] = snap
+	}
+
+	return nil
+}
+
+// unchanged reports whether next records the same balance as s.
+func (s *BalanceSnapshot) unchanged(next *BalanceSnapshot) bool {
+	return s.Balance.Equal(next.Balance) && s.ToBase.Equal(next.ToBase) && strings.EqualFold(s.Currency, next.Currency)
+}
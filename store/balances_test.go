@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBalances struct {
+	balance string
+}
+
+func (f *fakeBalances) GetAssets(context.Context) ([]*lunchmoney.Asset, error) {
+	return []*lunchmoney.Asset{{ID: 1, Name: "Cash", Balance: lunchmoney.MustParseDecimal(f.balance), Currency: "usd"}}, nil
+}
+
+func (f *fakeBalances) GetPlaidAccounts(context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	return []*lunchmoney.PlaidAccount{{ID: 1, Name: "Checking", Balance: lunchmoney.MustParseDecimal("500")}}, nil
+}
+
+func (f *fakeBalances) GetCrypto(context.Context) ([]*lunchmoney.Crypto, error) {
+	return nil, nil
+}
+
+func TestBalanceRecorder(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemory()
+	f := &fakeBalances{balance: "100"}
+	r := NewBalanceRecorder(f, s)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	for _, balance := range []string{"100", "100.00", "80", "95.5", "95.5"} {
+		f.balance = balance
+		_, err := r.GetAssets(ctx)
+		require.NoError(t, err)
+		now = now.Add(24 * time.Hour)
+	}
+	_, err := r.GetPlaidAccounts(ctx)
+	require.NoError(t, err)
+
+	history, err := BalanceHistory(ctx, s, events.AccountAsset, 1, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "100", history[0].Balance.String())
+	assert.Equal(t, "95.5", history[2].Balance.String())
+	assert.True(t, history[0].At.Before(history[1].At))
+
+	history, err = BalanceHistory(ctx, s, events.AccountAsset, 1, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "80", history[0].Balance.String())
+
+	// A new recorder finds the last balance in the store.
+	r = NewBalanceRecorder(f, s)
+	r.now = func() time.Time { return now }
+	_, err = r.GetAssets(ctx)
+	require.NoError(t, err)
+	history, err = BalanceHistory(ctx, s, events.AccountAsset, 1, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, history, 3)
+
+	history, err = BalanceHistory(ctx, s, events.AccountPlaidAccount, 1, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "Checking", history[0].Name)
+}
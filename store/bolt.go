@@ -0,0 +1,119 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bolt is a Store kept in a single BoltDB file, for data sets too large for
+// one file per record. Each entity is a bucket, writes are transactions that
+// are synced before Put or Delete returns, and ListRange reads only the keys
+// in the range.
+//
+// BoltDB locks the file, so a second process opening it waits until the
+// first closes it, or fails after the timeout given to OpenBolt.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens the BoltDB store at path, creating it if needed. It waits
+// up to timeout for another process to release the file; zero waits forever.
+func OpenBolt(path string, timeout time.Duration) (*Bolt, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return &Bolt{db: db}, nil
+}
+
+// Put implements Store.
+func (s *Bolt) Put(_ context.Context, e Entity, key string, value []byte) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(e))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), value)
+	})
+	if err != nil {
+		return fmt.Errorf("put %s %s: %w", e, key, err)
+	}
+
+	return nil
+}
+
+// Get implements Store.
+func (s *Bolt) Get(_ context.Context, e Entity, key string) ([]byte, error) {
+	var ret []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(e))
+		if b == nil {
+			return ErrNotFound
+		}
+		v := b.Get([]byte(key))
+		if v == nil {
+			return ErrNotFound
+		}
+		// v is only valid during the transaction.
+		ret = bytes.Clone(v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// List implements Store.
+func (s *Bolt) List(ctx context.Context, e Entity) ([]Item, error) {
+	return s.ListRange(ctx, e, "", "")
+}
+
+// ListRange implements Store.
+func (s *Bolt) ListRange(_ context.Context, e Entity, from, to string) ([]Item, error) {
+	ret := []Item{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(e))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.Seek([]byte(from)); k != nil && inRange(string(k), from, to); k, v = c.Next() {
+			ret = append(ret, Item{Key: string(k), Value: bytes.Clone(v)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", e, err)
+	}
+
+	return ret, nil
+}
+
+// Delete implements Store.
+func (s *Bolt) Delete(_ context.Context, e Entity, key string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(e))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(key))
+	})
+	if err != nil {
+		return fmt.Errorf("delete %s %s: %w", e, key, err)
+	}
+
+	return nil
+}
+
+// Close closes the database. The store cannot be used afterwards.
+func (s *Bolt) Close() error {
+	return s.db.Close()
+}
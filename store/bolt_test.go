@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestBoltReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.db")
+
+	s, err := OpenBolt(path, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(ctx, Tags, "1", []byte(`"a"`)))
+	require.NoError(t, s.Put(ctx, Tags, "1", []byte(`"b"`)))
+	require.NoError(t, s.Put(ctx, Tags, "2", []byte(`"c"`)))
+	require.NoError(t, s.Put(ctx, Tags, "3", []byte(`"d"`)))
+	require.NoError(t, s.Delete(ctx, Tags, "2"))
+
+	// The file is locked while it is open.
+	_, err = OpenBolt(path, 10*time.Millisecond)
+	assert.ErrorIs(t, err, bolt.ErrTimeout)
+	require.NoError(t, s.Close())
+
+	s, err = OpenBolt(path, time.Second)
+	require.NoError(t, err)
+	defer s.Close()
+	items, err := s.List(ctx, Tags)
+	require.NoError(t, err)
+	assert.Equal(t, []Item{{Key: "1", Value: []byte(`"b"`)}, {Key: "3", Value: []byte(`"d"`)}}, items)
+
+	items, err = s.ListRange(ctx, Tags, "2", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, keys(items))
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Dir is a Store that keeps each record in its own file, laid out as
+// <root>/<entity>/<key>.json. Writes go through a temporary file and a rename
+// so a crash never leaves a partially written record.
+type Dir struct {
+	root string
+	mu   sync.RWMutex
+}
+
+// OpenDir opens a directory store rooted at root, creating it if needed.
+func OpenDir(root string) (*Dir, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return &Dir{root: root}, nil
+}
+
+func (d *Dir) path(e Entity, key string) (string, error) {
+	if e == "" || strings.ContainsAny(string(e), `/\`) || e == "." || e == ".." {
+		return "", fmt.Errorf("invalid entity %q", e)
+	}
+	if key == "" || strings.ContainsAny(key, `/\`) || key == "." || key == ".." {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(d.root, string(e), key+".json"), nil
+}
+
+// Put implements Store.
+func (d *Dir) Put(_ context.Context, e Entity, key string, value []byte) error {
+	p, err := d.path(e, key)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return fmt.Errorf("put %s %s: %w", e, key, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("put %s %s: %w", e, key, err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(value); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("put %s %s: %w", e, key, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("put %s %s: %w", e, key, err)
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return fmt.Errorf("put %s %s: %w", e, key, err)
+	}
+
+	return nil
+}
+
+// Get implements Store.
+func (d *Dir) Get(_ context.Context, e Entity, key string) ([]byte, error) {
+	p, err := d.path(e, key)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	b, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s %s: %w", e, key, err)
+	}
+
+	return b, nil
+}
+
+// List implements Store.
+func (d *Dir) List(ctx context.Context, e Entity) ([]Item, error) {
+	return d.ListRange(ctx, e, "", "")
+}
+
+// ListRange implements Store. Only the files of records in the range are
+// read.
+func (d *Dir) ListRange(_ context.Context, e Entity, from, to string) ([]Item, error) {
+	p, err := d.path(e, "x")
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(p)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", e, err)
+	}
+
+	var ret []Item
+	for _, ent := range entries {
+		key, ok := strings.CutSuffix(ent.Name(), ".json")
+		if !ok || ent.IsDir() || strings.HasPrefix(ent.Name(), ".") || !inRange(key, from, to) {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, ent.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", e, err)
+		}
+		ret = append(ret, Item{Key: key, Value: b})
+	}
+	slices.SortFunc(ret, func(a, b Item) int { return strings.Compare(a.Key, b.Key) })
+
+	return ret, nil
+}
+
+// Delete implements Store.
+func (d *Dir) Delete(_ context.Context, e Entity, key string) error {
+	p, err := d.path(e, key)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete %s %s: %w", e, key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Memory is a Store that keeps records in memory. It is useful in tests and
+// for short-lived processes.
+type Memory struct {
+	mu   sync.RWMutex
+	data map[Entity]map[string][]byte
+}
+
+// NewMemory creates an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{data: map[Entity]map[string][]byte{}}
+}
+
+// Put implements Store.
+func (m *Memory) Put(_ context.Context, e Entity, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.data[e] == nil {
+		m.data[e] = map[string][]byte{}
+	}
+	m.data[e][key] = slices.Clone(value)
+	return nil
+}
+
+// Get implements Store.
+func (m *Memory) Get(_ context.Context, e Entity, key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	v, ok := m.data[e][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return slices.Clone(v), nil
+}
+
+// List implements Store.
+func (m *Memory) List(ctx context.Context, e Entity) ([]Item, error) {
+	return m.ListRange(ctx, e, "", "")
+}
+
+// ListRange implements Store.
+func (m *Memory) ListRange(_ context.Context, e Entity, from, to string) ([]Item, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ret []Item
+	for k, v := range m.data[e] {
+		if inRange(k, from, to) {
+			ret = append(ret, Item{Key: k, Value: slices.Clone(v)})
+		}
+	}
+	slices.SortFunc(ret, func(a, b Item) int { return strings.Compare(a.Key, b.Key) })
+
+	return ret, nil
+}
+
+// Delete implements Store.
+func (m *Memory) Delete(_ context.Context, e Entity, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.data[e], key)
+	return nil
+}
@@ -0,0 +1,195 @@
+// Package store persists Lunch Money data locally so it can be queried
+// offline and synced incrementally. Store is a small key-value interface
+// keyed by entity and ID; Memory, Dir and Bolt implement it, and other
+// backends can be plugged in by implementing it too.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Entity names a kind of record.
+type Entity string
+
+// Entities stored by the helpers in this package.
+const (
+	Transactions  Entity = "transactions"
+	Categories    Entity = "categories"
+	Tags          Entity = "tags"
+	Assets        Entity = "assets"
+	PlaidAccounts Entity = "plaid_accounts"
+	// Meta holds bookkeeping such as the date of the last sync.
+	Meta Entity = "meta"
+)
+
+// ErrNotFound is returned by Get when no record has the key.
+var ErrNotFound = errors.New("store: not found")
+
+// Item is a stored record.
+type Item struct {
+	Key   string
+	Value []byte
+}
+
+// Store is a persistent key-value store partitioned by entity. Values are
+// opaque bytes; the helpers in this package store JSON. Implementations must
+// be safe for concurrent use.
+type Store interface {
+	// Put creates or replaces the record with the given key.
+	Put(ctx context.Context, e Entity, key string, value []byte) error
+	// Get returns the record with the given key, or ErrNotFound.
+	Get(ctx context.Context, e Entity, key string) ([]byte, error)
+	// List returns every record of the entity, ordered by key.
+	List(ctx context.Context, e Entity) ([]Item, error)
+	// ListRange returns the records of the entity whose keys are at least
+	// from and less than to, ordered by key. An empty to leaves the range
+	// open at the end.
+	ListRange(ctx context.Context, e Entity, from, to string) ([]Item, error)
+	// Delete removes the record with the given key. Deleting a missing
+	// record is not an error.
+	Delete(ctx context.Context, e Entity, key string) error
+}
+
+// PutJSON stores v as JSON under key.
+func PutJSON(ctx context.Context, s Store, e Entity, key string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s %s: %w", e, key, err)
+	}
+
+	return s.Put(ctx, e, key, b)
+}
+
+// GetJSON decodes the record stored under key into v.
+func GetJSON(ctx context.Context, s Store, e Entity, key string, v any) error {
+	b, err := s.Get(ctx, e, key)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("decode %s %s: %w", e, key, err)
+	}
+
+	return nil
+}
+
+// ListPrefix returns the records of the entity whose keys start with prefix,
+// ordered by key.
+func ListPrefix(ctx context.Context, s Store, e Entity, prefix string) ([]Item, error) {
+	return s.ListRange(ctx, e, prefix, prefixEnd(prefix))
+}
+
+// prefixEnd returns the smallest key greater than every key starting with
+// prefix, or "" if there is none.
+func prefixEnd(prefix string) string {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1])
+		}
+	}
+
+	return ""
+}
+
+// inRange reports whether key is in the range of ListRange.
+func inRange(key, from, to string) bool {
+	return key >= from && (to == "" || key < to)
+}
+
+// ListJSON decodes every record of the entity, ordered by key.
+func ListJSON[T any](ctx context.Context, s Store, e Entity) ([]*T, error) {
+	items, err := s.List(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*T, 0, len(items))
+	for _, it := range items {
+		v := new(T)
+		if err := json.Unmarshal(it.Value, v); err != nil {
+			return nil, fmt.Errorf("decode %s %s: %w", e, it.Key, err)
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+// SaveTransactions stores each transaction under its ID.
+func SaveTransactions(ctx context.Context, s Store, txns []*lunchmoney.Transaction) error {
+	for _, t := range txns {
+		if err := PutJSON(ctx, s, Transactions, Key(t.ID), t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadTransactions returns every stored transaction.
+func LoadTransactions(ctx context.Context, s Store) ([]*lunchmoney.Transaction, error) {
+	return ListJSON[lunchmoney.Transaction](ctx, s, Transactions)
+}
+
+// SaveReferenceData stores categories, tags, assets and Plaid accounts under
+// their IDs.
+func SaveReferenceData(ctx context.Context, s Store, cats []*lunchmoney.Category, tags []*lunchmoney.Tag, assets []*lunchmoney.Asset, plaid []*lunchmoney.PlaidAccount) error {
+	for _, v := range cats {
+		if err := PutJSON(ctx, s, Categories, Key(v.ID), v); err != nil {
+			return err
+		}
+	}
+	for _, v := range tags {
+		if err := PutJSON(ctx, s, Tags, Key(v.ID), v); err != nil {
+			return err
+		}
+	}
+	for _, v := range assets {
+		if err := PutJSON(ctx, s, Assets, Key(v.ID), v); err != nil {
+			return err
+		}
+	}
+	for _, v := range plaid {
+		if err := PutJSON(ctx, s, PlaidAccounts, Key(v.ID), v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const syncedThroughKey = "synced_through"
+
+// SetSyncedThrough records the last date that has been fully synced, so the
+// next incremental sync can start from there.
+func SetSyncedThrough(ctx context.Context, s Store, d lunchmoney.Date) error {
+	return PutJSON(ctx, s, Meta, syncedThroughKey, d)
+}
+
+// SyncedThrough returns the date recorded by SetSyncedThrough. ok is false if
+// nothing has been synced yet.
+func SyncedThrough(ctx context.Context, s Store) (d lunchmoney.Date, ok bool, err error) {
+	err = GetJSON(ctx, s, Meta, syncedThroughKey, &d)
+	if errors.Is(err, ErrNotFound) {
+		return d, false, nil
+	}
+	if err != nil {
+		return d, false, err
+	}
+
+	return d, true, nil
+}
+
+// Key formats an ID as a store key. Keys are zero padded so that List returns
+// records in ID order.
+func Key[ID ~int64](id ID) string {
+	return fmt.Sprintf("%020d", int64(id))
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	dir, err := OpenDir(t.TempDir())
+	require.NoError(t, err)
+	bolt, err := OpenBolt(filepath.Join(t.TempDir(), "store.db"), time.Second)
+	require.NoError(t, err)
+	defer bolt.Close()
+
+	for name, s := range map[string]Store{"memory": NewMemory(), "dir": dir, "bolt": bolt} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := s.Get(ctx, Tags, "1")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			require.NoError(t, SaveTransactions(ctx, s, []*lunchmoney.Transaction{
+				{ID: 10, Payee: "Coffee"},
+				{ID: 2, Payee: "Rent"},
+			}))
+			require.NoError(t, SaveTransactions(ctx, s, []*lunchmoney.Transaction{{ID: 10, Payee: "Tea"}}))
+
+			txns, err := LoadTransactions(ctx, s)
+			require.NoError(t, err)
+			require.Len(t, txns, 2)
+			assert.Equal(t, lunchmoney.TransactionID(2), txns[0].ID)
+			assert.Equal(t, "Tea", txns[1].Payee)
+
+			require.NoError(t, s.Delete(ctx, Transactions, Key(lunchmoney.TransactionID(2))))
+			require.NoError(t, s.Delete(ctx, Transactions, "missing"))
+			txns, err = LoadTransactions(ctx, s)
+			require.NoError(t, err)
+			assert.Len(t, txns, 1)
+
+			_, ok, err := SyncedThrough(ctx, s)
+			require.NoError(t, err)
+			assert.False(t, ok)
+			d := lunchmoney.NewDate(2024, 3, 31)
+			require.NoError(t, SetSyncedThrough(ctx, s, d))
+			got, ok, err := SyncedThrough(ctx, s)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, d.String(), got.String())
+
+			for _, k := range []string{"a-1", "a-2", "ab-1", "b-1"} {
+				require.NoError(t, s.Put(ctx, Meta, k, []byte(`{}`)))
+			}
+			items, err := ListPrefix(ctx, s, Meta, "a-")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a-1", "a-2"}, keys(items))
+			items, err = s.ListRange(ctx, Meta, "a-2", "b-1")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a-2", "ab-1"}, keys(items))
+			items, err = s.ListRange(ctx, Meta, "ab", "")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"ab-1", "b-1", syncedThroughKey}, keys(items))
+		})
+	}
+}
+
+func keys(items []Item) []string {
+	var ret []string
+	for _, it := range items {
+		ret = append(ret, it.Key)
+	}
+	return ret
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, "b", prefixEnd("a"))
+	assert.Equal(t, "b", prefixEnd("a\xff"))
+	assert.Equal(t, "", prefixEnd("\xff\xff"))
+	assert.Equal(t, "", prefixEnd(""))
+}
+
+func TestDirRejectsPathKeys(t *testing.T) {
+	s, err := OpenDir(t.TempDir())
+	require.NoError(t, err)
+
+	assert.Error(t, s.Put(context.Background(), Tags, "../escape", []byte("{}")))
+}
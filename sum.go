@@ -0,0 +1,62 @@
+package lunchmoney
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Rhymond/go-money"
+)
+
+// ErrMixedCurrencies is returned when summing amounts in different
+// currencies.
+var ErrMixedCurrencies = errors.New("amounts are in different currencies")
+
+// SumTransactions adds up the amounts of txns exactly and returns the total
+// as money. All transactions must be in the same currency, otherwise the
+// error wraps ErrMixedCurrencies. It returns nil for an empty slice.
+func SumTransactions(txns []*Transaction) (*money.Money, error) {
+	if len(txns) == 0 {
+		return nil, nil
+	}
+
+	currency := txns[0].Currency
+	var total Decimal
+	for _, t := range txns {
+		if !strings.EqualFold(t.Currency, currency) {
+			return nil, fmt.Errorf("transaction %d is in %s, not %s: %w", t.ID, t.Currency, currency, ErrMixedCurrencies)
+		}
+		total = total.Add(t.Amount)
+	}
+
+	return total.Money(currency), nil
+}
+
+// SumTransactionsToBase adds up the amounts of txns converted to the user's
+// primary currency using the to_base value the API reports, so
+// multi-currency transactions can be totalled.
+func SumTransactionsToBase(txns []*Transaction, primaryCurrency string) *money.Money {
+	var total Decimal
+	for _, t := range txns {
+		total = total.Add(t.ToBase)
+	}
+
+	return total.Money(primaryCurrency)
+}
+
+// SumByCurrency adds up the amounts of txns per currency. Currency codes are
+// upper-cased.
+func SumByCurrency(txns []*Transaction) map[string]*money.Money {
+	totals := map[string]Decimal{}
+	for _, t := range txns {
+		code := strings.ToUpper(t.Currency)
+		totals[code] = totals[code].Add(t.Amount)
+	}
+
+	ret := make(map[string]*money.Money, len(totals))
+	for code, d := range totals {
+		ret[code] = d.Money(code)
+	}
+
+	return ret
+}
@@ -0,0 +1,49 @@
+package lunchmoney
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSumTransactions(t *testing.T) {
+	txns := []*Transaction{
+		{ID: 1, Amount: MustParseDecimal("0.10"), Currency: "usd"},
+		{ID: 2, Amount: MustParseDecimal("0.20"), Currency: "USD"},
+		{ID: 3, Amount: MustParseDecimal("-1.05"), Currency: "usd"},
+	}
+
+	total, err := SumTransactions(txns)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-75), total.Amount())
+
+	total, err = SumTransactions(nil)
+	require.NoError(t, err)
+	assert.Nil(t, total)
+
+	mixed := append(txns, &Transaction{ID: 4, Amount: MustParseDecimal("5"), Currency: "eur"})
+	_, err = SumTransactions(mixed)
+	assert.ErrorIs(t, err, ErrMixedCurrencies)
+
+	byCurrency := SumByCurrency(mixed)
+	assert.Len(t, byCurrency, 2)
+	assert.Equal(t, int64(-75), byCurrency["USD"].Amount())
+	assert.Equal(t, int64(500), byCurrency["EUR"].Amount())
+}
+
+func TestSumTransactionsToBase(t *testing.T) {
+	txns := []*Transaction{
+		{Amount: MustParseDecimal("10"), Currency: "eur", ToBase: MustParseDecimal("10.85")},
+		{Amount: MustParseDecimal("4.50"), Currency: "usd", ToBase: MustParseDecimal("4.50")},
+	}
+
+	total := SumTransactionsToBase(txns, "usd")
+	assert.Equal(t, int64(1535), total.Amount())
+	assert.Equal(t, int64(1085), txns[0].ToBaseMoney("usd").Amount())
+
+	var a Asset
+	require.NoError(t, json.Unmarshal([]byte(`{"balance": "100", "currency": "cad", "to_base": 73.1}`), &a))
+	assert.Equal(t, int64(7310), a.ToBaseMoney("usd").Amount())
+}
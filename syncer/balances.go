@@ -0,0 +1,95 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Balance is the current balance of a manually managed asset or crypto
+// balance. Exactly one of AssetID and CryptoID is set.
+type Balance struct {
+	AssetID  lunchmoney.AssetID
+	CryptoID lunchmoney.CryptoID
+	Balance  lunchmoney.Decimal
+	// Currency, if set, also changes the currency of the balance.
+	Currency string
+	// AsOf is when the balance was observed. It is sent as the asset's
+	// balance_as_of; the API records crypto balances as of the update.
+	AsOf time.Time
+}
+
+// AssetUpdater supplies the balances of manually managed accounts, read for
+// example from a brokerage or property value API.
+type AssetUpdater interface {
+	Balances(ctx context.Context) ([]Balance, error)
+}
+
+// AssetUpdaterFunc adapts a function to an AssetUpdater.
+type AssetUpdaterFunc func(ctx context.Context) ([]Balance, error)
+
+// Balances calls f.
+func (f AssetUpdaterFunc) Balances(ctx context.Context) ([]Balance, error) {
+	return f(ctx)
+}
+
+// BalanceWriter is the subset of *lunchmoney.Client UpdateBalances needs.
+type BalanceWriter interface {
+	UpdateAsset(ctx context.Context, id lunchmoney.AssetID, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error)
+	UpdateManualCrypto(ctx context.Context, id lunchmoney.CryptoID, crypto *lunchmoney.UpdateCrypto) (*lunchmoney.Crypto, error)
+}
+
+// UpdateBalances returns a job function pulling balances from every updater
+// and pushing them to Lunch Money. A failing updater does not stop the
+// others, and every balance is attempted; failures are joined.
+func UpdateBalances(w BalanceWriter, updaters ...AssetUpdater) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx = withOperation(ctx, "syncer.update_balances")
+
+		var errs []error
+		for i, u := range updaters {
+			balances, err := u.Balances(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("updater %d: %w", i, err))
+				continue
+			}
+			for _, b := range balances {
+				if err := push(ctx, w, b); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func push(ctx context.Context, w BalanceWriter, b Balance) error {
+	var currency *string
+	if b.Currency != "" {
+		currency = &b.Currency
+	}
+
+	switch {
+	case b.AssetID != 0 && b.CryptoID != 0:
+		return fmt.Errorf("asset %d: both asset and crypto ID set", b.AssetID)
+	case b.AssetID != 0:
+		update := &lunchmoney.UpdateAsset{Balance: &b.Balance, Currency: currency}
+		if !b.AsOf.IsZero() {
+			update.BalanceAsOf = lunchmoney.Ptr(b.AsOf.Format(time.RFC3339))
+		}
+		if _, err := w.UpdateAsset(ctx, b.AssetID, update); err != nil {
+			return fmt.Errorf("asset %d: %w", b.AssetID, err)
+		}
+	case b.CryptoID != 0:
+		if _, err := w.UpdateManualCrypto(ctx, b.CryptoID, &lunchmoney.UpdateCrypto{Balance: &b.Balance, Currency: currency}); err != nil {
+			return fmt.Errorf("crypto %d: %w", b.CryptoID, err)
+		}
+	default:
+		return errors.New("balance without asset or crypto ID")
+	}
+
+	return nil
+}
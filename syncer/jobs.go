@@ -0,0 +1,67 @@
+package syncer
+
+import (
+	"context"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/mirror"
+	"github.com/icco/lunchmoney/rules"
+)
+
+// PlaidRefresher is the subset of *lunchmoney.Client RefreshPlaid needs.
+type PlaidRefresher interface {
+	RefreshPlaidAccounts(ctx context.Context, opts *lunchmoney.RefreshPlaidAccounts) (bool, error)
+}
+
+// RefreshPlaid returns a job function asking Lunch Money to fetch the latest
+// Plaid transactions of every account. A refresh Lunch Money declines
+// because one was requested recently is not an error.
+func RefreshPlaid(r PlaidRefresher) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx = withOperation(ctx, "syncer.refresh_plaid")
+		_, err := r.RefreshPlaidAccounts(ctx, nil)
+		return err
+	}
+}
+
+// PullTransactions returns a job function syncing the transactions of the
+// last lookback days into m.
+func PullTransactions(m *mirror.Mirror, lookback int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx = withOperation(ctx, "syncer.pull_transactions")
+		end := time.Now()
+		_, err := m.Sync(ctx, end.AddDate(0, 0, -lookback), end)
+		return err
+	}
+}
+
+// TransactionFetcher is the subset of *lunchmoney.Client ApplyRules needs.
+type TransactionFetcher interface {
+	GetAllTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters, opts *lunchmoney.PaginationOptions) ([]*lunchmoney.Transaction, error)
+}
+
+// ApplyRules returns a job function running a over the transactions of the
+// last lookback days.
+func ApplyRules(f TransactionFetcher, a *rules.Applier, lookback int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx = withOperation(ctx, "syncer.apply_rules")
+		end := lunchmoney.Today()
+		start := end.AddDays(-lookback)
+		txns, err := f.GetAllTransactions(ctx, &lunchmoney.TransactionFilters{StartDate: &start, EndDate: &end}, nil)
+		if err != nil {
+			return err
+		}
+		_, err = a.Apply(ctx, txns)
+		return err
+	}
+}
+
+// withOperation names the operation of a built-in job, overriding the
+// syncer's default but not an operation set by the caller of Run.
+func withOperation(ctx context.Context, name string) context.Context {
+	if op := lunchmoney.OperationFromContext(ctx); op == "" || op == "syncer" {
+		return lunchmoney.WithOperation(ctx, name)
+	}
+	return ctx
+}
@@ -0,0 +1,52 @@
+package syncer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return v
+	}
+
+	// 2024-01-05 is a Friday.
+	from := at("2024-01-05 10:07")
+	for _, tt := range []struct {
+		spec string
+		want string
+	}{
+		{"*/15 * * * *", "2024-01-05 10:15"},
+		{"0 * * * *", "2024-01-05 11:00"},
+		{"@daily", "2024-01-06 00:00"},
+		{"30 6 * * 1-5", "2024-01-08 06:30"},
+		{"0 9 * * 7", "2024-01-07 09:00"},
+		{"0 0 1 */3 *", "2024-04-01 00:00"},
+		{"5,10 10 5 1 *", "2024-01-05 10:10"},
+		{"0 12 15 * 1", "2024-01-08 12:00"},
+		{"0 0 29 2 *", "2024-02-29 00:00"},
+	} {
+		s, err := ParseCron(tt.spec)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, at(tt.want), s.Next(from), tt.spec)
+	}
+
+	s, err := ParseCron("@every 90s")
+	require.NoError(t, err)
+	assert.Equal(t, from.Add(90*time.Second), s.Next(from))
+
+	s, err = ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(from).IsZero())
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every -1m"} {
+		_, err := ParseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,261 @@
+// Package syncer runs recurring Lunch Money jobs, such as refreshing Plaid,
+// pulling new transactions, applying rules and updating manual balances, on
+// their own schedules, so a personal-finance daemon needs no orchestration
+// code of its own.
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Job is a named task run on a schedule.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+	// RunOnStart runs the job as soon as the syncer starts instead of
+	// waiting for its first scheduled time.
+	RunOnStart bool
+}
+
+// Options configure a Syncer.
+type Options struct {
+	// MinBackoff is how long after a failure a job is retried. Each further
+	// consecutive failure doubles it. Defaults to one minute.
+	MinBackoff time.Duration
+	// MaxBackoff caps the retry delay. Defaults to one hour. A retry is never
+	// later than the job's next scheduled run.
+	MaxBackoff time.Duration
+	// OnError, if set, receives job errors, wrapped with the job name.
+	OnError func(error)
+}
+
+// JobStatus describes the state of a job.
+type JobStatus struct {
+	Name        string
+	Running     bool
+	LastRun     time.Time
+	LastSuccess time.Time
+	LastError   error
+	// Failures is the number of consecutive failed runs.
+	Failures int
+	// NextRun is when the job runs next. It is zero if the schedule never
+	// fires again.
+	NextRun time.Time
+}
+
+type job struct {
+	Job
+	status JobStatus
+}
+
+// Syncer runs jobs on their schedules. A job never runs concurrently with
+// itself, but different jobs run in parallel. It is safe for concurrent use.
+type Syncer struct {
+	opts Options
+	now  func() time.Time
+	wake chan struct{}
+
+	mu      sync.Mutex
+	jobs    []*job
+	started bool
+}
+
+// New creates a syncer without jobs.
+func New(opts Options) *Syncer {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = time.Minute
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Hour
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = opts.MinBackoff
+	}
+
+	return &Syncer{
+		opts: opts,
+		now:  time.Now,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Add registers a job. Jobs must be added before Run is called and names
+// must be unique.
+func (s *Syncer) Add(j Job) error {
+	switch {
+	case j.Name == "":
+		return errors.New("job name is empty")
+	case j.Schedule == nil:
+		return fmt.Errorf("job %q has no schedule", j.Name)
+	case j.Run == nil:
+		return fmt.Errorf("job %q has no run function", j.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("job %q added after the syncer started", j.Name)
+	}
+	for _, existing := range s.jobs {
+		if existing.Name == j.Name {
+			return fmt.Errorf("duplicate job %q", j.Name)
+		}
+	}
+	s.jobs = append(s.jobs, &job{Job: j, status: JobStatus{Name: j.Name}})
+
+	return nil
+}
+
+// Status returns the state of every job, in the order they were added.
+func (s *Syncer) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]JobStatus, len(s.jobs))
+	for i, j := range s.jobs {
+		ret[i] = j.status
+	}
+
+	return ret
+}
+
+// Trigger runs the named job as soon as possible, unless it is already
+// running. It only has an effect while Run is active.
+func (s *Syncer) Trigger(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.jobs, func(j *job) bool { return j.Name == name })
+	if i < 0 {
+		return fmt.Errorf("unknown job %q", name)
+	}
+	s.jobs[i].status.NextRun = s.now()
+	s.signal()
+
+	return nil
+}
+
+// Run runs jobs as they become due until ctx is done, then waits for running
+// jobs to return. It must only be called once. Jobs may name their own
+// operation with lunchmoney.WithOperation.
+func (s *Syncer) Run(ctx context.Context) {
+	ctx = lunchmoney.WithDefaultOperation(ctx, "syncer")
+
+	s.mu.Lock()
+	s.started = true
+	now := s.now()
+	for _, j := range s.jobs {
+		if j.RunOnStart {
+			j.status.NextRun = now
+		} else {
+			j.status.NextRun = j.Schedule.Next(now)
+		}
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		now := s.now()
+		var next time.Time
+		for _, j := range s.jobs {
+			if j.status.Running || j.status.NextRun.IsZero() {
+				continue
+			}
+			if !j.status.NextRun.After(now) {
+				j.status.Running = true
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s.run(ctx, j)
+				}()
+				continue
+			}
+			if next.IsZero() || j.status.NextRun.Before(next) {
+				next = j.status.NextRun
+			}
+		}
+		s.mu.Unlock()
+
+		// With nothing scheduled, only a finished job or a trigger can
+		// wake the loop.
+		var wait <-chan time.Time
+		if !next.IsZero() {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(next.Sub(now))
+			wait = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wait:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *Syncer) run(ctx context.Context, j *job) {
+	started := s.now()
+	err := j.Run(ctx)
+	if err != nil && s.opts.OnError != nil && ctx.Err() == nil {
+		s.opts.OnError(fmt.Errorf("job %q: %w", j.Name, err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	st := &j.status
+	st.Running = false
+	st.LastRun = started
+	st.LastError = err
+	st.NextRun = j.Schedule.Next(now)
+	if err == nil {
+		st.LastSuccess = started
+		st.Failures = 0
+	} else {
+		st.Failures++
+		retry := now.Add(s.backoff(st.Failures))
+		if st.NextRun.IsZero() || retry.Before(st.NextRun) {
+			st.NextRun = retry
+		}
+	}
+	s.signal()
+}
+
+func (s *Syncer) backoff(failures int) time.Duration {
+	d := s.opts.MinBackoff
+	for i := 1; i < failures && d < s.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, s.opts.MaxBackoff)
+}
+
+// signal wakes the Run loop without blocking.
+func (s *Syncer) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
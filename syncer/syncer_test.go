@@ -0,0 +1,138 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncer(t *testing.T) {
+	var ok, failing atomic.Int32
+	var errs atomic.Int32
+	s := New(Options{
+		MinBackoff: 20 * time.Millisecond,
+		MaxBackoff: 40 * time.Millisecond,
+		OnError:    func(error) { errs.Add(1) },
+	})
+	require.NoError(t, s.Add(Job{Name: "ok", Schedule: Every(10 * time.Millisecond), RunOnStart: true, Run: func(context.Context) error {
+		ok.Add(1)
+		return nil
+	}}))
+	require.NoError(t, s.Add(Job{Name: "failing", Schedule: Every(time.Hour), RunOnStart: true, Run: func(ctx context.Context) error {
+		assert.Equal(t, "syncer", lunchmoney.OperationFromContext(ctx))
+		failing.Add(1)
+		return errors.New("boom")
+	}}))
+	assert.ErrorContains(t, s.Add(Job{Name: "ok", Schedule: Every(time.Second), Run: func(context.Context) error { return nil }}), "duplicate")
+	assert.Error(t, s.Add(Job{Name: "nil", Run: func(context.Context) error { return nil }}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	s.Run(ctx)
+
+	assert.GreaterOrEqual(t, ok.Load(), int32(5))
+	// Retried after 20ms, then every 40ms despite the hourly schedule.
+	assert.GreaterOrEqual(t, failing.Load(), int32(3))
+	assert.Less(t, failing.Load(), int32(8))
+	assert.Equal(t, failing.Load(), errs.Load())
+
+	status := s.Status()
+	require.Len(t, status, 2)
+	assert.Equal(t, "ok", status[0].Name)
+	assert.NoError(t, status[0].LastError)
+	assert.Equal(t, 0, status[0].Failures)
+	assert.False(t, status[0].LastSuccess.IsZero())
+	assert.Equal(t, int(failing.Load()), status[1].Failures)
+	assert.EqualError(t, status[1].LastError, "boom")
+	assert.True(t, status[1].LastSuccess.IsZero())
+	assert.False(t, status[1].Running)
+
+	assert.ErrorContains(t, s.Add(Job{Name: "late", Schedule: Every(time.Second), Run: func(context.Context) error { return nil }}), "after the syncer started")
+}
+
+func TestSyncerTrigger(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	s := New(Options{})
+	require.NoError(t, s.Add(Job{Name: "daily", Schedule: Every(24 * time.Hour), Run: func(context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}}))
+	assert.Error(t, s.Trigger("missing"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	for s.Status()[0].NextRun.IsZero() {
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, s.Trigger("daily"))
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("triggered job did not run")
+	}
+
+	cancel()
+	<-done
+	assert.True(t, s.Status()[0].NextRun.After(time.Now().Add(23*time.Hour)))
+}
+
+type fakeWriter struct {
+	updated map[string]string
+}
+
+func (f *fakeWriter) UpdateAsset(_ context.Context, id lunchmoney.AssetID, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+	if id == 2 {
+		return nil, errors.New("not found")
+	}
+	f.updated[fmt.Sprintf("asset %d", id)] = asset.Balance.String() + " " + deref(asset.BalanceAsOf)
+	return &lunchmoney.Asset{ID: id}, nil
+}
+
+func (f *fakeWriter) UpdateManualCrypto(_ context.Context, id lunchmoney.CryptoID, crypto *lunchmoney.UpdateCrypto) (*lunchmoney.Crypto, error) {
+	f.updated[fmt.Sprintf("crypto %d", id)] = crypto.Balance.String() + " " + deref(crypto.Currency)
+	return &lunchmoney.Crypto{ID: id}, nil
+}
+
+func TestUpdateBalances(t *testing.T) {
+	f := &fakeWriter{updated: map[string]string{}}
+	brokerage := AssetUpdaterFunc(func(context.Context) ([]Balance, error) {
+		return []Balance{
+			{AssetID: 1, Balance: lunchmoney.MustParseDecimal("10.5"), AsOf: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+			{AssetID: 2, Balance: lunchmoney.MustParseDecimal("3")},
+			{CryptoID: 7, Balance: lunchmoney.MustParseDecimal("0.25"), Currency: "btc"},
+			{Balance: lunchmoney.MustParseDecimal("1")},
+		}, nil
+	})
+	property := AssetUpdaterFunc(func(context.Context) ([]Balance, error) {
+		return nil, errors.New("rate limited")
+	})
+
+	err := UpdateBalances(f, brokerage, property)(context.Background())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "asset 2: not found")
+	assert.ErrorContains(t, err, "balance without asset or crypto ID")
+	assert.ErrorContains(t, err, "updater 1: rate limited")
+	assert.Equal(t, map[string]string{
+		"asset 1":  "10.5 2024-03-01T12:00:00Z",
+		"crypto 7": "0.25 btc",
+	}, f.updated)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
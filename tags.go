@@ -3,9 +3,8 @@ package lunchmoney
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-
-	"github.com/go-playground/validator/v10"
 )
 
 // TagsResponse is the response from getting all tags.
@@ -13,33 +12,132 @@ type TagsResponse []*Tag
 
 // Tag is a single LM tag.
 type Tag struct {
-	ID          int    `json:"id"`
+	ID          TagID  `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Archived    bool   `json:"archived,omitempty"`
+
+	// Extra holds the fields of the tag the client does not know about
+	// yet, so they survive re-encoding.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes t, keeping unknown fields in Extra.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	extra, err := decodeExtra(data, (*plain)(t))
+	t.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes t together with the fields in Extra.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type plain Tag
+	return encodeExtra(plain(t), t.Extra)
+}
+
+// String describes the tag for logs and debugging, such as "tag 9 travel".
+func (t *Tag) String() string {
+	return fmt.Sprintf("tag %d %s", t.ID, t.Name)
 }
 
 // GetTags retrieves all tags from the Lunch Money API.
 // It returns a slice of Tag objects containing tag details such as ID, name, and description.
 // Returns an error if the request fails or if any tag fails validation.
 func (c *Client) GetTags(ctx context.Context) ([]*Tag, error) {
-	validate := validator.New()
-	body, err := c.Get(ctx, "/v1/tags", nil)
-	if err != nil {
+	resp := &TagsResponse{}
+	if err := c.getJSON(ctx, c.endpoint("tags"), nil, resp); err != nil {
 		return nil, fmt.Errorf("get tags: %w", err)
 	}
 
-	resp := &TagsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	return validateRecords(ctx, c, []*Tag(*resp))
+}
+
+// ErrUnsupported is returned for operations the API has not been detected or
+// declared to support. See WithCapabilities.
+var ErrUnsupported = errors.New("operation not supported by the API")
+
+// TagService groups tag operations. The write methods are only enabled once
+// the client knows the API supports them, so they can be switched on without
+// changing any call sites when the API starts exposing tag management.
+type TagService struct {
+	c *Client
+}
+
+// Tags returns the tag operations of the client.
+func (c *Client) Tags() *TagService {
+	return &TagService{c: c}
+}
+
+// List returns all tags. It is equivalent to Client.GetTags.
+func (s *TagService) List(ctx context.Context) ([]*Tag, error) {
+	return s.c.GetTags(ctx)
+}
+
+// CreateTag holds the fields of a new tag.
+type CreateTag struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateTag holds the tag fields to change. Only non-nil fields are sent.
+type UpdateTag struct {
+	Name        *string `json:"name,omitempty" validate:"omitnil,min=1,max=100"`
+	Description *string `json:"description,omitempty"`
+	Archived    *bool   `json:"archived,omitempty"`
+}
+
+// Create adds a new tag and returns it. It requires CapabilityTagWrite.
+func (s *TagService) Create(ctx context.Context, t *CreateTag) (*Tag, error) {
+	if err := s.c.require(CapabilityTagWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.c.validateRequest(ctx, t); err != nil {
+		return nil, err
+	}
+
+	body, err := s.c.Post(ctx, s.c.endpoint("tags"), t)
+	if err != nil {
+		return nil, fmt.Errorf("create tag: %w", err)
+	}
+
+	resp := &Tag{}
+	if err := s.c.decode(body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	ret := []*Tag(*resp)
+	return resp, nil
+}
 
-	for _, t := range ret {
-		if err := validate.Struct(t); err != nil {
-			return nil, err
-		}
+// Update changes an existing tag and returns it. It requires
+// CapabilityTagWrite.
+func (s *TagService) Update(ctx context.Context, id TagID, t *UpdateTag) (*Tag, error) {
+	if err := s.c.require(CapabilityTagWrite); err != nil {
+		return nil, err
 	}
 
-	return ret, nil
+	if err := s.c.validateRequest(ctx, t); err != nil {
+		return nil, err
+	}
+
+	body, err := s.c.Put(ctx, s.c.endpoint("tags/%d", id), t)
+	if err != nil {
+		return nil, fmt.Errorf("update tag %d: %w", id, err)
+	}
+
+	resp := &Tag{}
+	if err := s.c.decode(body, resp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Archive hides a tag from pickers while keeping it on existing
+// transactions. It requires CapabilityTagWrite.
+func (s *TagService) Archive(ctx context.Context, id TagID) error {
+	archived := true
+	_, err := s.Update(ctx, id, &UpdateTag{Archived: &archived})
+	return err
 }
@@ -0,0 +1,37 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagServiceCapabilityGate(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/tags/7", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, err := w.Write([]byte(`{"id": 7, "name": "travel", "archived": true}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, client.Tags().Archive(context.Background(), 7), ErrUnsupported)
+	_, err = client.Tags().Create(context.Background(), &CreateTag{Name: "travel"})
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	enabled, err := client.With(WithCapabilities(CapabilityTagWrite))
+	require.NoError(t, err)
+	assert.False(t, client.Supports(CapabilityTagWrite))
+	require.NoError(t, enabled.Tags().Archive(context.Background(), 7))
+	assert.Equal(t, map[string]any{"archived": true}, got)
+}
@@ -0,0 +1,73 @@
+package lunchmoney
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time returned by the API, such as created_at or
+// last_import. Decoding is tolerant of the several formats the API has used:
+// RFC 3339 with or without fractional seconds, "2006-01-02 15:04:05" without
+// a zone (read as UTC), and a bare date. Null and the empty string decode to
+// the zero Timestamp, which encodes as null.
+type Timestamp struct {
+	time.Time
+}
+
+// timestampLayouts are tried in order when decoding. time.RFC3339 also
+// accepts fractional seconds.
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	time.DateOnly,
+}
+
+// ParseTimestamp parses s in any of the formats accepted by Timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t}, nil
+		}
+	}
+
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// MarshalJSON encodes ts in RFC 3339 format, or null for the zero value.
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	if ts.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Quote(ts.Format(time.RFC3339Nano))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ts *Timestamp) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*ts = Timestamp{}
+		return nil
+	}
+
+	s, err := strconv.Unquote(s)
+	if err != nil {
+		return fmt.Errorf("decode timestamp: %w", err)
+	}
+
+	if s == "" {
+		*ts = Timestamp{}
+		return nil
+	}
+
+	v, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+
+	*ts = v
+	return nil
+}
@@ -0,0 +1,43 @@
+package lunchmoney
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`"2024-03-01T12:30:00Z"`, want},
+		{`"2024-03-01T12:30:00.000Z"`, want},
+		{`"2024-03-01T07:30:00-05:00"`, want},
+		{`"2024-03-01 12:30:00"`, want},
+		{`"2024-03-01T12:30:00"`, want},
+		{`"2024-03-01"`, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		var ts Timestamp
+		require.NoError(t, json.Unmarshal([]byte(tt.in), &ts), tt.in)
+		assert.True(t, tt.want.Equal(ts.Time), "%s decoded to %s", tt.in, ts.Time)
+	}
+
+	var ts Timestamp
+	assert.Error(t, json.Unmarshal([]byte(`"last tuesday"`), &ts))
+
+	b, err := json.Marshal(struct {
+		A Timestamp `json:"a"`
+		B Timestamp `json:"b"`
+	}{A: Timestamp{want}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": "2024-03-01T12:30:00Z", "b": null}`, string(b))
+}
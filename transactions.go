@@ -3,7 +3,9 @@ package lunchmoney
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Rhymond/go-money"
 	"github.com/go-playground/validator/v10"
@@ -12,46 +14,129 @@ import (
 // TransactionsResponse is the response we get from requesting transactions.
 type TransactionsResponse struct {
 	Transactions []*Transaction `json:"transactions"`
+	// HasMore is reported by the API when a limit was given.
+	HasMore *bool `json:"has_more,omitempty"`
 }
 
-// Transaction is a single LM transaction.
+// Transaction is a single LM transaction. Reference fields the API returns as
+// null are nil, so an uncategorized transaction can be told apart from one in
+// category 0.
 type Transaction struct {
-	ID             int64  `json:"id"`
-	Date           string `json:"date" validate:"omitempty,datetime=2006-01-02"`
-	Payee          string `json:"payee"`
-	Amount         string `json:"amount"`
-	Currency       string `json:"currency"`
-	Notes          string `json:"notes"`
-	CategoryID     int64  `json:"category_id"`
-	RecurringID    int64  `json:"recurring_id"`
-	AssetID        int64  `json:"asset_id"`
-	PlaidAccountID int64  `json:"plaid_account_id"`
-	Status         string `json:"status"`
-	IsGroup        bool   `json:"is_group"`
-	GroupID        int64  `json:"group_id"`
-	ParentID       int64  `json:"parent_id"`
-	ExternalID     string `json:"external_id"`
+	ID             TransactionID   `json:"id"`
+	Date           Date            `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	Payee          string          `json:"payee"`
+	Amount         Decimal         `json:"amount"`
+	Currency       string          `json:"currency"`
+	ToBase         Decimal         `json:"to_base"` // the amount converted to the user's primary currency
+	Notes          string          `json:"notes"`
+	CategoryID     *CategoryID     `json:"category_id"`
+	RecurringID    *RecurringID    `json:"recurring_id"`
+	AssetID        *AssetID        `json:"asset_id"`
+	PlaidAccountID *PlaidAccountID `json:"plaid_account_id"`
+	Status         string          `json:"status"`
+	IsGroup        bool            `json:"is_group"`
+	GroupID        *TransactionID  `json:"group_id"`
+	ParentID       *TransactionID  `json:"parent_id"`
+	ExternalID     string          `json:"external_id"`
+	// Tags holds the {id, name} tag objects attached to the transaction,
+	// using the same model as GetTags.
+	Tags []*Tag `json:"tags"`
+
+	// Extra holds the fields of the transaction the client does not know
+	// about yet, so they survive re-encoding.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// String describes the transaction for logs and debugging, such as
+// "transaction 12 2024-03-02 Dinner 50.01 USD".
+func (t *Transaction) String() string {
+	return fmt.Sprintf("transaction %d %s %s %s %s", t.ID, t.Date, t.Payee, t.Amount, strings.ToUpper(t.Currency))
+}
+
+// UnmarshalJSON decodes t, keeping unknown fields in Extra.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type plain Transaction
+	extra, err := decodeExtra(data, (*plain)(t))
+	t.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes t together with the fields in Extra.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	type plain Transaction
+	return encodeExtra(plain(t), t.Extra)
+}
+
+// HasCategory reports whether the transaction is categorized.
+func (t *Transaction) HasCategory() bool {
+	return t.CategoryID != nil
+}
+
+// HasAsset reports whether the transaction belongs to a manually-managed asset.
+func (t *Transaction) HasAsset() bool {
+	return t.AssetID != nil
+}
+
+// HasPlaidAccount reports whether the transaction belongs to a Plaid account.
+func (t *Transaction) HasPlaidAccount() bool {
+	return t.PlaidAccountID != nil
+}
+
+// IsRecurring reports whether the transaction is matched to a recurring item.
+func (t *Transaction) IsRecurring() bool {
+	return t.RecurringID != nil
+}
+
+// InGroup reports whether the transaction is part of a transaction group.
+func (t *Transaction) InGroup() bool {
+	return t.GroupID != nil
+}
+
+// IsSplit reports whether the transaction is a child of a split transaction.
+func (t *Transaction) IsSplit() bool {
+	return t.ParentID != nil
+}
+
+// HasTag reports whether the tag with the given ID is attached to the
+// transaction.
+func (t *Transaction) HasTag(id TagID) bool {
+	for _, tag := range t.Tags {
+		if tag.ID == id {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ParsedAmount converts the transaction's amount and currency into a money.Money object.
 // This provides a convenient way to work with the transaction amount using the go-money library's
 // currency handling capabilities. Returns an error if the amount cannot be parsed.
 func (t *Transaction) ParsedAmount() (*money.Money, error) {
-	return ParseCurrency(t.Amount, t.Currency)
+	return t.Amount.Money(t.Currency), nil
+}
+
+// ToBaseMoney returns ToBase, the amount the API converted to the user's
+// primary currency, as money in primaryCurrency. Pass the currency reported
+// by GetUser; the API does not repeat it on each transaction.
+func (t *Transaction) ToBaseMoney(primaryCurrency string) *money.Money {
+	return t.ToBase.Money(primaryCurrency)
 }
 
 // TransactionFilters are options to pass into the request for transactions.
 type TransactionFilters struct {
-	TagID           *int64  `json:"tag_id"`
-	RecurringID     *int64  `json:"recurring_id"`
-	PlaidAccountID  *int64  `json:"plaid_account_id"`
-	CategoryID      *int64  `json:"category_id"`
-	AssetID         *int64  `json:"asset_id"`
-	Offset          *int64  `json:"offset"`
-	Limit           *int64  `json:"limit"`
-	StartDate       *string `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
-	EndDate         *string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
-	DebitAsNegative *bool   `json:"debit_as_negative"`
+	TagID           *TagID          `json:"tag_id"`
+	RecurringID     *RecurringID    `json:"recurring_id"`
+	PlaidAccountID  *PlaidAccountID `json:"plaid_account_id"`
+	CategoryID      *CategoryID     `json:"category_id"`
+	AssetID         *AssetID        `json:"asset_id"`
+	Offset          *int64          `json:"offset"`
+	Limit           *int64          `json:"limit"`
+	StartDate       *Date           `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate         *Date           `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	DebitAsNegative *bool           `json:"debit_as_negative"`
+	// ExternalID matches the user-defined external_id set on insert.
+	ExternalID *string `json:"external_id" validate:"omitnil,max=75"`
 }
 
 // ToMap converts the filters to a string map to be sent with the request as
@@ -89,28 +174,47 @@ func (r *TransactionFilters) ToMap() (map[string]string, error) {
 	}
 
 	if r.StartDate != nil {
-		ret["start_date"] = *r.StartDate
+		ret["start_date"] = r.StartDate.String()
 	}
 
 	if r.EndDate != nil {
-		ret["end_date"] = *r.EndDate
+		ret["end_date"] = r.EndDate.String()
 	}
 
 	if r.DebitAsNegative != nil {
 		ret["debit_as_negative"] = fmt.Sprintf("%t", *r.DebitAsNegative)
 	}
 
+	if r.ExternalID != nil {
+		ret["external_id"] = *r.ExternalID
+	}
+
 	return ret, nil
 }
 
+// validate checks the date range, whose start and end date the API requires
+// together.
+func (r *TransactionFilters) validate() error {
+	return validateDateRange(r.StartDate, r.EndDate, true)
+}
+
 // GetTransactions retrieves all transactions from the Lunch Money API based on the provided filters.
 // It returns a slice of Transaction objects or an error if the request fails.
 // The filters parameter can be used to narrow down results by date range, category, and other criteria.
 func (c *Client) GetTransactions(ctx context.Context, filters *TransactionFilters) ([]*Transaction, error) {
-	validate := validator.New()
+	page, err := c.getTransactions(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Items, nil
+}
+
+func (c *Client) getTransactions(ctx context.Context, filters *TransactionFilters) (*Page[Transaction], error) {
 	options := map[string]string{}
+	var opts ListOptions
 	if filters != nil {
-		if err := validate.Struct(filters); err != nil {
+		if err := c.validateRequest(ctx, filters); err != nil {
 			return nil, err
 		}
 
@@ -119,56 +223,135 @@ func (c *Client) GetTransactions(ctx context.Context, filters *TransactionFilter
 			return nil, fmt.Errorf("convert filters to map: %w", err)
 		}
 		options = maps
+		if filters.Limit != nil {
+			opts.Limit = *filters.Limit
+		}
+		if filters.Offset != nil {
+			opts.Offset = *filters.Offset
+		}
 	}
 
-	body, err := c.Get(ctx, "/v1/transactions", options)
-	if err != nil {
+	resp := &TransactionsResponse{}
+	// A full page is the common case when syncing, so the slice is sized
+	// for it up front rather than grown while decoding.
+	if opts.Limit > 0 {
+		resp.Transactions = make([]*Transaction, 0, min(opts.Limit, DefaultPageSize))
+	}
+	if err := c.getJSON(ctx, c.endpoint("transactions"), options, resp); err != nil {
 		return nil, fmt.Errorf("get transactions: %w", err)
 	}
 
-	resp := &TransactionsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	txns, err := validateRecords(ctx, c, resp.Transactions)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := validate.Struct(resp); err != nil {
-		return nil, err
+	return newPage(txns, len(resp.Transactions), opts, resp.HasMore), nil
+}
+
+// GetTransactionOptions are the query parameters of a single transaction
+// lookup.
+type GetTransactionOptions struct {
+	// DebitAsNegative returns debits as negative amounts and credits as
+	// positive ones.
+	DebitAsNegative *bool `json:"debit_as_negative"`
+}
+
+// ToMap converts the options to a string map to be sent with the request as
+// GET parameters. If the field is nil, it will not be included in the map.
+func (r *GetTransactionOptions) ToMap() map[string]string {
+	ret := map[string]string{}
+	if r.DebitAsNegative != nil {
+		ret["debit_as_negative"] = fmt.Sprintf("%t", *r.DebitAsNegative)
 	}
 
-	return resp.Transactions, nil
+	return ret
 }
 
 // GetTransaction retrieves a single transaction from the Lunch Money API by its ID.
 // It returns the transaction details or an error if the request fails.
-// The filters parameter can be used to specify additional query parameters for the request.
-func (c *Client) GetTransaction(ctx context.Context, id int64, filters *TransactionFilters) (*Transaction, error) {
-	validate := validator.New()
+// opts may be nil.
+func (c *Client) GetTransaction(ctx context.Context, id TransactionID, opts *GetTransactionOptions) (*Transaction, error) {
 	options := map[string]string{}
-	if filters != nil {
-		if err := validate.Struct(filters); err != nil {
-			return nil, err
-		}
+	if opts != nil {
+		options = opts.ToMap()
+	}
 
-		maps, err := filters.ToMap()
-		if err != nil {
-			return nil, err
-		}
-		options = maps
+	resp := &Transaction{}
+	if err := c.getJSON(ctx, c.endpoint("transactions/%d", id), options, resp); err != nil {
+		return nil, fmt.Errorf("get transaction %d: %w", id, err)
+	}
+
+	if err := c.validateRecord(ctx, resp); err != nil {
+		return nil, err
 	}
 
-	body, err := c.Get(ctx, fmt.Sprintf("/v1/transactions/%d", id), options)
+	return resp, nil
+}
+
+// TransactionGroup is a transaction group together with the transactions
+// grouped into it.
+type TransactionGroup struct {
+	Transaction
+	Children []*Transaction `json:"children"`
+}
+
+// UnmarshalJSON decodes the group; without it the embedded Transaction's
+// method would decode the group and drop the children.
+func (g *TransactionGroup) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Children []*Transaction `json:"children"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := g.Transaction.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	delete(g.Extra, "children")
+	if len(g.Extra) == 0 {
+		g.Extra = nil
+	}
+	g.Children = v.Children
+	return nil
+}
+
+// MarshalJSON encodes the group with its current children.
+func (g TransactionGroup) MarshalJSON() ([]byte, error) {
+	b, err := g.Transaction.MarshalJSON()
 	if err != nil {
-		return nil, fmt.Errorf("get transaction %d: %w", id, err)
+		return nil, err
 	}
 
-	resp := &Transaction{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if m["children"], err = json.Marshal(g.Children); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(m)
+}
+
+// GetTransactionGroup retrieves the group the transaction with the given ID
+// heads, so its members can be inspected before the group is changed or
+// deleted.
+func (c *Client) GetTransactionGroup(ctx context.Context, id TransactionID) (*TransactionGroup, error) {
+	resp := &TransactionGroup{}
+	if err := c.getJSON(ctx, c.endpoint("transactions/group"), map[string]string{"transaction_id": fmt.Sprintf("%d", id)}, resp); err != nil {
+		return nil, fmt.Errorf("get transaction group %d: %w", id, err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
+	if err := c.validateRecord(ctx, resp); err != nil {
 		return nil, err
 	}
+	children, err := validateRecords(ctx, c, resp.Children)
+	if err != nil {
+		return nil, err
+	}
+	resp.Children = children
 
 	return resp, nil
 }
@@ -188,67 +371,268 @@ type InsertTransactionsRequest struct {
 // It contains all the details needed to create a new transaction, with required fields being
 // Date and Amount, while other fields are optional.
 type InsertTransaction struct {
-	Date           string `json:"date" validate:"datetime=2006-01-02"`
-	Amount         string `json:"amount"`
-	CategoryID     *int64 `json:"category_id,omitempty"`
-	Payee          string `json:"payee,omitempty"`
-	Currency       string `json:"currency,omitempty"`
-	AssetID        *int64 `json:"asset_id,omitempty"`
-	PlaidAccountID *int64 `json:"plaid_account_id,omitempty"`
-	RecurringID    *int64 `json:"recurring_id,omitempty"`
-	Notes          string `json:"notes,omitempty"`
-	Status         string `json:"status,omitempty" validate:"omitnil,oneof=cleared uncleared"`
-	ExternalID     string `json:"external_id,omitempty" validate:"max=75"`
-	TagsIDs        []int  `json:"tags,omitempty"`
+	Date           Date            `json:"date" validate:"datetime=2006-01-02"`
+	Amount         Decimal         `json:"amount"`
+	CategoryID     *CategoryID     `json:"category_id,omitempty"`
+	Payee          string          `json:"payee,omitempty"`
+	Currency       string          `json:"currency,omitempty"`
+	AssetID        *AssetID        `json:"asset_id,omitempty"`
+	PlaidAccountID *PlaidAccountID `json:"plaid_account_id,omitempty"`
+	RecurringID    *RecurringID    `json:"recurring_id,omitempty"`
+	Notes          string          `json:"notes,omitempty"`
+	Status         string          `json:"status,omitempty" validate:"omitempty,oneof=cleared uncleared"`
+	ExternalID     string          `json:"external_id,omitempty" validate:"max=75"`
+	TagsIDs        []TagID         `json:"tags,omitempty"`
+	// TagNames are attached by name, creating tags that do not exist yet.
+	// They are sent in tags alongside TagsIDs.
+	TagNames []string `json:"-"`
+}
+
+// MarshalJSON encodes t as the API expects it, with TagsIDs and TagNames
+// merged into tags.
+func (t InsertTransaction) MarshalJSON() ([]byte, error) {
+	type plain InsertTransaction
+	var tags []any
+	for _, id := range t.TagsIDs {
+		tags = append(tags, id)
+	}
+	for _, name := range t.TagNames {
+		tags = append(tags, name)
+	}
+
+	return json.Marshal(struct {
+		plain
+		Tags []any `json:"tags,omitempty"`
+	}{plain(t), tags})
+}
+
+// UnmarshalJSON decodes t, sorting tags into TagsIDs and TagNames.
+func (t *InsertTransaction) UnmarshalJSON(data []byte) error {
+	type plain InsertTransaction
+	var v struct {
+		plain
+		Tags []json.RawMessage `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = InsertTransaction(v.plain)
+	for _, raw := range v.Tags {
+		var id TagID
+		if err := json.Unmarshal(raw, &id); err == nil {
+			t.TagsIDs = append(t.TagsIDs, id)
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return fmt.Errorf("decode tag %s: %w", raw, err)
+		}
+		t.TagNames = append(t.TagNames, name)
+	}
+
+	return nil
 }
 
 // InsertTransactionsResponse contains the IDs of transactions created through the InsertTransactions method.
 // These IDs can be used to reference the newly created transactions in subsequent API calls.
 type InsertTransactionsResponse struct {
-	IDs []int64 `json:"ids"`
+	IDs []TransactionID `json:"ids"`
 }
 
 // InsertTransactions creates new transactions in the Lunch Money API.
 // It takes an InsertTransactionsRequest with transaction details and options.
 // Returns the IDs of the created transactions or an error if the insertion fails.
 func (c *Client) InsertTransactions(ctx context.Context, itReq InsertTransactionsRequest) (*InsertTransactionsResponse, error) {
-	validate := validator.New(validator.WithRequiredStructEnabled())
-	if err := validate.Struct(itReq); err != nil {
+	if err := c.validateRequest(ctx, itReq, validator.WithRequiredStructEnabled()); err != nil {
 		return nil, err
 	}
 
-	body, err := c.Post(ctx, "/v1/transactions", itReq)
+	body, err := c.Post(ctx, c.endpoint("transactions"), itReq)
 	if err != nil {
 		return nil, fmt.Errorf("insert transaction: %w", err)
 	}
 
 	resp := &InsertTransactionsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := c.decode(body, resp); err != nil {
 		return nil, fmt.Errorf("insert response decode error: %w", err)
 	}
 
 	return resp, nil
 }
 
+// ErrNotInserted is returned by InsertTransaction when the API created no
+// transaction, for example because it was skipped as a duplicate.
+var ErrNotInserted = errors.New("transaction not inserted")
+
+// InsertOption sets an option of the insert request made by
+// InsertTransaction.
+type InsertOption func(*InsertTransactionsRequest)
+
+// InsertApplyRules applies the user's rules to the new transaction.
+func InsertApplyRules() InsertOption {
+	return func(r *InsertTransactionsRequest) { r.ApplyRules = true }
+}
+
+// InsertSkipDuplicates skips the transaction if it duplicates an existing one.
+func InsertSkipDuplicates() InsertOption {
+	return func(r *InsertTransactionsRequest) { r.SkipDuplicates = true }
+}
+
+// InsertCheckForRecurring matches the transaction to recurring items.
+func InsertCheckForRecurring() InsertOption {
+	return func(r *InsertTransactionsRequest) { r.CheckForRecurring = true }
+}
+
+// InsertDebitAsNegative treats negative amounts as debits.
+func InsertDebitAsNegative() InsertOption {
+	return func(r *InsertTransactionsRequest) { r.DebitAsNegative = true }
+}
+
+// InsertSkipBalanceUpdate leaves the balance of the transaction's asset
+// unchanged.
+func InsertSkipBalanceUpdate() InsertOption {
+	return func(r *InsertTransactionsRequest) { r.SkipBalanceUpdate = true }
+}
+
+// InsertTransaction creates a single transaction and returns its ID. It is a
+// shorthand for InsertTransactions with one transaction.
+func (c *Client) InsertTransaction(ctx context.Context, t *InsertTransaction, opts ...InsertOption) (TransactionID, error) {
+	if err := c.validateRequest(ctx, t); err != nil {
+		return 0, err
+	}
+
+	req := InsertTransactionsRequest{Transactions: []InsertTransaction{*t}}
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	resp, err := c.InsertTransactions(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.IDs) == 0 {
+		return 0, ErrNotInserted
+	}
+
+	return resp.IDs[0], nil
+}
+
 // UpdateTransaction contains fields that can be updated for an existing transaction.
 // All fields are optional, and only non-nil fields will be sent in the update request.
 // This provides a flexible way to update specific fields without needing to include unchanged values.
 type UpdateTransaction struct {
-	Date        *string `json:"date,omitempty" validate:"omitnil,datetime=2006-01-02"`
-	CategoryID  *int    `json:"category_id,omitempty"`
-	Payee       *string `json:"payee,omitempty"`
-	Currency    *string `json:"currency,omitempty"`
-	AssetID     *int    `json:"asset_id,omitempty"`
-	RecurringID *int    `json:"recurring_id,omitempty"`
-	Notes       *string `json:"notes,omitempty"`
-	Status      *string `json:"status,omitempty" validate:"omitnil,oneof=cleared uncleared"`
-	ExternalID  *string `json:"external_id,omitempty"`
+	Date        *Date        `json:"date,omitempty" validate:"omitnil,datetime=2006-01-02"`
+	CategoryID  *CategoryID  `json:"category_id,omitempty"`
+	Payee       *string      `json:"payee,omitempty"`
+	Currency    *string      `json:"currency,omitempty"`
+	AssetID     *AssetID     `json:"asset_id,omitempty"`
+	RecurringID *RecurringID `json:"recurring_id,omitempty"`
+	Notes       *string      `json:"notes,omitempty"`
+	Status      *string      `json:"status,omitempty" validate:"omitnil,oneof=cleared uncleared"`
+	ExternalID  *string      `json:"external_id,omitempty" validate:"omitnil,max=75"`
+	// TagsIDs, if non-nil, replaces the transaction's tags. An empty
+	// non-nil slice removes them all.
+	TagsIDs []TagID `json:"tags,omitempty"`
+	// ClearCategory, ClearAsset and ClearRecurring remove the reference by
+	// sending null, which a nil pointer cannot express. Each excludes the
+	// matching ID field.
+	ClearCategory  bool `json:"-" validate:"excluded_with=CategoryID"`
+	ClearAsset     bool `json:"-" validate:"excluded_with=AssetID"`
+	ClearRecurring bool `json:"-" validate:"excluded_with=RecurringID"`
+}
+
+// MarshalJSON encodes ut as the API expects it, with the cleared references
+// sent as null and tags sent whenever TagsIDs is non-nil.
+func (ut UpdateTransaction) MarshalJSON() ([]byte, error) {
+	type plain UpdateTransaction
+	var tags any
+	if ut.TagsIDs != nil {
+		tags = ut.TagsIDs
+	}
+
+	return json.Marshal(struct {
+		plain
+		CategoryID  any `json:"category_id,omitempty"`
+		AssetID     any `json:"asset_id,omitempty"`
+		RecurringID any `json:"recurring_id,omitempty"`
+		TagsIDs     any `json:"tags,omitempty"`
+	}{
+		plain(ut),
+		nullableRef(ut.ClearCategory, ut.CategoryID),
+		nullableRef(ut.ClearAsset, ut.AssetID),
+		nullableRef(ut.ClearRecurring, ut.RecurringID),
+		tags,
+	})
+}
+
+// UnmarshalJSON decodes ut, turning an explicit null reference into the
+// matching Clear flag so a decoded update sends the same request.
+func (ut *UpdateTransaction) UnmarshalJSON(data []byte) error {
+	type plain UpdateTransaction
+	var v struct {
+		plain
+		CategoryID  json.RawMessage `json:"category_id"`
+		AssetID     json.RawMessage `json:"asset_id"`
+		RecurringID json.RawMessage `json:"recurring_id"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*ut = UpdateTransaction(v.plain)
+	var err error
+	if ut.CategoryID, ut.ClearCategory, err = decodeRef[CategoryID](v.CategoryID); err != nil {
+		return fmt.Errorf("category_id: %w", err)
+	}
+	if ut.AssetID, ut.ClearAsset, err = decodeRef[AssetID](v.AssetID); err != nil {
+		return fmt.Errorf("asset_id: %w", err)
+	}
+	if ut.RecurringID, ut.ClearRecurring, err = decodeRef[RecurringID](v.RecurringID); err != nil {
+		return fmt.Errorf("recurring_id: %w", err)
+	}
+
+	return nil
+}
+
+// decodeRef is the inverse of nullableRef: an absent field leaves the
+// reference alone, null clears it and anything else sets it.
+func decodeRef[ID any](raw json.RawMessage) (*ID, bool, error) {
+	switch {
+	case raw == nil:
+		return nil, false, nil
+	case string(raw) == "null":
+		return nil, true, nil
+	}
+
+	id := new(ID)
+	if err := json.Unmarshal(raw, id); err != nil {
+		return nil, false, err
+	}
+
+	return id, false, nil
+}
+
+// nullableRef is the value sent for a reference: null to clear it, the ID to
+// set it, or nothing to leave it alone.
+func nullableRef[ID any](clear bool, id *ID) any {
+	switch {
+	case clear:
+		return json.RawMessage("null")
+	case id != nil:
+		return *id
+	default:
+		return nil
+	}
 }
 
 // UpdateRequest is the request body used to update a transaction in the Lunch Money API.
 // It wraps an UpdateTransaction object in the format expected by the API.
 type UpdateRequest struct {
 	Transaction *UpdateTransaction `json:"transaction"`
+	// Split, if set, splits the transaction into these parts. See
+	// Client.SplitTransaction.
+	Split []*SplitPart `json:"split,omitempty"`
 }
 
 // UpdateTransactionResp is the response received from the API when updating a transaction.
@@ -262,21 +646,76 @@ type UpdateTransactionResp struct {
 // UpdateTransaction modifies an existing transaction with the specified ID.
 // It takes an UpdateTransaction object with the fields to be updated.
 // Returns information about the update operation or an error if the update fails.
-func (c *Client) UpdateTransaction(ctx context.Context, id int64, ut *UpdateTransaction) (*UpdateTransactionResp, error) {
-	validate := validator.New(validator.WithRequiredStructEnabled())
-	if err := validate.Struct(ut); err != nil {
+func (c *Client) UpdateTransaction(ctx context.Context, id TransactionID, ut *UpdateTransaction) (*UpdateTransactionResp, error) {
+	if err := c.validateRequest(ctx, ut, validator.WithRequiredStructEnabled()); err != nil {
 		return nil, err
 	}
 
-	body, err := c.Put(ctx, fmt.Sprintf("/v1/transactions/%d", id), &UpdateRequest{Transaction: ut})
+	body, err := c.Put(ctx, c.endpoint("transactions/%d", id), &UpdateRequest{Transaction: ut})
 	if err != nil {
 		return nil, fmt.Errorf("update transaction %d: %w", id, err)
 	}
 
 	resp := &UpdateTransactionResp{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := c.decode(body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	return resp, nil
 }
+
+// ErrConflict is returned by UpdateTransactionIfUnchanged when the
+// transaction was modified by someone else after it was read.
+var ErrConflict = errors.New("transaction changed since it was read")
+
+// UpdateTransactionIfUnchanged is like UpdateTransaction but first re-fetches
+// the transaction and checks that every field ut is about to update still has
+// the value it had in original. If any of them changed, nothing is written
+// and an error wrapping ErrConflict is returned. This keeps interactive tools
+// and automated rules from silently overwriting each other's edits.
+//
+// The check and the update are separate requests, so a concurrent write in
+// between can still be lost; the window is just much smaller.
+func (c *Client) UpdateTransactionIfUnchanged(ctx context.Context, original *Transaction, ut *UpdateTransaction) (*UpdateTransactionResp, error) {
+	current, err := c.GetTransaction(ctx, original.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("re-read transaction %d: %w", original.ID, err)
+	}
+
+	if changed := ut.conflicts(original, current); len(changed) > 0 {
+		return nil, fmt.Errorf("update transaction %d: %v changed: %w", original.ID, changed, ErrConflict)
+	}
+
+	return c.UpdateTransaction(ctx, original.ID, ut)
+}
+
+// conflicts returns the JSON names of the fields ut sets whose value differs
+// between a and b.
+func (ut *UpdateTransaction) conflicts(a, b *Transaction) []string {
+	var ret []string
+	check := func(name string, set, same bool) {
+		if set && !same {
+			ret = append(ret, name)
+		}
+	}
+
+	check("date", ut.Date != nil, a.Date == b.Date)
+	check("category_id", ut.CategoryID != nil || ut.ClearCategory, equalPtr(a.CategoryID, b.CategoryID))
+	check("payee", ut.Payee != nil, a.Payee == b.Payee)
+	check("currency", ut.Currency != nil, a.Currency == b.Currency)
+	check("asset_id", ut.AssetID != nil || ut.ClearAsset, equalPtr(a.AssetID, b.AssetID))
+	check("recurring_id", ut.RecurringID != nil || ut.ClearRecurring, equalPtr(a.RecurringID, b.RecurringID))
+	check("notes", ut.Notes != nil, a.Notes == b.Notes)
+	check("status", ut.Status != nil, a.Status == b.Status)
+	check("external_id", ut.ExternalID != nil, a.ExternalID == b.ExternalID)
+
+	return ret
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -1,22 +1,31 @@
 package lunchmoney
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTransactionFilters_ToMap(t *testing.T) {
-	tagID := int64(1)
-	recurringID := int64(2)
-	plaidAccountID := int64(3)
-	categoryID := int64(4)
-	assetID := int64(5)
+	tagID := TagID(1)
+	recurringID := RecurringID(2)
+	plaidAccountID := PlaidAccountID(3)
+	categoryID := CategoryID(4)
+	assetID := AssetID(5)
 	offset := int64(10)
 	limit := int64(20)
-	startDate := "2023-01-01"
-	endDate := "2023-12-31"
+	startDate := NewDate(2023, 1, 1)
+	endDate := NewDate(2023, 12, 31)
 	debitAsNegative := true
+	externalID := "INV-2023-001"
 
 	tests := []struct {
 		name     string
@@ -36,6 +45,7 @@ func TestTransactionFilters_ToMap(t *testing.T) {
 				StartDate:       &startDate,
 				EndDate:         &endDate,
 				DebitAsNegative: &debitAsNegative,
+				ExternalID:      &externalID,
 			},
 			expected: map[string]string{
 				"tag_id":            "1",
@@ -48,6 +58,7 @@ func TestTransactionFilters_ToMap(t *testing.T) {
 				"start_date":        "2023-01-01",
 				"end_date":          "2023-12-31",
 				"debit_as_negative": "true",
+				"external_id":       "INV-2023-001",
 			},
 		},
 		{
@@ -78,3 +89,278 @@ func TestTransactionFilters_ToMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTransaction(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/9", r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		_, err := w.Write([]byte(`{"id": 9, "amount": "-4.50"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	txn, err := client.GetTransaction(context.Background(), 9, &GetTransactionOptions{DebitAsNegative: Ptr(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "-4.50", txn.Amount.String())
+
+	_, err = client.GetTransaction(context.Background(), 9, nil)
+	require.NoError(t, err)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, url.Values{"debit_as_negative": {"true"}}, queries[0])
+	assert.Empty(t, queries[1])
+}
+
+func TestGetTransactionGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/group", r.URL.Path)
+		assert.Equal(t, "12", r.URL.Query().Get("transaction_id"))
+		_, err := w.Write([]byte(`{"id": 12, "payee": "Trip", "amount": "30.00", "is_group": true, "children": [
+			{"id": 4, "payee": "Hotel", "amount": "20.00", "group_id": 12},
+			{"id": 5, "payee": "Taxi", "amount": "10.00", "group_id": 12}
+		]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	group, err := client.GetTransactionGroup(context.Background(), 12)
+	require.NoError(t, err)
+	assert.True(t, group.IsGroup)
+	assert.Equal(t, "Trip", group.Payee)
+	require.Len(t, group.Children, 2)
+	assert.Equal(t, TransactionID(5), group.Children[1].ID)
+	assert.Equal(t, TransactionID(12), *group.Children[1].GroupID)
+}
+
+func TestInsertTransaction(t *testing.T) {
+	var got []InsertTransactionsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/transactions", r.URL.Path)
+		var req InsertTransactionsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		got = append(got, req)
+		if req.SkipDuplicates {
+			_, _ = w.Write([]byte(`{"ids": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ids": [31]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	txn := &InsertTransaction{Date: MustParseDate("2024-05-01"), Amount: MustParseDecimal("12.00"), Payee: "Bakery"}
+	id, err := client.InsertTransaction(context.Background(), txn, InsertApplyRules())
+	require.NoError(t, err)
+	assert.Equal(t, TransactionID(31), id)
+
+	_, err = client.InsertTransaction(context.Background(), txn, InsertSkipDuplicates())
+	assert.ErrorIs(t, err, ErrNotInserted)
+
+	require.Len(t, got, 2)
+	assert.True(t, got[0].ApplyRules)
+	assert.False(t, got[0].SkipDuplicates)
+	require.Len(t, got[0].Transactions, 1)
+	assert.Equal(t, "Bakery", got[0].Transactions[0].Payee)
+
+	_, err = client.InsertTransaction(context.Background(), &InsertTransaction{Date: txn.Date, Status: "void"})
+	require.Error(t, err)
+	assert.Len(t, got, 2)
+}
+
+func TestTransaction_NullableReferences(t *testing.T) {
+	var uncategorized Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 1, "category_id": null, "asset_id": null}`), &uncategorized))
+	assert.False(t, uncategorized.HasCategory())
+	assert.False(t, uncategorized.HasAsset())
+	assert.False(t, uncategorized.InGroup())
+
+	var categorized Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 2, "category_id": 0, "parent_id": 7}`), &categorized))
+	assert.True(t, categorized.HasCategory())
+	assert.Equal(t, CategoryID(0), *categorized.CategoryID)
+	assert.True(t, categorized.IsSplit())
+}
+
+func TestUpdateTransaction_ClearReferences(t *testing.T) {
+	b, err := json.Marshal(&UpdateTransaction{ClearCategory: true, AssetID: Ptr(AssetID(0)), Notes: Ptr("x")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"category_id": null, "asset_id": 0, "notes": "x"}`, string(b))
+
+	b, err = json.Marshal(&UpdateTransaction{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(b))
+
+	b, err = json.Marshal(&UpdateTransaction{TagsIDs: []TagID{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tags": []}`, string(b))
+
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	_, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{CategoryID: Ptr(CategoryID(4)), ClearCategory: true})
+	assert.True(t, IsValidation(err))
+}
+
+func TestUpdateTransaction_RoundTrip(t *testing.T) {
+	for _, ut := range []*UpdateTransaction{
+		{ClearCategory: true, ClearAsset: true, ClearRecurring: true},
+		{CategoryID: Ptr(CategoryID(0)), AssetID: Ptr(AssetID(3)), Notes: Ptr("x")},
+		{TagsIDs: []TagID{}},
+		{TagsIDs: []TagID{4}},
+		{},
+	} {
+		b, err := json.Marshal(ut)
+		require.NoError(t, err)
+
+		var got UpdateTransaction
+		require.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, ut, &got, string(b))
+	}
+
+	var ut UpdateTransaction
+	assert.Error(t, json.Unmarshal([]byte(`{"category_id": "x"}`), &ut))
+}
+
+func TestUpdateTransactionIfUnchanged(t *testing.T) {
+	current := `{"id": 1, "payee": "Cafe", "notes": "edited elsewhere", "category_id": 4}`
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/1", r.URL.Path)
+		body := current
+		if r.Method == http.MethodPut {
+			puts++
+			body = `{"updated": true}`
+		}
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	original := &Transaction{ID: 1, Payee: "Cafe", Notes: "original", CategoryID: Ptr(CategoryID(4))}
+
+	_, err = client.UpdateTransactionIfUnchanged(context.Background(), original, &UpdateTransaction{Notes: Ptr("mine")})
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.Equal(t, 0, puts)
+
+	resp, err := client.UpdateTransactionIfUnchanged(context.Background(), original, &UpdateTransaction{CategoryID: Ptr(CategoryID(5))})
+	require.NoError(t, err)
+	assert.True(t, resp.Updated)
+	assert.Equal(t, 1, puts)
+}
+
+func TestTransaction_Tags(t *testing.T) {
+	var txn Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{"id": 1, "tags": [{"id": 4, "name": "travel"}, {"id": 9, "name": "work"}]}`), &txn))
+	require.Len(t, txn.Tags, 2)
+	assert.Equal(t, &Tag{ID: 4, Name: "travel"}, txn.Tags[0])
+	assert.True(t, txn.HasTag(9))
+	assert.False(t, txn.HasTag(5))
+}
+
+func TestModelStrings(t *testing.T) {
+	assert.Equal(t, "transaction 12 2024-03-02 Dinner 50.01 USD", (&Transaction{ID: 12, Date: MustParseDate("2024-03-02"), Payee: "Dinner", Amount: MustParseDecimal("50.01"), Currency: "usd"}).String())
+	assert.Equal(t, "category 3 Groceries", (&Category{ID: 3, Name: "Groceries"}).String())
+	assert.Equal(t, "category group 4 Food", (&Category{ID: 4, Name: "Food", IsGroup: true}).String())
+	assert.Equal(t, "asset 7 Wallet 20.00 USD", (&Asset{ID: 7, Name: "Wallet", Balance: MustParseDecimal("20.00"), Currency: "usd"}).String())
+	assert.Equal(t, "crypto 3 Cold wallet 0.5 BTC", (&Crypto{ID: 3, Name: "Cold wallet", Balance: MustParseDecimal("0.5"), Currency: "btc"}).String())
+	assert.Equal(t, "tag 9 travel", (&Tag{ID: 9, Name: "travel"}).String())
+	assert.Equal(t, "[tag 9 travel]", fmt.Sprint([]*Tag{{ID: 9, Name: "travel"}}))
+}
+
+func TestTransactionJSONRoundTrip(t *testing.T) {
+	in := `{
+		"id": 41, "date": "2024-03-02", "payee": "Cafe", "amount": "4.5000", "currency": "usd",
+		"to_base": 4.5, "notes": null, "category_id": null, "asset_id": 2, "plaid_account_id": null,
+		"recurring_id": null, "status": "cleared", "is_group": false, "group_id": null, "parent_id": null,
+		"external_id": null, "tags": [{"id": 1, "name": "coffee"}],
+		"original_name": "CAFE #123", "created_at": "2024-03-02T10:00:00.000Z", "plaid_metadata": {"pending": false}
+	}`
+
+	var txn Transaction
+	require.NoError(t, json.Unmarshal([]byte(in), &txn))
+	out, err := json.Marshal(txn)
+	require.NoError(t, err)
+	// Known fields are re-encoded the way the client sends them, unknown
+	// ones are kept as they were.
+	assert.JSONEq(t, `{
+		"id": 41, "date": "2024-03-02", "payee": "Cafe", "amount": "4.5000", "currency": "usd",
+		"to_base": "4.5", "notes": "", "category_id": null, "asset_id": 2, "plaid_account_id": null,
+		"recurring_id": null, "status": "cleared", "is_group": false, "group_id": null, "parent_id": null,
+		"external_id": "", "tags": [{"id": 1, "name": "coffee", "description": ""}],
+		"original_name": "CAFE #123", "created_at": "2024-03-02T10:00:00.000Z", "plaid_metadata": {"pending": false}
+	}`, string(out))
+
+	txn.Notes = "flat white"
+	txn.CategoryID = Ptr(CategoryID(9))
+	out, err = json.Marshal(&txn)
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, "flat white", got["notes"])
+	assert.Equal(t, float64(9), got["category_id"])
+	assert.Equal(t, "4.5", got["to_base"])
+	assert.Equal(t, "CAFE #123", got["original_name"])
+
+	fresh, err := json.Marshal(&Transaction{ID: 1, Amount: MustParseDecimal("2")})
+	require.NoError(t, err)
+	assert.NotContains(t, string(fresh), "original_name")
+}
+
+func TestTransactionGroupJSON(t *testing.T) {
+	in := `{"id": 12, "payee": "Trip", "is_group": true, "children": [{"id": 4, "payee": "Hotel", "group_id": 12}]}`
+
+	var g TransactionGroup
+	require.NoError(t, json.Unmarshal([]byte(in), &g))
+	assert.Equal(t, "Trip", g.Payee)
+	require.Len(t, g.Children, 1)
+
+	g.Children = append(g.Children, &Transaction{ID: 5})
+	out, err := json.Marshal(g)
+	require.NoError(t, err)
+	var got struct {
+		Payee    string         `json:"payee"`
+		Children []*Transaction `json:"children"`
+	}
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, "Trip", got.Payee)
+	assert.Len(t, got.Children, 2)
+}
+
+func BenchmarkGetTransactions(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`{"transactions": [`)
+	for i := range DefaultPageSize {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"id": %d, "date": "2024-01-02", "payee": "Shop %d", "amount": "12.3400", "currency": "usd", "to_base": 12.34, "notes": null, "category_id": 4, "asset_id": null, "plaid_account_id": 7, "status": "cleared", "is_group": false, "group_id": null, "parent_id": null, "external_id": null, "tags": [{"id": 1, "name": "food"}], "original_name": "SHOP", "created_at": "2024-01-02T10:00:00.000Z"}`, i, i)
+	}
+	sb.WriteString(`]}`)
+	body := sb.String()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	for range b.N {
+		_, err := client.GetTransactions(context.Background(), &TransactionFilters{Limit: Ptr(int64(DefaultPageSize))})
+		require.NoError(b, err)
+	}
+}
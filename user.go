@@ -2,7 +2,9 @@ package lunchmoney
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 )
 
 // User represents the authenticated user's profile information from the Lunch Money API.
@@ -19,15 +21,38 @@ type User struct {
 // GetUser retrieves information about the currently authenticated user.
 // It returns details such as user name, email, ID, and account preferences.
 func (c *Client) GetUser(ctx context.Context) (*User, error) {
-	body, err := c.Get(ctx, "/v1/me", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	resp := &User{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := c.getJSON(ctx, c.endpoint("me"), nil, resp); err != nil {
 		return nil, err
 	}
 
 	return resp, nil
 }
+
+// ErrInvalidToken is returned by Ping when the API rejects the access token,
+// either because it is wrong or because it has expired or been revoked.
+var ErrInvalidToken = errors.New("access token invalid or expired")
+
+// PingResult describes a successful health check.
+type PingResult struct {
+	User    *User
+	Latency time.Duration
+}
+
+// Ping checks that the API is reachable and the access token is valid by
+// fetching the current user. It returns the budget identity the token belongs
+// to and how long the round trip took. An error wrapping ErrInvalidToken
+// means retrying will not help.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	start := time.Now()
+	u, err := c.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, ErrUnauthorized) {
+			return nil, fmt.Errorf("ping: %w: %w", ErrInvalidToken, err)
+		}
+
+		return nil, fmt.Errorf("ping: %w", err)
+	}
+
+	return &PingResult{User: u, Latency: time.Since(start)}, nil
+}
@@ -0,0 +1,42 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/me", r.URL.Path)
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			_, _ = w.Write([]byte(`{"error": "Access token does not exist."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id": 1, "account_id": 2, "budget_name": "Household"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	res, err := client.Ping(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Household", res.User.BudgetName)
+	assert.Positive(t, res.Latency)
+
+	status = http.StatusUnauthorized
+	_, err = client.Ping(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	status = http.StatusInternalServerError
+	_, err = client.Ping(context.Background())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidToken)
+}
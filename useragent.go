@@ -0,0 +1,66 @@
+package lunchmoney
+
+import (
+	"errors"
+	"runtime/debug"
+	"strings"
+)
+
+const modulePath = "github.com/icco/lunchmoney"
+
+// version is the version of the package, such as "v1.4.0", as recorded in
+// the build information of the binary using it, or "devel" if it is unknown,
+// as in builds of the module itself.
+var version = moduleVersion()
+
+// defaultUserAgent is the User-Agent sent unless WithApplication adds to it.
+var defaultUserAgent = "lunchmoney-go/" + version
+
+func moduleVersion() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+
+	var m *debug.Module
+	if bi.Main.Path == modulePath {
+		m = &bi.Main
+	}
+	for _, dep := range bi.Deps {
+		if dep.Path == modulePath {
+			m = dep
+		}
+	}
+	if m == nil {
+		return "devel"
+	}
+	if m.Replace != nil {
+		m = m.Replace
+	}
+	if m.Version == "" || m.Version == "(devel)" {
+		return "devel"
+	}
+
+	return m.Version
+}
+
+// WithApplication appends an identifier of the application, such as
+// "budget-sync/1.2", to the client's "lunchmoney-go/<version>" User-Agent, so
+// problems seen by the API can be traced to the application and client
+// version that caused them. It replaces an identifier set before.
+func WithApplication(id string) Option {
+	return func(c *Client) error {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return errors.New("application ID is empty")
+		}
+
+		a, err := c.authTransport()
+		if err != nil {
+			return err
+		}
+
+		a.UserAgent = defaultUserAgent + " " + id
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ResponseValidation controls how records returned by the API are validated.
+type ResponseValidation int
+
+const (
+	// ValidateResponses fails the whole call if any record in the response
+	// is invalid. This is the default.
+	ValidateResponses ResponseValidation = iota
+	// SkipInvalidRecords drops invalid records from list responses and
+	// reports each one to the InvalidRecordFunc and the logger instead of
+	// failing the call. Single-record responses are returned as-is after
+	// being reported.
+	SkipInvalidRecords
+	// NoResponseValidation returns responses without validating them.
+	NoResponseValidation
+)
+
+// InvalidRecordFunc is called for every record that failed validation under
+// SkipInvalidRecords.
+type InvalidRecordFunc func(ctx context.Context, record any, err error)
+
+// WithResponseValidation sets how records returned by the API are validated.
+func WithResponseValidation(mode ResponseValidation) Option {
+	return func(c *Client) error {
+		if mode < ValidateResponses || mode > NoResponseValidation {
+			return fmt.Errorf("invalid response validation mode %d", mode)
+		}
+
+		c.responseValidation = mode
+		return nil
+	}
+}
+
+// WithInvalidRecordHandler sets a function that receives the records skipped
+// under SkipInvalidRecords.
+func WithInvalidRecordHandler(fn InvalidRecordFunc) Option {
+	return func(c *Client) error {
+		c.onInvalidRecord = fn
+		return nil
+	}
+}
+
+// WithValidation turns client-side validation of requests on or off. It is
+// on by default. Turned off, requests are sent as they are, so fields and
+// values the validator does not know about yet reach the API and any
+// mistakes are reported by the server instead. Responses are validated
+// according to WithResponseValidation either way.
+func WithValidation(enabled bool) Option {
+	return func(c *Client) error {
+		c.skipValidation = !enabled
+		return nil
+	}
+}
+
+// WithValidator validates requests and responses with v instead of a
+// validator of the client's own, so applications can add rules with
+// RegisterValidation or RegisterStructValidation and translate the
+// validator.ValidationErrors a ValidationError wraps. The client registers
+// its Date and Timestamp types and reports fields by their JSON names, so v
+// should not be shared with code relying on other field names. Options such
+// as validator.WithRequiredStructEnabled are those v was created with.
+func WithValidator(v *validator.Validate) Option {
+	return func(c *Client) error {
+		if v == nil {
+			return errors.New("validator is nil")
+		}
+
+		registerTypes(v)
+		c.validate = v
+		return nil
+	}
+}
+
+// validator returns the client's validator: the one given to WithValidator
+// or a new one created with opts.
+func (c *Client) validator(opts ...validator.Option) *validator.Validate {
+	if c.validate != nil {
+		return c.validate
+	}
+
+	return newValidator(opts...)
+}
+
+// fieldsValidator is implemented by requests with rules spanning several
+// fields, which struct tags cannot express.
+type fieldsValidator interface {
+	validate() error
+}
+
+// validateRequest validates a request before it is sent, returning a
+// ValidationError for invalid fields.
+func validateRequest(ctx context.Context, req any, opts ...validator.Option) error {
+	return validateRequestWith(ctx, newValidator(opts...), req)
+}
+
+func validateRequestWith(ctx context.Context, v *validator.Validate, req any) error {
+	if err := newValidationError(v.StructCtx(ctx, req)); err != nil {
+		return err
+	}
+	if v, ok := req.(fieldsValidator); ok {
+		return v.validate()
+	}
+
+	return nil
+}
+
+// validateRequest validates a request unless validation was turned off with
+// WithValidation.
+func (c *Client) validateRequest(ctx context.Context, req any, opts ...validator.Option) error {
+	if c.skipValidation {
+		return nil
+	}
+
+	return validateRequestWith(ctx, c.validator(opts...), req)
+}
+
+// validateDateRange checks the dates of a range filter, which the API
+// rejects with an unhelpful message. An end date needs a start date and,
+// if both is set, a start date needs an end date. The end must not be
+// before the start.
+func validateDateRange(start, end *Date, both bool) error {
+	switch {
+	case end != nil && start == nil:
+		return &ValidationError{Fields: []FieldError{{Field: "start_date", Constraint: "required_with", Param: "end_date", Value: ""}}}
+	case both && start != nil && end == nil:
+		return &ValidationError{Fields: []FieldError{{Field: "end_date", Constraint: "required_with", Param: "start_date", Value: ""}}}
+	case start != nil && end != nil && end.Before(*start):
+		return &ValidationError{Fields: []FieldError{{Field: "end_date", Constraint: "gtefield", Param: "start_date", Value: end.String()}}}
+	}
+
+	return nil
+}
+
+// validateRecord validates a single record of a response according to the
+// client's ResponseValidation. It only returns an error in the default mode.
+func (c *Client) validateRecord(ctx context.Context, rec any) error {
+	if c.responseValidation == NoResponseValidation {
+		return nil
+	}
+
+	err := c.validator().StructCtx(ctx, rec)
+	if err == nil || c.responseValidation == ValidateResponses {
+		return err
+	}
+
+	c.reportInvalid(ctx, rec, err)
+	return nil
+}
+
+// validateRecords validates each record of a list response according to the
+// client's ResponseValidation and returns the records to keep.
+func validateRecords[T any](ctx context.Context, c *Client, recs []*T) ([]*T, error) {
+	if c.responseValidation == NoResponseValidation {
+		return recs, nil
+	}
+
+	validate := c.validator()
+	ret := make([]*T, 0, len(recs))
+	for _, r := range recs {
+		err := validate.StructCtx(ctx, r)
+		if err == nil {
+			ret = append(ret, r)
+			continue
+		}
+
+		if c.responseValidation == ValidateResponses {
+			return nil, err
+		}
+
+		c.reportInvalid(ctx, r, err)
+	}
+
+	return ret, nil
+}
+
+func (c *Client) reportInvalid(ctx context.Context, rec any, err error) {
+	if c.logger != nil {
+		c.logger.WarnContext(ctx, "lunchmoney skipped invalid record", slog.String("type", fmt.Sprintf("%T", rec)), slog.Any("error", err))
+	}
+
+	if c.onInvalidRecord != nil {
+		c.onInvalidRecord(ctx, rec, err)
+	}
+}
+
+// responseValidationError describes an error from validating a response.
+func responseValidationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	var invalidValidationError *validator.InvalidValidationError
+
+	switch {
+	case errors.As(err, &validationErrors):
+		return fmt.Errorf("validating response: %s", validationErrors.Error())
+	case errors.As(err, &invalidValidationError):
+		return fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
+	default:
+		return fmt.Errorf("validating response (%T): %w", err, err)
+	}
+}
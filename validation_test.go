@@ -0,0 +1,127 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedRecord struct {
+	Name string `validate:"required"`
+}
+
+func TestValidateRecords(t *testing.T) {
+	ctx := context.Background()
+	recs := []*validatedRecord{{Name: "a"}, {}, {Name: "c"}}
+
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	_, err = validateRecords(ctx, client, recs)
+	require.Error(t, err)
+
+	var skipped []any
+	skip, err := client.With(
+		WithResponseValidation(SkipInvalidRecords),
+		WithInvalidRecordHandler(func(_ context.Context, rec any, err error) {
+			assert.Error(t, err)
+			skipped = append(skipped, rec)
+		}),
+	)
+	require.NoError(t, err)
+	got, err := validateRecords(ctx, skip, recs)
+	require.NoError(t, err)
+	assert.Equal(t, []*validatedRecord{{Name: "a"}, {Name: "c"}}, got)
+	assert.Equal(t, []any{recs[1]}, skipped)
+	require.NoError(t, skip.validateRecord(ctx, &validatedRecord{}))
+	assert.Len(t, skipped, 2)
+
+	off, err := client.With(WithResponseValidation(NoResponseValidation))
+	require.NoError(t, err)
+	got, err = validateRecords(ctx, off, recs)
+	require.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	_, err = NewClient("test-token", WithResponseValidation(ResponseValidation(7)))
+	require.Error(t, err)
+}
+
+func TestValidateDateRange(t *testing.T) {
+	start, end := Ptr(MustParseDate("2024-01-01")), Ptr(MustParseDate("2024-01-31"))
+
+	assert.NoError(t, validateDateRange(nil, nil, true))
+	assert.NoError(t, validateDateRange(start, end, true))
+	assert.NoError(t, validateDateRange(start, start, true))
+	assert.NoError(t, validateDateRange(start, nil, false))
+	assert.EqualError(t, validateDateRange(start, nil, true), "invalid request: end_date: failed required_with=start_date (got )")
+	assert.EqualError(t, validateDateRange(nil, end, false), "invalid request: start_date: failed required_with=end_date (got )")
+	assert.EqualError(t, validateDateRange(end, start, false), "invalid request: end_date: failed gtefield=start_date (got 2024-01-01)")
+
+	client, err := NewClient("test-token", WithDryRun(true))
+	require.NoError(t, err)
+	_, err = client.GetTransactions(context.Background(), &TransactionFilters{StartDate: start})
+	assert.ErrorIs(t, err, ErrValidation)
+	_, err = client.GetBudgets(context.Background(), &BudgetFilters{StartDate: *end, EndDate: *start})
+	assert.ErrorIs(t, err, ErrValidation)
+	_, err = client.RefreshPlaidAccounts(context.Background(), &RefreshPlaidAccounts{EndDate: end})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestWithValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "Invalid status"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ut := &UpdateTransaction{Status: Ptr("reconciled")}
+	_, err = client.UpdateTransaction(context.Background(), 1, ut)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, 0, requests)
+
+	raw, err := client.With(WithValidation(false))
+	require.NoError(t, err)
+	_, err = raw.UpdateTransaction(context.Background(), 1, ut)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.ErrorContains(t, err, "Invalid status")
+	assert.Equal(t, 1, requests)
+}
+
+func TestWithValidator(t *testing.T) {
+	v := validator.New()
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		if ut := sl.Current().Interface().(UpdateTransaction); ut.Notes != nil && strings.Contains(*ut.Notes, "TODO") {
+			sl.ReportError(ut.Notes, "notes", "Notes", "no_todo", "")
+		}
+	}, UpdateTransaction{})
+
+	client, err := NewClient("test-token", WithDryRun(true), WithValidator(v))
+	require.NoError(t, err)
+
+	_, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Notes: Ptr("TODO: split"), Status: Ptr("void")})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Fields, 2)
+	assert.ErrorContains(t, err, "notes: failed no_todo")
+
+	var verrs validator.ValidationErrors
+	require.ErrorAs(t, err, &verrs)
+	assert.Len(t, verrs, 2)
+
+	_, err = client.UpdateTransaction(context.Background(), 1, &UpdateTransaction{Date: Ptr(MustParseDate("2024-01-02"))})
+	require.NoError(t, err)
+
+	_, err = NewClient("test-token", WithValidator(nil))
+	require.Error(t, err)
+}
@@ -0,0 +1,292 @@
+// Package watch polls Lunch Money for transaction changes and reports them as
+// events, for notification bots and similar consumers.
+package watch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/events"
+)
+
+// Fetcher is the subset of *lunchmoney.Client the watcher needs.
+type Fetcher interface {
+	GetTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error)
+}
+
+// BalanceFetcher is the subset of *lunchmoney.Client the watcher needs to
+// report BalanceChanged events.
+type BalanceFetcher interface {
+	GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error)
+	GetPlaidAccounts(ctx context.Context) ([]*lunchmoney.PlaidAccount, error)
+	GetCrypto(ctx context.Context) ([]*lunchmoney.Crypto, error)
+}
+
+// BudgetFetcher is the subset of *lunchmoney.Client the watcher needs to
+// report BudgetExceeded events.
+type BudgetFetcher interface {
+	GetBudgets(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+}
+
+// Options configure a Watcher.
+type Options struct {
+	// Interval between polls. Defaults to five minutes.
+	Interval time.Duration
+	// Lookback is how many days before today each poll covers. Changes to
+	// older transactions are not noticed. Defaults to 30.
+	Lookback int
+	// EmitInitial reports every transaction found by the first poll as
+	// created. By default the first poll only records what exists.
+	EmitInitial bool
+	// OnError, if set, receives poll errors. The watcher keeps polling.
+	OnError func(error)
+
+	// Balances, if set, is polled too, and a BalanceChanged event is
+	// emitted when the balance of an asset, Plaid account or crypto
+	// holding differs from the previous poll.
+	Balances BalanceFetcher
+	// Budgets, if set, is polled too, and a BudgetExceeded event is
+	// emitted the first time spending in a category passes its budget for
+	// the current month.
+	Budgets BudgetFetcher
+	// Currency is the user's primary currency, which budget amounts are
+	// in. It is reported in BudgetExceeded events.
+	Currency string
+}
+
+// Watcher polls for transactions and emits TransactionCreated,
+// TransactionUpdated and StatusChanged events, and BalanceChanged and
+// BudgetExceeded events if Options.Balances and Options.Budgets are set.
+type Watcher struct {
+	fetcher Fetcher
+	opts    Options
+	events  chan events.Event
+	today   func() lunchmoney.Date
+
+	// seen holds the transactions returned by the last poll. Transactions
+	// that fall out of the lookback window are dropped from it.
+	seen   map[lunchmoney.TransactionID]events.Transaction
+	primed bool
+	// balances holds the balances returned by the last poll, nil before
+	// the first one.
+	balances map[account]lunchmoney.Decimal
+	// exceeded holds the category budgets already reported as exceeded,
+	// nil before the first poll.
+	exceeded map[budgetMonth]bool
+}
+
+type account struct {
+	kind string
+	id   int64
+}
+
+type budgetMonth struct {
+	category lunchmoney.CategoryID
+	month    lunchmoney.Date
+}
+
+// New creates a watcher reading from f.
+func New(f Fetcher, opts Options) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Minute
+	}
+	if opts.Lookback <= 0 {
+		opts.Lookback = 30
+	}
+
+	return &Watcher{
+		fetcher: f,
+		opts:    opts,
+		events:  make(chan events.Event),
+		today:   lunchmoney.Today,
+		seen:    map[lunchmoney.TransactionID]events.Transaction{},
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed when Run
+// returns. Polling waits for events to be received, so a slow consumer slows
+// the watcher down rather than losing events.
+func (w *Watcher) Events() <-chan events.Event {
+	return w.events
+}
+
+// Run polls immediately and then every interval until ctx is done. It must
+// only be called once.
+func (w *Watcher) Run(ctx context.Context) {
+	defer close(w.events)
+
+	ctx = lunchmoney.WithDefaultOperation(ctx, "watch")
+
+	t := time.NewTicker(w.opts.Interval)
+	defer t.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil && ctx.Err() == nil && w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context) error {
+	err := w.pollTransactions(ctx)
+	if w.opts.Balances != nil && ctx.Err() == nil {
+		err = errors.Join(err, w.pollBalances(ctx))
+	}
+	if w.opts.Budgets != nil && ctx.Err() == nil {
+		err = errors.Join(err, w.pollBudgets(ctx))
+	}
+
+	return err
+}
+
+func (w *Watcher) pollTransactions(ctx context.Context) error {
+	end := w.today()
+	start := end.AddDays(-w.opts.Lookback)
+	txns, err := w.fetcher.GetTransactions(ctx, &lunchmoney.TransactionFilters{StartDate: &start, EndDate: &end})
+	if err != nil {
+		return err
+	}
+
+	emit := w.primed || w.opts.EmitInitial
+	w.primed = true
+	seen := make(map[lunchmoney.TransactionID]events.Transaction, len(txns))
+	for _, t := range txns {
+		cur := events.FromTransaction(t)
+		prev, ok := w.seen[t.ID]
+		seen[t.ID] = cur
+		if !emit {
+			continue
+		}
+
+		if !ok {
+			if !w.send(ctx, &events.TransactionCreated{Transaction: cur}) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		changed := events.Changed(prev, cur)
+		if len(changed) == 0 {
+			continue
+		}
+		if !w.send(ctx, &events.TransactionUpdated{Before: prev, After: cur, Changed: changed}) {
+			return ctx.Err()
+		}
+		if prev.Status != cur.Status {
+			if !w.send(ctx, &events.StatusChanged{Transaction: cur, Previous: prev.Status, Current: cur.Status}) {
+				return ctx.Err()
+			}
+		}
+	}
+	w.seen = seen
+
+	return nil
+}
+
+func (w *Watcher) pollBalances(ctx context.Context) error {
+	assets, err := w.opts.Balances.GetAssets(ctx)
+	if err != nil {
+		return err
+	}
+	plaid, err := w.opts.Balances.GetPlaidAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	crypto, err := w.opts.Balances.GetCrypto(ctx)
+	if err != nil {
+		return err
+	}
+
+	var changes []*events.BalanceChanged
+	balances := map[account]lunchmoney.Decimal{}
+	check := func(kind string, id int64, name string, balance lunchmoney.Decimal, currency string) {
+		key := account{kind, id}
+		balances[key] = balance
+		if prev, ok := w.balances[key]; ok && prev.Cmp(balance) != 0 {
+			changes = append(changes, &events.BalanceChanged{AccountType: kind, AccountID: id, Name: name, Previous: prev, Current: balance, Currency: currency})
+		}
+	}
+	for _, a := range assets {
+		check(events.AccountAsset, int64(a.ID), a.Name, a.Balance, a.Currency)
+	}
+	for _, p := range plaid {
+		check(events.AccountPlaidAccount, int64(p.ID), p.Name, p.Balance, p.Currency)
+	}
+	for _, c := range crypto {
+		check(events.AccountCrypto, int64(c.ID), c.Name, c.Balance, c.Currency)
+	}
+
+	for _, e := range changes {
+		if !w.send(ctx, e) {
+			return ctx.Err()
+		}
+	}
+	w.balances = balances
+
+	return nil
+}
+
+func (w *Watcher) pollBudgets(ctx context.Context) error {
+	today := w.today()
+	start := lunchmoney.NewDate(today.Year, today.Month, 1)
+	end := start.AddMonths(1).AddDays(-1)
+	budgets, err := w.opts.Budgets.GetBudgets(ctx, &lunchmoney.BudgetFilters{StartDate: start, EndDate: end})
+	if err != nil {
+		return err
+	}
+
+	emit := w.exceeded != nil || w.opts.EmitInitial
+	if w.exceeded == nil {
+		w.exceeded = map[budgetMonth]bool{}
+	}
+	// Only the current month is polled, so earlier ones can be forgotten.
+	for k := range w.exceeded {
+		if k.month != start {
+			delete(w.exceeded, k)
+		}
+	}
+	for _, b := range budgets {
+		if b.IsGroup || b.IsIncome {
+			continue
+		}
+		bd, ok := b.Month(start)
+		if !ok || bd.BudgetToBase.Sign() <= 0 || bd.SpendingToBase.Cmp(bd.BudgetToBase) <= 0 {
+			continue
+		}
+
+		key := budgetMonth{b.CategoryID, start}
+		if w.exceeded[key] {
+			continue
+		}
+		if emit && !w.send(ctx, &events.BudgetExceeded{
+			CategoryID:   b.CategoryID,
+			CategoryName: b.CategoryName,
+			Month:        start.String(),
+			Budgeted:     bd.BudgetToBase,
+			Spent:        bd.SpendingToBase,
+			Currency:     w.opts.Currency,
+		}) {
+			return ctx.Err()
+		}
+		w.exceeded[key] = true
+	}
+
+	return nil
+}
+
+func (w *Watcher) send(ctx context.Context, e events.Event) bool {
+	select {
+	case w.events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,213 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	mu    sync.Mutex
+	polls [][]*lunchmoney.Transaction
+	calls int
+}
+
+func (f *fakeFetcher) GetTransactions(context.Context, *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.calls
+	f.calls++
+	if i >= len(f.polls) {
+		return nil, errors.New("no more polls")
+	}
+
+	return f.polls[i], nil
+}
+
+func TestWatcher(t *testing.T) {
+	f := &fakeFetcher{polls: [][]*lunchmoney.Transaction{
+		{{ID: 1, Payee: "Coffee", Status: "uncleared"}},
+		{{ID: 1, Payee: "Coffee", Status: "cleared"}, {ID: 2, Payee: "Rent"}},
+	}}
+
+	w := New(f, Options{Interval: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	var got []events.Event
+	for e := range w.Events() {
+		got = append(got, e)
+		if len(got) == 3 {
+			cancel()
+		}
+	}
+
+	require.Len(t, got, 3)
+	updated, ok := got[0].(*events.TransactionUpdated)
+	require.True(t, ok)
+	assert.Equal(t, []string{"status"}, updated.Changed)
+	status, ok := got[1].(*events.StatusChanged)
+	require.True(t, ok)
+	assert.Equal(t, "uncleared", status.Previous)
+	assert.Equal(t, "cleared", status.Current)
+	created, ok := got[2].(*events.TransactionCreated)
+	require.True(t, ok)
+	assert.Equal(t, lunchmoney.TransactionID(2), created.Transaction.ID)
+}
+
+func TestWatcherEmitInitial(t *testing.T) {
+	f := &fakeFetcher{polls: [][]*lunchmoney.Transaction{{{ID: 1}}}}
+	w := New(f, Options{Interval: time.Hour, EmitInitial: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go w.Run(ctx)
+
+	e := <-w.Events()
+	cancel()
+	_, ok := e.(*events.TransactionCreated)
+	assert.True(t, ok)
+	for range w.Events() {
+	}
+}
+
+func TestWatcherForgetsOldTransactions(t *testing.T) {
+	f := &fakeFetcher{polls: [][]*lunchmoney.Transaction{
+		{{ID: 1}, {ID: 2}},
+		{{ID: 2, Tags: []*lunchmoney.Tag{{ID: 5}}}},
+	}}
+	w := New(f, Options{})
+
+	require.NoError(t, w.poll(context.Background()))
+	assert.Len(t, w.seen, 2)
+
+	done := make(chan struct{})
+	var got []events.Event
+	go func() {
+		defer close(done)
+		for e := range w.Events() {
+			got = append(got, e)
+		}
+	}()
+	require.NoError(t, w.poll(context.Background()))
+	close(w.events)
+	<-done
+
+	// Transaction 1 fell out of the lookback window.
+	assert.Len(t, w.seen, 1)
+	require.Len(t, got, 1)
+	assert.Equal(t, []string{"tag_ids"}, got[0].(*events.TransactionUpdated).Changed)
+}
+
+type fakeAccounts struct {
+	assets []*lunchmoney.Asset
+	crypto []*lunchmoney.Crypto
+	spent  lunchmoney.Decimal
+	month  lunchmoney.Date
+}
+
+func (f *fakeAccounts) GetAssets(context.Context) ([]*lunchmoney.Asset, error) {
+	return f.assets, nil
+}
+
+func (f *fakeAccounts) GetPlaidAccounts(context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) GetCrypto(context.Context) ([]*lunchmoney.Crypto, error) {
+	return f.crypto, nil
+}
+
+func (f *fakeAccounts) GetBudgets(_ context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	f.month = filters.StartDate
+	return []*lunchmoney.Budget{{
+		CategoryID:   4,
+		CategoryName: "Dining",
+		Data: map[string]*lunchmoney.BudgetData{
+			"2024-03-01": {BudgetMonth: lunchmoney.NewDate(2024, 3, 1), BudgetToBase: lunchmoney.MustParseDecimal("100.00"), SpendingToBase: f.spent},
+		},
+	}}, nil
+}
+
+func TestWatcherBalancesAndBudgets(t *testing.T) {
+	f := &fakeAccounts{
+		assets: []*lunchmoney.Asset{{ID: 1, Name: "Wallet", Balance: lunchmoney.MustParseDecimal("20.00"), Currency: "usd"}},
+		crypto: []*lunchmoney.Crypto{{ID: 2, Name: "Cold", Balance: lunchmoney.MustParseDecimal("0.5"), Currency: "btc"}},
+		spent:  lunchmoney.MustParseDecimal("120.00"),
+	}
+	w := New(&fakeFetcher{polls: [][]*lunchmoney.Transaction{{}, {}, {}}}, Options{Balances: f, Budgets: f, Currency: "usd"})
+	w.today = func() lunchmoney.Date { return lunchmoney.NewDate(2024, 3, 15) }
+
+	done := make(chan struct{})
+	var got []events.Event
+	go func() {
+		defer close(done)
+		for e := range w.Events() {
+			got = append(got, e)
+		}
+	}()
+
+	// The first poll records what exists, including a budget that is
+	// already exceeded.
+	require.NoError(t, w.poll(context.Background()))
+	assert.Equal(t, lunchmoney.NewDate(2024, 3, 1), f.month)
+
+	f.assets[0].Balance = lunchmoney.MustParseDecimal("15.50")
+	require.NoError(t, w.poll(context.Background()))
+
+	// A new month starts over; the budget is reported once.
+	w.today = func() lunchmoney.Date { return lunchmoney.NewDate(2024, 4, 2) }
+	require.NoError(t, w.poll(context.Background()))
+	close(w.events)
+	<-done
+
+	require.Len(t, got, 1)
+	balance, ok := got[0].(*events.BalanceChanged)
+	require.True(t, ok)
+	assert.Equal(t, events.AccountAsset, balance.AccountType)
+	assert.Equal(t, "20.00", balance.Previous.String())
+	assert.Equal(t, "15.50", balance.Current.String())
+}
+
+func TestWatcherBudgetExceeded(t *testing.T) {
+	f := &fakeAccounts{spent: lunchmoney.MustParseDecimal("80.00")}
+	w := New(&fakeFetcher{polls: [][]*lunchmoney.Transaction{{}, {}, {}}}, Options{Budgets: f, Currency: "usd"})
+	w.today = func() lunchmoney.Date { return lunchmoney.NewDate(2024, 3, 15) }
+
+	done := make(chan struct{})
+	var got []events.Event
+	go func() {
+		defer close(done)
+		for e := range w.Events() {
+			got = append(got, e)
+		}
+	}()
+
+	require.NoError(t, w.poll(context.Background()))
+	f.spent = lunchmoney.MustParseDecimal("100.01")
+	require.NoError(t, w.poll(context.Background()))
+	require.NoError(t, w.poll(context.Background()))
+	close(w.events)
+	<-done
+
+	require.Len(t, got, 1)
+	exceeded, ok := got[0].(*events.BudgetExceeded)
+	require.True(t, ok)
+	assert.Equal(t, &events.BudgetExceeded{
+		CategoryID:   4,
+		CategoryName: "Dining",
+		Month:        "2024-03-01",
+		Budgeted:     lunchmoney.MustParseDecimal("100.00"),
+		Spent:        lunchmoney.MustParseDecimal("100.01"),
+		Currency:     "usd",
+	}, exceeded)
+}